@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path"
+	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/viper"
@@ -18,25 +19,59 @@ type General struct {
 type SetlistFM struct {
 	APIKey  string `mapstructure:"api_key"`
 	BaseURL string `mapstructure:"base_url"`
+	WebURL  string `mapstructure:"web_url"`
 	Timeout int    `mapstructure:"timeout_ms"`
 }
 
 type Spotify struct {
-	ClientID     string `mapstructure:"client_id"`
-	ClientSecret string `mapstructure:"client_secret"`
-	RedirectURL  string `mapstructure:"redirect_url"`
+	ClientID          string `mapstructure:"client_id"`
+	ClientSecret      string `mapstructure:"client_secret"`
+	RedirectURL       string `mapstructure:"redirect_url"`
+	SearchConcurrency int    `mapstructure:"search_concurrency"`
+	Market            string `mapstructure:"market"`
+}
+
+type Matching struct {
+	Threshold         float64  `mapstructure:"threshold"`
+	Candidates        int      `mapstructure:"candidates"`
+	VersionPreference string   `mapstructure:"version_preference"`
+	Exclude           []string `mapstructure:"exclude"`
+	Covers            string   `mapstructure:"covers"`
+}
+
+type Playlist struct {
+	Private       bool   `mapstructure:"private"`
+	Collaborative bool   `mapstructure:"collaborative"`
+	Title         string `mapstructure:"title"`
+	Description   string `mapstructure:"description"`
+	GenerateCover bool   `mapstructure:"generate_cover"`
+	Sets          string `mapstructure:"sets"`
+	Merge         string `mapstructure:"merge"`
+	Festival      string `mapstructure:"festival"`
+}
+
+type Cache struct {
+	Enabled bool          `mapstructure:"enabled"`
+	TTL     time.Duration `mapstructure:"ttl"`
 }
 
 type Config struct {
 	General   `mapstructure:"general"`
 	SetlistFM `mapstructure:"setlistfm"`
 	Spotify   `mapstructure:"spotify"`
+	Matching  `mapstructure:"matching"`
+	Playlist  `mapstructure:"playlist"`
+	Cache     `mapstructure:"cache"`
 }
 
 type ConfigPaths struct {
 	AppConfigDir    string
 	AppConfigFile   string
 	SpotifyAuthFile string
+	OverridesFile   string
+	MatchCacheFile  string
+	PlaylistsFile   string
+	HistoryFile     string
 }
 
 func Init() (*ConfigPaths, error) {
@@ -50,6 +85,10 @@ func Init() (*ConfigPaths, error) {
 	appConfigDirPath := path.Join(userConfigDir, "setlist-to-playlist")
 	appConfigFilePath := path.Join(appConfigDirPath, "config.toml")
 	spotifyAuthFilePath := path.Join(appConfigDirPath, "spotify_auth.json")
+	overridesFilePath := path.Join(appConfigDirPath, "overrides.toml")
+	matchCacheFilePath := path.Join(appConfigDirPath, "cache.json")
+	playlistsFilePath := path.Join(appConfigDirPath, "playlists.json")
+	historyFilePath := path.Join(appConfigDirPath, "history.json")
 
 	if err := fsDriver.CreateDir(appConfigDirPath, 0750); err != nil {
 		return nil, err
@@ -61,10 +100,38 @@ func Init() (*ConfigPaths, error) {
 		}
 	}
 
+	if exists := fsDriver.Exists(overridesFilePath); !exists {
+		if err := fsDriver.Write(overridesFilePath, []byte{}, 0660); err != nil {
+			return nil, err
+		}
+	}
+
+	if exists := fsDriver.Exists(matchCacheFilePath); !exists {
+		if err := fsDriver.Write(matchCacheFilePath, []byte("{}"), 0660); err != nil {
+			return nil, err
+		}
+	}
+
+	if exists := fsDriver.Exists(playlistsFilePath); !exists {
+		if err := fsDriver.Write(playlistsFilePath, []byte("{}"), 0660); err != nil {
+			return nil, err
+		}
+	}
+
+	if exists := fsDriver.Exists(historyFilePath); !exists {
+		if err := fsDriver.Write(historyFilePath, []byte("{}"), 0660); err != nil {
+			return nil, err
+		}
+	}
+
 	return &ConfigPaths{
 		AppConfigDir:    appConfigDirPath,
 		AppConfigFile:   appConfigFilePath,
 		SpotifyAuthFile: spotifyAuthFilePath,
+		OverridesFile:   overridesFilePath,
+		MatchCacheFile:  matchCacheFilePath,
+		PlaylistsFile:   playlistsFilePath,
+		HistoryFile:     historyFilePath,
 	}, nil
 }
 
@@ -79,8 +146,23 @@ func Load(configPaths ConfigPaths) (*Config, error) {
 	viper.SetDefault("general.log_level", "info")
 	viper.SetDefault("general.webserver_port", 8080)
 	viper.SetDefault("setlistfm.base_url", "https://api.setlist.fm/rest")
+	viper.SetDefault("setlistfm.web_url", "https://www.setlist.fm")
 	viper.SetDefault("setlistfm.timeout_ms", 3000)
 	viper.SetDefault("spotify.redirect_url", "http://localhost:8080/callback")
+	viper.SetDefault("spotify.search_concurrency", 5)
+	viper.SetDefault("matching.threshold", 0.8)
+	viper.SetDefault("matching.candidates", 10)
+	viper.SetDefault("matching.version_preference", "any")
+	viper.SetDefault("matching.exclude", []string{"karaoke", "instrumental", "tribute"})
+	viper.SetDefault("matching.covers", "fallback")
+	viper.SetDefault("playlist.private", false)
+	viper.SetDefault("playlist.collaborative", false)
+	viper.SetDefault("playlist.generate_cover", false)
+	viper.SetDefault("playlist.sets", "combined")
+	viper.SetDefault("playlist.merge", "concat")
+	viper.SetDefault("playlist.festival", "combined")
+	viper.SetDefault("cache.enabled", true)
+	viper.SetDefault("cache.ttl", "168h")
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -1,7 +1,10 @@
 package setlistfm
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/httpclient"
@@ -9,6 +12,10 @@ import (
 
 type SetlistFMClientInterface interface {
 	GetSetlistByID(setlistID string) (*setlistfm.Set, error)
+	SearchArtists(name string, page int) (*setlistfm.ArtistPage, error)
+	GetArtist(mbid string) (*setlistfm.Artist, error)
+	GetArtistSetlists(mbid string, page int) (*setlistfm.SetlistPage, error)
+	SearchSetlists(input setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error)
 }
 
 type SetlistFMClient struct {
@@ -17,7 +24,11 @@ type SetlistFMClient struct {
 }
 
 var (
-	GetSetlistByIDPath = "/1.0/setlist/%s"
+	GetSetlistByIDPath    = "/1.0/setlist/%s"
+	SearchArtistsPath     = "/1.0/search/artists?%s"
+	GetArtistPath         = "/1.0/artist/%s"
+	GetArtistSetlistsPath = "/1.0/artist/%s/setlists?p=%d"
+	SearchSetlistsPath    = "/1.0/search/setlists?%s"
 )
 
 func NewSetlistFMClient(httpClient httpclient.HttpClientInterface, apiKey string) SetlistFMClientInterface {
@@ -30,14 +41,93 @@ func NewSetlistFMClient(httpClient httpclient.HttpClientInterface, apiKey string
 func (c *SetlistFMClient) GetSetlistByID(id string) (*setlistfm.Set, error) {
 	var setlist setlistfm.Set
 
-	headers := map[string]interface{}{
-		"x-api-key": c.APIKey,
+	err := c.HttpClient.Get(fmt.Sprintf(GetSetlistByIDPath, id), c.headers(), &setlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &setlist, nil
+}
+
+// SearchArtists looks artists up by name, the most relevant first.
+func (c *SetlistFMClient) SearchArtists(name string, page int) (*setlistfm.ArtistPage, error) {
+	var artists setlistfm.ArtistPage
+
+	query := url.Values{
+		"artistName": {name},
+		"p":          {fmt.Sprint(page)},
+		"sort":       {"relevance"},
+	}
+
+	err := c.HttpClient.Get(fmt.Sprintf(SearchArtistsPath, query.Encode()), c.headers(), &artists)
+	if isNotFound(err) {
+		return &setlistfm.ArtistPage{Page: page}, nil
 	}
 
-	err := c.HttpClient.Get(fmt.Sprintf(GetSetlistByIDPath, id), headers, &setlist)
 	if err != nil {
 		return nil, err
 	}
 
-	return &setlist, nil
+	return &artists, nil
+}
+
+// GetArtist returns the artist with the given MusicBrainz ID.
+func (c *SetlistFMClient) GetArtist(mbid string) (*setlistfm.Artist, error) {
+	var artist setlistfm.Artist
+
+	err := c.HttpClient.Get(fmt.Sprintf(GetArtistPath, url.PathEscape(mbid)), c.headers(), &artist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &artist, nil
+}
+
+// GetArtistSetlists returns a page of the setlists of an artist, newest first.
+func (c *SetlistFMClient) GetArtistSetlists(mbid string, page int) (*setlistfm.SetlistPage, error) {
+	var setlists setlistfm.SetlistPage
+
+	err := c.HttpClient.Get(fmt.Sprintf(GetArtistSetlistsPath, url.PathEscape(mbid), page), c.headers(), &setlists)
+	if isNotFound(err) {
+		return &setlistfm.SetlistPage{Page: page}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &setlists, nil
+}
+
+// SearchSetlists returns a page of the setlists matching the search, newest first.
+func (c *SetlistFMClient) SearchSetlists(input setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error) {
+	query, err := input.Query()
+	if err != nil {
+		return nil, err
+	}
+
+	var setlists setlistfm.SetlistPage
+
+	err = c.HttpClient.Get(fmt.Sprintf(SearchSetlistsPath, query.Encode()), c.headers(), &setlists)
+	if isNotFound(err) {
+		return &setlistfm.SetlistPage{Page: input.Page}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &setlists, nil
+}
+
+func (c *SetlistFMClient) headers() map[string]interface{} {
+	return map[string]interface{}{
+		"x-api-key": c.APIKey,
+	}
+}
+
+// isNotFound tells whether Setlist.fm answered 404, which it does for searches without results.
+func isNotFound(err error) bool {
+	var statusErr *httpclient.StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
 }
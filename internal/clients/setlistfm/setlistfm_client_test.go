@@ -2,11 +2,14 @@ package setlistfm
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/httpclient"
 	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
 )
 
@@ -61,3 +64,132 @@ func (s *SetlistFMClientTestSuite) TestGetSetlistByID() {
 		s.Nil(result)
 	})
 }
+
+func (s *SetlistFMClientTestSuite) TestSearchArtists() {
+	headers := map[string]interface{}{"x-api-key": "any-api-key"}
+
+	s.Run("Should search artists by name", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.
+			On("Get", "/1.0/search/artists?artistName=Guns+N%27+Roses&p=1&sort=relevance", headers, &setlistfm.ArtistPage{}).
+			Return(nil)
+
+		result, err := s.SetlistFMClient.SearchArtists("Guns N' Roses", 1)
+
+		s.NoError(err)
+		s.NotNil(result)
+	})
+
+	s.Run("Should return an empty page when nothing is found", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.
+			On("Get", mock.Anything, headers, &setlistfm.ArtistPage{}).
+			Return(&httpclient.StatusError{StatusCode: http.StatusNotFound})
+
+		result, err := s.SetlistFMClient.SearchArtists("any-artist", 1)
+
+		s.NoError(err)
+		s.Empty(result.Artist)
+	})
+}
+
+func (s *SetlistFMClientTestSuite) TestGetArtist() {
+	headers := map[string]interface{}{"x-api-key": "any-api-key"}
+
+	s.Run("Should return the artist", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.On("Get", "/1.0/artist/any-mbid", headers, &setlistfm.Artist{}).Return(nil)
+
+		result, err := s.SetlistFMClient.GetArtist("any-mbid")
+
+		s.NoError(err)
+		s.NotNil(result)
+	})
+
+	s.Run("Should return an error when http client fails", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.
+			On("Get", "/1.0/artist/any-mbid", headers, &setlistfm.Artist{}).
+			Return(&httpclient.StatusError{StatusCode: http.StatusNotFound})
+
+		result, err := s.SetlistFMClient.GetArtist("any-mbid")
+
+		s.ErrorContains(err, "unexpected status code [404]")
+		s.Nil(result)
+	})
+}
+
+func (s *SetlistFMClientTestSuite) TestGetArtistSetlists() {
+	headers := map[string]interface{}{"x-api-key": "any-api-key"}
+
+	s.Run("Should return a page of setlists", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.
+			On("Get", "/1.0/artist/any-mbid/setlists?p=2", headers, &setlistfm.SetlistPage{}).
+			Return(nil)
+
+		result, err := s.SetlistFMClient.GetArtistSetlists("any-mbid", 2)
+
+		s.NoError(err)
+		s.NotNil(result)
+	})
+
+	s.Run("Should return an error when http client fails", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.
+			On("Get", "/1.0/artist/any-mbid/setlists?p=1", headers, &setlistfm.SetlistPage{}).
+			Return(&httpclient.StatusError{StatusCode: http.StatusTooManyRequests})
+
+		result, err := s.SetlistFMClient.GetArtistSetlists("any-mbid", 1)
+
+		s.ErrorContains(err, "unexpected status code [429]")
+		s.Nil(result)
+	})
+}
+
+func (s *SetlistFMClientTestSuite) TestSearchSetlists() {
+	headers := map[string]interface{}{"x-api-key": "any-api-key"}
+
+	s.Run("Should search setlists with the given criteria", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.
+			On("Get", "/1.0/search/setlists?artistName=Paramore&p=1&year=2023", headers, &setlistfm.SetlistPage{}).
+			Return(nil)
+
+		result, err := s.SetlistFMClient.SearchSetlists(setlistfm.SearchSetlistsInput{ArtistName: "Paramore", Year: "2023"})
+
+		s.NoError(err)
+		s.NotNil(result)
+	})
+
+	s.Run("Should return an empty page when nothing matches", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.
+			On("Get", mock.Anything, headers, &setlistfm.SetlistPage{}).
+			Return(&httpclient.StatusError{StatusCode: http.StatusNotFound})
+
+		result, err := s.SetlistFMClient.SearchSetlists(setlistfm.SearchSetlistsInput{CityName: "any-city", Page: 2})
+
+		s.NoError(err)
+		s.Empty(result.Setlist)
+		s.True(result.IsLast())
+	})
+
+	s.Run("Should not call the API without criteria", func() {
+		defer s.cleanMock()
+
+		result, err := s.SetlistFMClient.SearchSetlists(setlistfm.SearchSetlistsInput{})
+
+		s.Error(err)
+		s.Nil(result)
+		s.HttpClientMock.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
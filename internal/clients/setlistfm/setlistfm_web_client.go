@@ -0,0 +1,66 @@
+package setlistfm
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/httpclient"
+)
+
+// setlistLinkRegex matches the links to setlists on a festival page, capturing their year, venue
+// and ID, e.g. "../setlist/paramore/2024/donington-park-castle-donington-england-3bd5a4c8.html".
+var setlistLinkRegex = regexp.MustCompile(`href="[^"]*setlist/[^/"]+/(\d{4})/([^/"]+)-([0-9a-f]{7,8})\.html"`)
+
+// ErrNoFestivalSetlists is returned when a festival page links to no setlist, most likely because
+// the layout of the website changed.
+var ErrNoFestivalSetlists = errors.New("no setlists were found on the festival page, the Setlist.fm website may have changed")
+
+// SetlistFMWebClientInterface reads what the Setlist.fm API doesn't offer from the website.
+type SetlistFMWebClientInterface interface {
+	GetFestivalSetlists(path string) ([]setlistfm.LineupSetlist, error)
+}
+
+type SetlistFMWebClient struct {
+	HttpClient httpclient.HttpClientInterface
+}
+
+func NewSetlistFMWebClient(httpClient httpclient.HttpClientInterface) SetlistFMWebClientInterface {
+	return &SetlistFMWebClient{
+		HttpClient: httpClient,
+	}
+}
+
+// GetFestivalSetlists returns the setlists linked from a festival page, once each and in the
+// order they are listed. The API has no festival endpoint, so one of them is needed to find the
+// venue and dates of the festival; the page also links to setlists of other events, see
+// GetFestivalSetlistsInput.Anchor.
+func (c *SetlistFMWebClient) GetFestivalSetlists(path string) ([]setlistfm.LineupSetlist, error) {
+	body, err := c.HttpClient.GetBody(path, map[string]interface{}{"Accept": "text/html"})
+	if err != nil {
+		return nil, err
+	}
+
+	var links []setlistfm.LineupSetlist
+	seen := make(map[string]bool)
+
+	for _, match := range setlistLinkRegex.FindAllSubmatch(body, -1) {
+		id := string(match[3])
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		links = append(links, setlistfm.LineupSetlist{
+			ID:        id,
+			Year:      string(match[1]),
+			VenueSlug: string(match[2]),
+		})
+	}
+
+	if len(links) == 0 {
+		return nil, ErrNoFestivalSetlists
+	}
+
+	return links, nil
+}
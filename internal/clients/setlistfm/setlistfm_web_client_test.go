@@ -0,0 +1,81 @@
+package setlistfm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/httpclient"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SetlistFMWebClientTestSuite struct {
+	suite.Suite
+	HttpClientMock *mocks.HttpClientMock
+
+	WebClient SetlistFMWebClientInterface
+}
+
+func (s *SetlistFMWebClientTestSuite) SetupTest() {
+	s.HttpClientMock = new(mocks.HttpClientMock)
+	s.WebClient = NewSetlistFMWebClient(s.HttpClientMock)
+}
+
+func (s *SetlistFMWebClientTestSuite) cleanMock() {
+	s.HttpClientMock.ExpectedCalls = nil
+	s.HttpClientMock.Calls = nil
+}
+
+func TestSetlistFMWebClient(t *testing.T) {
+	suite.Run(t, new(SetlistFMWebClientTestSuite))
+}
+
+func (s *SetlistFMWebClientTestSuite) TestGetFestivalSetlists() {
+	path := "/festival/2024/download-festival-2024-73d44e99.html"
+	headers := map[string]interface{}{"Accept": "text/html"}
+
+	s.Run("Should return the linked setlists once, in order", func() {
+		defer s.cleanMock()
+
+		page := `<div class="lineup">
+			<a href="../../setlist/queens-of-the-stone-age/2024/donington-park-castle-donington-england-3bd5a4c8.html">Queens of the Stone Age</a>
+			<a href="../../setlist/avenged-sevenfold/2024/donington-park-castle-donington-england-1bd5a5a0.html">Avenged Sevenfold</a>
+			<a href="../../setlist/queens-of-the-stone-age/2024/donington-park-castle-donington-england-3bd5a4c8.html">Setlist</a>
+			<a href="../../venue/donington-park-castle-donington-england-73d6a6c5.html">Donington Park</a>
+		</div>`
+
+		s.HttpClientMock.On("GetBody", path, headers).Return([]byte(page), nil)
+
+		links, err := s.WebClient.GetFestivalSetlists(path)
+
+		s.NoError(err)
+		s.Equal([]setlistfm.LineupSetlist{
+			{ID: "3bd5a4c8", Year: "2024", VenueSlug: "donington-park-castle-donington-england"},
+			{ID: "1bd5a5a0", Year: "2024", VenueSlug: "donington-park-castle-donington-england"},
+		}, links)
+	})
+
+	s.Run("Should return an error when the page links to no setlist", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.On("GetBody", path, headers).Return([]byte(`<div class="lineup"></div>`), nil)
+
+		links, err := s.WebClient.GetFestivalSetlists(path)
+
+		s.ErrorIs(err, ErrNoFestivalSetlists)
+		s.Nil(links)
+	})
+
+	s.Run("Should return an error when http client fails", func() {
+		defer s.cleanMock()
+
+		s.HttpClientMock.On("GetBody", path, headers).Return(nil, &httpclient.StatusError{StatusCode: http.StatusNotFound})
+
+		links, err := s.WebClient.GetFestivalSetlists(path)
+
+		s.ErrorContains(err, "unexpected status code [404]")
+		s.Nil(links)
+	})
+}
@@ -0,0 +1,233 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/zmb3/spotify/v2"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+)
+
+var (
+	MaxChunkRetries   = 3
+	ChunkRetryBackoff = time.Second
+)
+
+// addTracksInChunks adds the tracks in requests of at most MaxTracksPerRequest, in order.
+// added is how many tracks of total are already in the playlist, for progress reporting.
+func (c *SpotifyClient) addTracksInChunks(
+	ctx context.Context,
+	playlistID spotify.ID,
+	ids []spotify.ID,
+	added int,
+	total int,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var length int
+
+	err := c.retryChunk(ctx, func() (err error) {
+		length, err = c.playlistLength(ctx, playlistID)
+		return err
+	})
+	if err != nil {
+		return &entities.PartialAddError{
+			PlaylistID: playlistID.String(),
+			Added:      added,
+			Total:      total,
+			Err:        err,
+		}
+	}
+
+	for start := 0; start < len(ids); start += MaxTracksPerRequest {
+		end := min(start+MaxTracksPerRequest, len(ids))
+
+		chunk := ids[start:end]
+
+		err = c.retryChunk(ctx, func() error {
+			return c.addChunk(ctx, playlistID, chunk, length)
+		})
+		if err != nil {
+			return &entities.PartialAddError{
+				PlaylistID: playlistID.String(),
+				Added:      added,
+				Total:      total,
+				Err:        err,
+			}
+		}
+
+		added += end - start
+		length += end - start
+
+		c.Logger.Debug("Added tracks to playlist", map[string]interface{}{
+			"playlist_id": playlistID,
+			"added":       added,
+			"total":       total,
+		})
+	}
+
+	return nil
+}
+
+// addChunk adds one chunk of tracks to a playlist that held length tracks before it. Adding
+// isn't idempotent: a request that timed out or failed with a server error may have been
+// applied anyway, so before it's retried the playlist is checked against that length. Only 429
+// and 503 mean Spotify didn't process the request.
+func (c *SpotifyClient) addChunk(
+	ctx context.Context,
+	playlistID spotify.ID,
+	chunk []spotify.ID,
+	length int,
+) error {
+	_, err := c.AuthenticatedClient.AddTracksToPlaylist(ctx, playlistID, chunk...)
+	if err == nil || !isTransient(err) || isUnprocessed(err) {
+		return err
+	}
+
+	added, checkErr := c.chunkAdded(ctx, playlistID, chunk, length)
+	if checkErr != nil {
+		return &uncertainAddError{Err: err, CheckErr: checkErr}
+	}
+
+	if added {
+		c.Logger.Debug("Tracks were added despite the failed request", map[string]interface{}{
+			"playlist_id": playlistID,
+			"error":       err.Error(),
+		})
+
+		return nil
+	}
+
+	return err
+}
+
+// chunkAdded tells whether the chunk was added after the first length tracks of the playlist.
+// A playlist that still holds length tracks didn't get it, one that holds any other number than
+// length plus the chunk changed in some other way and can't be told apart.
+func (c *SpotifyClient) chunkAdded(
+	ctx context.Context,
+	playlistID spotify.ID,
+	chunk []spotify.ID,
+	length int,
+) (bool, error) {
+	total, err := c.playlistLength(ctx, playlistID)
+	if err != nil {
+		return false, err
+	}
+
+	if total == length {
+		return false, nil
+	}
+
+	if total != length+len(chunk) {
+		return false, fmt.Errorf("playlist has %d tracks, expected %d or %d", total, length, length+len(chunk))
+	}
+
+	page, err := c.AuthenticatedClient.GetPlaylistItems(
+		ctx,
+		playlistID,
+		spotify.Offset(length),
+		spotify.Limit(len(chunk)),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if len(page.Items) != len(chunk) {
+		return false, fmt.Errorf("playlist returned %d of the %d added tracks", len(page.Items), len(chunk))
+	}
+
+	for i, item := range page.Items {
+		if item.Track.Track == nil || item.Track.Track.ID != chunk[i] {
+			return false, fmt.Errorf("playlist has other tracks than the added ones at position %d", length+i)
+		}
+	}
+
+	return true, nil
+}
+
+func (c *SpotifyClient) playlistLength(ctx context.Context, playlistID spotify.ID) (int, error) {
+	page, err := c.AuthenticatedClient.GetPlaylistItems(ctx, playlistID, spotify.Limit(1), spotify.Fields("total"))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(page.Total), nil
+}
+
+// uncertainAddError is returned when adding tracks failed in a way they may have been added
+// anyway and the playlist couldn't be checked. Retrying could add them twice, so it isn't.
+type uncertainAddError struct {
+	Err      error
+	CheckErr error
+}
+
+func (e *uncertainAddError) Error() string {
+	return fmt.Sprintf("%s (could not check whether the tracks were added anyway: %s)", e.Err, e.CheckErr)
+}
+
+func (e *uncertainAddError) Unwrap() error {
+	return e.Err
+}
+
+func (c *SpotifyClient) retryChunk(ctx context.Context, fn func() error) error {
+	backoff := ChunkRetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= MaxChunkRetries || ctx.Err() != nil || !isTransient(err) {
+			return err
+		}
+
+		c.Logger.Warn(fmt.Sprintf("Spotify request failed, retrying in %s: %s", backoff, err), map[string]interface{}{
+			"attempt": attempt + 1,
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// isTransient tells whether a failed request is worth retrying: Spotify being rate limited or
+// failing on its side, or the connection timing out or breaking.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var uncertain *uncertainAddError
+	if errors.As(err, &uncertain) {
+		return false
+	}
+
+	var apiErr spotify.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= http.StatusInternalServerError || apiErr.Status == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// isUnprocessed tells whether Spotify turned the request down without processing it.
+func isUnprocessed(err error) bool {
+	var apiErr spotify.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.Status == http.StatusTooManyRequests || apiErr.Status == http.StatusServiceUnavailable
+}
@@ -0,0 +1,302 @@
+package spotify_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/zmb3/spotify/v2"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type fakePlaylistAPI struct {
+	mu       sync.Mutex
+	requests [][]string
+	existing []string
+	checks   int
+	// failures fail the nth request without adding its tracks, applied fails it after adding them.
+	failures map[int]int
+	applied  map[int]int
+	status   int
+	// onFailure runs after a request fails, to change the playlist meanwhile.
+	onFailure func()
+}
+
+func (api *fakePlaylistAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if r.Method == http.MethodGet {
+		api.serveItems(w, r)
+		return
+	}
+
+	var body struct {
+		URIs []string `json:"uris"`
+	}
+
+	if r.Method == http.MethodPut {
+		uris := r.URL.Query().Get("uris")
+		body.URIs = strings.Split(uris, ",")
+	} else {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	call := len(api.requests)
+	api.requests = append(api.requests, body.URIs)
+
+	status := api.status
+	if status == 0 {
+		status = http.StatusBadGateway
+	}
+
+	if api.failures[call] > 0 {
+		api.failures[call]--
+		api.requests = api.requests[:call]
+
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"error":{"status":%d,"message":"failed"}}`, status)
+		return
+	}
+
+	if api.applied[call] > 0 {
+		api.applied[call]--
+
+		if api.onFailure != nil {
+			api.onFailure()
+		}
+
+		w.WriteHeader(http.StatusGatewayTimeout)
+		fmt.Fprint(w, `{"error":{"status":504,"message":"gateway timeout"}}`)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprint(w, `{"snapshot_id":"any-snapshot"}`)
+}
+
+func (api *fakePlaylistAPI) serveItems(w http.ResponseWriter, r *http.Request) {
+	api.checks++
+
+	uris := append([]string{}, api.existing...)
+	for _, req := range api.requests {
+		uris = append(uris, req...)
+	}
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	type item struct {
+		Track map[string]string `json:"track"`
+	}
+
+	items := []item{}
+
+	for i := offset; i < min(offset+limit, len(uris)); i++ {
+		items = append(items, item{Track: map[string]string{
+			"id":   strings.TrimPrefix(uris[i], "spotify:track:"),
+			"type": "track",
+		}})
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"total": len(uris), "items": items})
+}
+
+type BatchTestSuite struct {
+	suite.Suite
+	LoggerMock *mocks.LoggerMock
+	API        *fakePlaylistAPI
+	Server     *httptest.Server
+
+	Client *client.SpotifyClient
+}
+
+func (s *BatchTestSuite) SetupTest() {
+	client.ChunkRetryBackoff = 0
+
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+	s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+
+	s.API = &fakePlaylistAPI{failures: map[int]int{}, applied: map[int]int{}}
+	s.Server = httptest.NewServer(s.API)
+
+	s.Client = &client.SpotifyClient{
+		Logger: s.LoggerMock,
+		AuthenticatedClient: client.AuthenticatedClient{
+			Client: *spotify.New(s.Server.Client(), spotify.WithBaseURL(s.Server.URL+"/")),
+		},
+	}
+}
+
+func (s *BatchTestSuite) TearDownTest() {
+	s.Server.Close()
+	client.ChunkRetryBackoff = time.Second
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
+
+func tracks(n int) []entities.Song {
+	songs := make([]entities.Song, n)
+	for i := range songs {
+		songs[i] = entities.Song{ID: fmt.Sprintf("track%03d", i)}
+	}
+
+	return songs
+}
+
+func (s *BatchTestSuite) sent() []string {
+	var uris []string
+	for _, r := range s.API.requests {
+		uris = append(uris, r...)
+	}
+
+	return uris
+}
+
+func (s *BatchTestSuite) TestAddTracksToPlaylist() {
+	input := entities.AddTracksToPlaylistClientInput{PlaylistID: "any-playlist-id", Tracks: tracks(250)}
+
+	s.Run("Should add the tracks in chunks of 100, in order", func() {
+		err := s.Client.AddTracksToPlaylist(context.Background(), input)
+
+		s.NoError(err)
+		s.Len(s.API.requests, 3)
+		s.Len(s.API.requests[0], 100)
+		s.Len(s.API.requests[2], 50)
+
+		for i, uri := range s.sent() {
+			s.Equal(fmt.Sprintf("spotify:track:track%03d", i), uri)
+		}
+	})
+}
+
+func (s *BatchTestSuite) TestRetries() {
+	input := entities.AddTracksToPlaylistClientInput{PlaylistID: "any-playlist-id", Tracks: tracks(250)}
+
+	s.Run("Should retry a chunk that failed for a transient reason", func() {
+		s.API.failures[1] = 2
+
+		err := s.Client.AddTracksToPlaylist(context.Background(), input)
+
+		s.NoError(err)
+		s.Len(s.sent(), 250)
+		s.Equal(3, s.API.checks)
+	})
+}
+
+func (s *BatchTestSuite) TestUnprocessedRetries() {
+	input := entities.AddTracksToPlaylistClientInput{PlaylistID: "any-playlist-id", Tracks: tracks(250)}
+
+	s.Run("Should retry right away when Spotify didn't process the chunk", func() {
+		s.API.status = http.StatusServiceUnavailable
+		s.API.failures[1] = 2
+
+		err := s.Client.AddTracksToPlaylist(context.Background(), input)
+
+		s.NoError(err)
+		s.Len(s.sent(), 250)
+		s.Equal(1, s.API.checks)
+	})
+}
+
+func (s *BatchTestSuite) TestAppliedFailure() {
+	input := entities.AddTracksToPlaylistClientInput{PlaylistID: "any-playlist-id", Tracks: tracks(250)}
+
+	s.Run("Should not add a chunk twice when it was added despite the error", func() {
+		s.API.applied[1] = 1
+
+		err := s.Client.AddTracksToPlaylist(context.Background(), input)
+
+		s.NoError(err)
+		s.Len(s.API.requests, 3)
+		s.Len(s.sent(), 250)
+
+		for i, uri := range s.sent() {
+			s.Equal(fmt.Sprintf("spotify:track:track%03d", i), uri)
+		}
+	})
+}
+
+func (s *BatchTestSuite) TestFailureAfterSameTracks() {
+	input := entities.AddTracksToPlaylistClientInput{PlaylistID: "any-playlist-id", Tracks: tracks(100)}
+
+	s.Run("Should retry a chunk that wasn't added when the playlist already ended with its tracks", func() {
+		for _, song := range input.Tracks {
+			s.API.existing = append(s.API.existing, "spotify:track:"+song.ID)
+		}
+
+		s.API.failures[0] = 1
+
+		err := s.Client.AddTracksToPlaylist(context.Background(), input)
+
+		s.NoError(err)
+		s.Len(s.sent(), 100)
+	})
+}
+
+func (s *BatchTestSuite) TestChangedPlaylist() {
+	input := entities.AddTracksToPlaylistClientInput{PlaylistID: "any-playlist-id", Tracks: tracks(250)}
+
+	s.Run("Should not retry a failed chunk when the playlist changed in some other way", func() {
+		s.API.applied[1] = 1
+		s.API.onFailure = func() {
+			s.API.existing = append(s.API.existing, "spotify:track:someone-else")
+		}
+
+		err := s.Client.AddTracksToPlaylist(context.Background(), input)
+
+		var partial *entities.PartialAddError
+
+		s.ErrorAs(err, &partial)
+		s.Equal(100, partial.Added)
+		s.ErrorContains(err, "could not check whether the tracks were added anyway")
+		s.Len(s.sent(), 200)
+	})
+}
+
+func (s *BatchTestSuite) TestPartialProgress() {
+	input := entities.AddTracksToPlaylistClientInput{PlaylistID: "any-playlist-id", Tracks: tracks(250)}
+
+	s.Run("Should report how many tracks were added when a chunk keeps failing", func() {
+		s.API.failures[2] = client.MaxChunkRetries + 1
+
+		err := s.Client.AddTracksToPlaylist(context.Background(), input)
+
+		var partial *entities.PartialAddError
+
+		s.ErrorAs(err, &partial)
+		s.Equal(200, partial.Added)
+		s.Equal(250, partial.Total)
+		s.Equal("any-playlist-id", partial.PlaylistID)
+		s.ErrorContains(err, "added 200 of 250 tracks")
+	})
+}
+
+func (s *BatchTestSuite) TestReplacePlaylistTracks() {
+	s.Run("Should replace the first chunk and add the rest", func() {
+		err := s.Client.ReplacePlaylistTracks(context.Background(), entities.AddTracksToPlaylistClientInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     tracks(150),
+		})
+
+		s.NoError(err)
+		s.Len(s.API.requests, 2)
+		s.Len(s.API.requests[0], 100)
+		s.Len(s.API.requests[1], 50)
+	})
+}
@@ -0,0 +1,116 @@
+package spotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type MatchCacheStoreInterface interface {
+	Read() (*cache.MatchCache, error)
+	Write(data cache.MatchCache) error
+}
+
+type CachedSpotifyClient struct {
+	SpotifyClientInterface
+	Cache  MatchCacheStoreInterface
+	Logger logger.LoggerInterface
+	Search cache.Search
+	TTL    time.Duration
+	Now    func() time.Time
+}
+
+func NewCachedSpotifyClient(
+	c SpotifyClientInterface,
+	p MatchCacheStoreInterface,
+	l logger.LoggerInterface,
+	search cache.Search,
+	ttl time.Duration,
+) SpotifyClientInterface {
+	return &CachedSpotifyClient{
+		SpotifyClientInterface: c,
+		Cache:                  p,
+		Logger:                 l,
+		Search:                 search,
+		TTL:                    ttl,
+		Now:                    time.Now,
+	}
+}
+
+func (c *CachedSpotifyClient) FindAllSongsByName(
+	ctx context.Context,
+	input entities.FindAllSongsInput,
+) (*entities.FindAllSongsOutput, error) {
+	saved, err := c.Cache.Read()
+	if err != nil {
+		c.Logger.Warn("Could not read the match cache, searching every song on Spotify", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return c.SpotifyClientInterface.FindAllSongsByName(ctx, input)
+	}
+
+	now := c.Now()
+	results := make([]entities.SongResult, len(input.Songs))
+	keys := make([]string, len(input.Songs))
+
+	var (
+		missIndexes []int
+		missSongs   []entities.SongQuery
+	)
+
+	for i, q := range input.Songs {
+		keys[i] = cache.Key(c.Search, input.Artist, q, input.Policy)
+
+		if res, ok := saved.Get(keys[i], c.TTL, now); ok {
+			// Titles that normalize the same share an entry, the result shows the one asked for.
+			results[i] = *res
+			results[i].Query = q.Title
+			continue
+		}
+
+		missIndexes = append(missIndexes, i)
+		missSongs = append(missSongs, q)
+	}
+
+	c.Logger.Debug("Match cache lookup", map[string]interface{}{
+		"hits":   len(input.Songs) - len(missSongs),
+		"misses": len(missSongs),
+	})
+
+	if len(missSongs) == 0 {
+		return entities.NewFindAllSongsOutput(input.Artist, results), nil
+	}
+
+	missInput := input
+	missInput.Songs = missSongs
+
+	found, err := c.SpotifyClientInterface.FindAllSongsByName(ctx, missInput)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, res := range found.Results {
+		i := missIndexes[j]
+
+		results[i] = res
+
+		// Songs that weren't found are searched again next time, they may be released since.
+		if res.Status != entities.MatchStatusNotFound {
+			saved.Put(keys[i], res, now)
+		}
+	}
+
+	saved.Prune(c.TTL, now)
+
+	if err := c.Cache.Write(*saved); err != nil {
+		c.Logger.Warn("Could not update the match cache", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return entities.NewFindAllSongsOutput(input.Artist, results), nil
+}
@@ -0,0 +1,206 @@
+package spotify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type CachedSpotifyClientTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SpotifyClientMock
+	CacheMock  *mocks.MatchCachePersistenceMock
+	LoggerMock *mocks.LoggerMock
+	Now        time.Time
+
+	Client *client.CachedSpotifyClient
+}
+
+func (s *CachedSpotifyClientTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SpotifyClientMock)
+	s.CacheMock = new(mocks.MatchCachePersistenceMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.Now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	s.Client = client.NewCachedSpotifyClient(
+		s.ClientMock,
+		s.CacheMock,
+		s.LoggerMock,
+		cache.Search{Market: "BR"},
+		24*time.Hour,
+	).(*client.CachedSpotifyClient)
+	s.Client.Now = func() time.Time { return s.Now }
+}
+
+func (s *CachedSpotifyClientTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+	s.CacheMock.ExpectedCalls = nil
+	s.CacheMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestCachedSpotifyClient(t *testing.T) {
+	suite.Run(t, new(CachedSpotifyClientTestSuite))
+}
+
+func (s *CachedSpotifyClientTestSuite) TestFindAllSongsByName() {
+	input := entities.FindAllSongsInput{
+		Songs:  []entities.SongQuery{{Title: "Dammit"}, {Title: "Adam's Song"}},
+		Artist: "blink-182",
+	}
+
+	dammit := entities.SongResult{
+		Query:  "Dammit",
+		Status: entities.MatchStatusMatched,
+		Song:   &entities.Song{ID: "dammit-id", Title: "Dammit"},
+	}
+	adams := entities.SongResult{
+		Query:  "Adam's Song",
+		Status: entities.MatchStatusMatched,
+		Song:   &entities.Song{ID: "adams-id", Title: "Adam's Song"},
+	}
+
+	dammitKey := cache.Key(cache.Search{Market: "BR"}, "blink-182", input.Songs[0], input.Policy)
+
+	s.Run("Should only search the songs missing from the cache", func() {
+		defer s.cleanMocks()
+
+		saved := &cache.MatchCache{}
+		saved.Put(dammitKey, dammit, s.Now.Add(-time.Hour))
+
+		missInput := input
+		missInput.Songs = []entities.SongQuery{{Title: "Adam's Song"}}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.CacheMock.On("Read").Return(saved, nil)
+		s.CacheMock.On("Write", mock.Anything).Return(nil)
+		s.ClientMock.
+			On("FindAllSongsByName", mock.Anything, missInput).
+			Return(entities.NewFindAllSongsOutput("blink-182", []entities.SongResult{adams}), nil)
+
+		out, err := s.Client.FindAllSongsByName(context.Background(), input)
+
+		s.NoError(err)
+		s.Equal([]entities.SongResult{dammit, adams}, out.Results)
+
+		written := s.CacheMock.Calls[1].Arguments[0].(cache.MatchCache)
+		s.Len(written.Entries, 2)
+	})
+
+	s.Run("Should not search Spotify when every song is cached", func() {
+		defer s.cleanMocks()
+
+		saved := &cache.MatchCache{}
+		saved.Put(dammitKey, dammit, s.Now)
+		saved.Put(cache.Key(cache.Search{Market: "BR"}, "blink-182", input.Songs[1], input.Policy), adams, s.Now)
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.CacheMock.On("Read").Return(saved, nil)
+
+		out, err := s.Client.FindAllSongsByName(context.Background(), input)
+
+		s.NoError(err)
+		s.Len(out.Songs, 2)
+		s.ClientMock.AssertNotCalled(s.T(), "FindAllSongsByName", mock.Anything, mock.Anything)
+		s.CacheMock.AssertNotCalled(s.T(), "Write", mock.Anything)
+	})
+
+	s.Run("Should show the title of the current song on a hit", func() {
+		defer s.cleanMocks()
+
+		saved := &cache.MatchCache{}
+		saved.Put(dammitKey, dammit, s.Now)
+
+		variant := entities.FindAllSongsInput{Songs: []entities.SongQuery{{Title: "DAMMIT!"}}, Artist: "blink-182"}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.CacheMock.On("Read").Return(saved, nil)
+
+		out, err := s.Client.FindAllSongsByName(context.Background(), variant)
+
+		s.NoError(err)
+		s.Equal("DAMMIT!", out.Results[0].Query)
+		s.Equal("dammit-id", out.Results[0].Song.ID)
+		s.Equal("Dammit", saved.Entries[dammitKey].Result.Query)
+	})
+
+	s.Run("Should not cache the songs that weren't found", func() {
+		defer s.cleanMocks()
+
+		missing := entities.SongResult{Query: "Adam's Song", Status: entities.MatchStatusNotFound}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.CacheMock.On("Read").Return(&cache.MatchCache{}, nil)
+		s.CacheMock.On("Write", mock.Anything).Return(nil)
+		s.ClientMock.
+			On("FindAllSongsByName", mock.Anything, input).
+			Return(entities.NewFindAllSongsOutput("blink-182", []entities.SongResult{dammit, missing}), nil)
+
+		_, err := s.Client.FindAllSongsByName(context.Background(), input)
+
+		s.NoError(err)
+
+		written := s.CacheMock.Calls[1].Arguments[0].(cache.MatchCache)
+		s.Len(written.Entries, 1)
+		s.Contains(written.Entries, dammitKey)
+	})
+
+	s.Run("Should search again once an entry expires", func() {
+		defer s.cleanMocks()
+
+		saved := &cache.MatchCache{}
+		saved.Put(dammitKey, dammit, s.Now.Add(-48*time.Hour))
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.CacheMock.On("Read").Return(saved, nil)
+		s.CacheMock.On("Write", mock.Anything).Return(nil)
+		s.ClientMock.
+			On("FindAllSongsByName", mock.Anything, input).
+			Return(entities.NewFindAllSongsOutput("blink-182", []entities.SongResult{dammit, adams}), nil)
+
+		out, err := s.Client.FindAllSongsByName(context.Background(), input)
+
+		s.NoError(err)
+		s.Len(out.Songs, 2)
+	})
+
+	s.Run("Should fall back to searching everything when the cache can't be read", func() {
+		defer s.cleanMocks()
+
+		expected := entities.NewFindAllSongsOutput("blink-182", []entities.SongResult{dammit, adams})
+
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.CacheMock.On("Read").Return(nil, errors.New("any-error"))
+		s.ClientMock.On("FindAllSongsByName", mock.Anything, input).Return(expected, nil)
+
+		out, err := s.Client.FindAllSongsByName(context.Background(), input)
+
+		s.NoError(err)
+		s.Equal(expected, out)
+	})
+
+	s.Run("Should return the search error without touching the cache", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.CacheMock.On("Read").Return(&cache.MatchCache{}, nil)
+		s.ClientMock.On("FindAllSongsByName", mock.Anything, input).Return(nil, errors.New("any-error"))
+
+		out, err := s.Client.FindAllSongsByName(context.Background(), input)
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+		s.CacheMock.AssertNotCalled(s.T(), "Write", mock.Anything)
+	})
+}
@@ -0,0 +1,75 @@
+package spotify
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorMessageLength keeps an HTML error page from flooding the logs.
+const maxErrorMessageLength = 200
+
+// ErrorStatusTransport rewrites error responses the API client can't decode, like an empty body
+// or an HTML page from a proxy in front of the API, into a Spotify error object, so every failed
+// request comes back as a spotify.Error carrying its status code.
+type ErrorStatusTransport struct {
+	Base http.RoundTripper
+}
+
+func NewErrorStatusTransport(base http.RoundTripper) *ErrorStatusTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &ErrorStatusTransport{Base: base}
+}
+
+func (t *ErrorStatusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.Base.RoundTrip(req)
+	if err != nil || res.StatusCode < http.StatusBadRequest {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded struct {
+		Error struct {
+			Status  int    `json:"status"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if json.Unmarshal(body, &decoded) != nil {
+		decoded.Error.Status = 0
+		decoded.Error.Message = strings.TrimSpace(http.StatusText(res.StatusCode) + ": " + string(bytes.TrimSpace(body)))
+		decoded.Error.Message = strings.TrimSuffix(decoded.Error.Message, ":")
+
+		if len(decoded.Error.Message) > maxErrorMessageLength {
+			decoded.Error.Message = decoded.Error.Message[:maxErrorMessageLength]
+		}
+	}
+
+	if decoded.Error.Status == 0 || decoded.Error.Message == "" {
+		decoded.Error.Status = res.StatusCode
+
+		if decoded.Error.Message == "" {
+			decoded.Error.Message = http.StatusText(res.StatusCode)
+		}
+
+		body, _ = json.Marshal(decoded)
+		res.Header.Set("Content-Type", "application/json")
+		res.Header.Del("Content-Length")
+		res.ContentLength = int64(len(body))
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package spotify_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zmb3/spotify/v2"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+)
+
+type ErrorStatusTransportTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorStatusTransport(t *testing.T) {
+	suite.Run(t, new(ErrorStatusTransportTestSuite))
+}
+
+// getPlaylist requests a playlist from a server that always answers with the given status and body.
+func (s *ErrorStatusTransportTestSuite) getPlaylist(status int, body string) error {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: client.NewErrorStatusTransport(http.DefaultTransport)}
+	api := spotify.New(httpClient, spotify.WithBaseURL(server.URL+"/"))
+
+	_, err := api.GetPlaylist(context.Background(), "any-playlist-id")
+
+	return err
+}
+
+func (s *ErrorStatusTransportTestSuite) TestRoundTrip() {
+	s.Run("Should keep the status of an error with an empty body", func() {
+		err := s.getPlaylist(http.StatusBadGateway, "")
+
+		var apiErr spotify.Error
+
+		s.ErrorAs(err, &apiErr)
+		s.Equal(http.StatusBadGateway, apiErr.Status)
+		s.Equal("Bad Gateway", apiErr.Message)
+	})
+
+	s.Run("Should keep the status and body of an error that isn't JSON", func() {
+		err := s.getPlaylist(http.StatusServiceUnavailable, "<html>upstream unavailable</html>")
+
+		var apiErr spotify.Error
+
+		s.ErrorAs(err, &apiErr)
+		s.Equal(http.StatusServiceUnavailable, apiErr.Status)
+		s.Equal("Service Unavailable: <html>upstream unavailable</html>", apiErr.Message)
+	})
+
+	s.Run("Should fill in the status of a Spotify error without one", func() {
+		err := s.getPlaylist(http.StatusInternalServerError, `{"error":{"message":"server error"}}`)
+
+		var apiErr spotify.Error
+
+		s.ErrorAs(err, &apiErr)
+		s.Equal(http.StatusInternalServerError, apiErr.Status)
+		s.Equal("server error", apiErr.Message)
+	})
+
+	s.Run("Should leave Spotify errors as they are", func() {
+		err := s.getPlaylist(http.StatusNotFound, `{"error":{"status":404,"message":"Resource not found"}}`)
+
+		var apiErr spotify.Error
+
+		s.ErrorAs(err, &apiErr)
+		s.Equal(http.StatusNotFound, apiErr.Status)
+		s.Equal("Resource not found", apiErr.Message)
+	})
+}
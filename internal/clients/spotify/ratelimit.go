@@ -0,0 +1,105 @@
+package spotify
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+var (
+	DefaultRetryAfter    = 5 * time.Second
+	MaxRateLimitRetries  = 5
+	MaxRetryAfterBackoff = 60 * time.Second
+)
+
+type RateLimitTransport struct {
+	Base       http.RoundTripper
+	Logger     logger.LoggerInterface
+	MaxRetries int
+}
+
+func NewRateLimitTransport(base http.RoundTripper, l logger.LoggerInterface) *RateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &RateLimitTransport{
+		Base:       base,
+		Logger:     l,
+		MaxRetries: MaxRateLimitRetries,
+	}
+}
+
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		r, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := t.Base.RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusTooManyRequests || attempt >= t.MaxRetries || !canRetry(req) {
+			return res, nil
+		}
+
+		wait := retryAfter(res)
+		res.Body.Close()
+
+		t.Logger.Warn(fmt.Sprintf("Rate limited by Spotify, retrying in %s", wait), map[string]interface{}{
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+		})
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	r := req.Clone(req.Context())
+	r.Body = body
+
+	return r, nil
+}
+
+func canRetry(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func retryAfter(res *http.Response) time.Duration {
+	raw := res.Header.Get("Retry-After")
+	if raw == "" {
+		return DefaultRetryAfter
+	}
+
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds < 0 {
+		return DefaultRetryAfter
+	}
+
+	wait := time.Duration(seconds) * time.Second
+	if wait > MaxRetryAfterBackoff {
+		return MaxRetryAfterBackoff
+	}
+
+	return wait
+}
@@ -0,0 +1,96 @@
+package spotify_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type RateLimitTransportTestSuite struct {
+	suite.Suite
+	LoggerMock *mocks.LoggerMock
+
+	Transport *client.RateLimitTransport
+}
+
+func (s *RateLimitTransportTestSuite) SetupTest() {
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+
+	s.Transport = client.NewRateLimitTransport(http.DefaultTransport, s.LoggerMock)
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	suite.Run(t, new(RateLimitTransportTestSuite))
+}
+
+func (s *RateLimitTransportTestSuite) TestRoundTrip() {
+	s.Run("Should retry after being rate limited", func() {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		res, err := s.Transport.RoundTrip(req)
+
+		s.NoError(err)
+		s.Equal(http.StatusOK, res.StatusCode)
+		s.Equal(int32(2), atomic.LoadInt32(&calls))
+	})
+
+	s.Run("Should give up after the maximum number of retries", func() {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+		res, err := s.Transport.RoundTrip(req)
+
+		s.NoError(err)
+		s.Equal(http.StatusTooManyRequests, res.StatusCode)
+		s.Equal(int32(client.MaxRateLimitRetries+1), atomic.LoadInt32(&calls))
+	})
+
+	s.Run("Should stop waiting when the context is cancelled", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+		res, err := s.Transport.RoundTrip(req)
+
+		s.ErrorIs(err, context.DeadlineExceeded)
+		s.Nil(res)
+	})
+}
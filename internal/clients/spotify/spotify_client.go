@@ -1,20 +1,28 @@
 package spotify
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/zmb3/spotify/v2"
 	spotifyauth "github.com/zmb3/spotify/v2/auth"
 	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
 
 	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
 	oauth2util "github.com/mathcale/setlist-to-playlist/internal/pkg/oauth2"
 )
 
+// DefaultScopes are asked every time the user authorizes the app, others are added with RequireScopes.
+var DefaultScopes = []string{spotifyauth.ScopeUserReadEmail, spotifyauth.ScopePlaylistModifyPublic}
+
 type SpotifyClientInterface interface {
 	GetToken(ctx context.Context, r *http.Request, state string, genCodes oauth2util.GenerateOutput) (*oauth2.Token, error)
 	GetAuthURL(state string, genCodes oauth2util.GenerateOutput) string
@@ -24,11 +32,33 @@ type SpotifyClientInterface interface {
 	CurrentUser(ctx context.Context) (*spotify.PrivateUser, error)
 	CurrentSession() (*oauth2.Token, error)
 	RefreshToken(ctx context.Context, tok *oauth2.Token) (*oauth2.Token, error)
-	FindAllSongsByName(ctx context.Context, name []string, artist string) (*entities.FindAllSongsOutput, error)
-	CreatePlaylist(ctx context.Context, title string, description string) (*entities.CreatePlaylistOutput, error)
+	Scopes() []string
+	RequireScopes(scopes ...string)
+	FindAllSongsByName(ctx context.Context, input entities.FindAllSongsInput) (*entities.FindAllSongsOutput, error)
+	SearchTracks(ctx context.Context, query string) ([]entities.Song, error)
+	GetTracks(ctx context.Context, ids []string) ([]entities.Song, error)
+	CreatePlaylist(
+		ctx context.Context,
+		title string,
+		description string,
+		visibility entities.PlaylistVisibility,
+	) (*entities.CreatePlaylistOutput, error)
 	AddTracksToPlaylist(ctx context.Context, input entities.AddTracksToPlaylistClientInput) error
+	GetPlaylist(ctx context.Context, playlistID string) (*entities.CreatePlaylistOutput, error)
+	ReplacePlaylistTracks(ctx context.Context, input entities.AddTracksToPlaylistClientInput) error
+	GetPlaylistTracks(ctx context.Context, playlistID string) ([]entities.Song, error)
+	RemovePlaylistTracks(ctx context.Context, playlistID string, items []entities.PlaylistItem) error
+	UploadPlaylistCover(ctx context.Context, playlistID string, image []byte) error
+	ReorderPlaylistTrack(ctx context.Context, playlistID string, from int, insertBefore int) error
 }
 
+var (
+	MaxTracksPerLookup  = 50
+	MaxTracksPerRequest = 100
+	// MaxSearchLimit is the most results Spotify returns for a search, it rejects anything above it.
+	MaxSearchLimit = 50
+)
+
 type AuthenticatedClient struct {
 	spotify.Client
 }
@@ -37,6 +67,11 @@ type SpotifyClient struct {
 	Auth                *spotifyauth.Authenticator
 	AuthenticatedClient AuthenticatedClient
 	Logger              logger.LoggerInterface
+	SearchConcurrency   int
+	SearchLimit         int
+	Market              string
+	Matcher             matching.MatcherInterface
+	RequestedScopes     []string
 }
 
 func NewSpotifyClient(
@@ -44,16 +79,33 @@ func NewSpotifyClient(
 	redirURL string,
 	clientID string,
 	clientSecret string,
+	searchConcurrency int,
+	searchLimit int,
+	market string,
+	matcher matching.MatcherInterface,
 ) SpotifyClientInterface {
+	if searchConcurrency < 1 {
+		searchConcurrency = 1
+	}
+
+	searchLimit = max(1, min(searchLimit, MaxSearchLimit))
+
+	scopes := slices.Clone(DefaultScopes)
+
 	return &SpotifyClient{
 		Auth: spotifyauth.New(
 			spotifyauth.WithRedirectURL(redirURL),
 			spotifyauth.WithClientID(clientID),
 			spotifyauth.WithClientSecret(clientSecret),
-			spotifyauth.WithScopes(spotifyauth.ScopeUserReadEmail, spotifyauth.ScopePlaylistModifyPublic),
+			spotifyauth.WithScopes(scopes...),
 		),
+		RequestedScopes:     scopes,
 		AuthenticatedClient: AuthenticatedClient{},
 		Logger:              logger,
+		SearchConcurrency:   searchConcurrency,
+		SearchLimit:         searchLimit,
+		Market:              market,
+		Matcher:             matcher,
 	}
 }
 
@@ -62,9 +114,23 @@ func (c *SpotifyClient) GetAuthURL(state string, genCodes oauth2util.GenerateOut
 		state,
 		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
 		oauth2.SetAuthURLParam("code_challenge", genCodes.CodeChallenge),
+		oauth2.SetAuthURLParam("scope", strings.Join(c.RequestedScopes, " ")),
 	)
 }
 
+func (c *SpotifyClient) Scopes() []string {
+	return c.RequestedScopes
+}
+
+// RequireScopes adds scopes to the ones asked for the next time the user authorizes the app.
+func (c *SpotifyClient) RequireScopes(scopes ...string) {
+	for _, scope := range scopes {
+		if !slices.Contains(c.RequestedScopes, scope) {
+			c.RequestedScopes = append(c.RequestedScopes, scope)
+		}
+	}
+}
+
 func (c *SpotifyClient) GetToken(
 	ctx context.Context,
 	r *http.Request,
@@ -85,7 +151,10 @@ func (c *SpotifyClient) GetToken(
 }
 
 func (c *SpotifyClient) NewAPIClient(ctx context.Context, tok *oauth2.Token) *spotify.Client {
-	return spotify.New(c.Auth.Client(ctx, tok))
+	httpClient := c.Auth.Client(ctx, tok)
+	httpClient.Transport = NewErrorStatusTransport(NewRateLimitTransport(httpClient.Transport, c.Logger))
+
+	return spotify.New(httpClient)
 }
 
 func (c *SpotifyClient) SetAuthenticatedClient(ch chan AuthenticatedClient) {
@@ -113,59 +182,267 @@ func (c *SpotifyClient) RefreshToken(
 
 func (c *SpotifyClient) FindAllSongsByName(
 	ctx context.Context,
-	name []string,
-	artist string,
+	input entities.FindAllSongsInput,
 ) (*entities.FindAllSongsOutput, error) {
-	// TODO: use goroutines to search for each song in parallel
-	result := &entities.FindAllSongsOutput{
-		Artist: artist,
+	results := make([]entities.SongResult, len(input.Songs))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.SearchConcurrency)
+
+	for i, n := range input.Songs {
+		g.Go(func() error {
+			res, err := c.findSong(gctx, n, input.Artist, input.Policy)
+			if err != nil {
+				return err
+			}
+
+			results[i] = *res
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return entities.NewFindAllSongsOutput(input.Artist, results), nil
+}
+
+func (c *SpotifyClient) findSong(
+	ctx context.Context,
+	query entities.SongQuery,
+	artist string,
+	policy matching.Policy,
+) (*entities.SongResult, error) {
+	if !query.IsCover() || policy.Covers == matching.CoversPerformer {
+		return c.findSongByName(ctx, query.Title, artist, policy)
+	}
+
+	var performer *entities.SongResult
+
+	if policy.Covers != matching.CoversOriginal {
+		res, err := c.findSongByName(ctx, query.Title, artist, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		res.OriginalArtist = query.OriginalArtist
+
+		if policy.Covers == matching.CoversFallback && res.Status == entities.MatchStatusMatched {
+			return res, nil
+		}
+
+		performer = res
+	}
+
+	c.Logger.Debug("Searching cover under the original artist", map[string]interface{}{
+		"song":           query.Title,
+		"originalArtist": query.OriginalArtist,
+	})
+
+	original, err := c.findSongByName(ctx, query.Title, query.OriginalArtist, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	original.OriginalArtist = query.OriginalArtist
+
+	if performer != nil && !original.IsBetterThan(*performer) {
+		return performer, nil
 	}
 
-	for _, n := range name {
-		q := fmt.Sprintf(`"%s"%%20artist:%s`, strings.ToLower(n), strings.ToLower(artist))
+	return original, nil
+}
+
+func (c *SpotifyClient) findSongByName(
+	ctx context.Context,
+	name string,
+	artist string,
+	policy matching.Policy,
+) (*entities.SongResult, error) {
+	q := fmt.Sprintf(`track:"%s" artist:"%s"`, stripQuotes(name), stripQuotes(artist))
+
+	c.Logger.Debug("Searching for track", map[string]interface{}{
+		"query": q,
+	})
 
-		c.Logger.Debug("Searching for track", map[string]interface{}{
+	res, err := c.AuthenticatedClient.Search(
+		ctx,
+		q,
+		spotify.SearchTypeTrack,
+		c.searchOptions()...,
+	)
+	if err != nil {
+		c.Logger.Error("Failed to search for track", err, map[string]interface{}{
 			"query": q,
 		})
 
-		res, err := c.AuthenticatedClient.Search(
-			ctx,
-			q,
-			spotify.SearchTypeTrack,
-			spotify.Limit(1),
-		)
-		if err != nil {
-			c.Logger.Error("Failed to search for track", err, map[string]interface{}{
-				"query": q,
-			})
+		return nil, err
+	}
+
+	result := &entities.SongResult{
+		Query:          name,
+		Status:         entities.MatchStatusNotFound,
+		SearchedArtist: artist,
+	}
+
+	if res.Tracks == nil || len(res.Tracks.Tracks) == 0 {
+		c.Logger.Debug("No track found", map[string]interface{}{
+			"query": q,
+		})
+
+		return result, nil
+	}
+
+	candidates := make([]matching.Candidate, len(res.Tracks.Tracks))
+	for i, t := range res.Tracks.Tracks {
+		candidates[i] = toCandidate(t)
+	}
 
+	mq := matching.Query{Title: name, Artist: artist}
+
+	for _, r := range c.Matcher.Rank(mq, candidates, policy) {
+		result.Candidates = append(result.Candidates, *toSong(r.Candidate))
+	}
+
+	best, ok := c.Matcher.Best(mq, candidates, policy)
+	if best == nil {
+		c.Logger.Debug("Every candidate was excluded by the version policy", map[string]interface{}{
+			"query":  q,
+			"policy": policy,
+		})
+
+		return result, nil
+	}
+
+	result.Status = entities.MatchStatusAmbiguous
+	result.Score = best.Score
+
+	if !ok {
+		c.Logger.Debug("No candidate above the match threshold", map[string]interface{}{
+			"query":     q,
+			"bestTrack": best.Candidate.Title,
+			"bestScore": best.Score,
+		})
+
+		return result, nil
+	}
+
+	result.Status = entities.MatchStatusMatched
+	result.Song = toSong(best.Candidate)
+
+	c.Logger.Debug("Found track", map[string]interface{}{
+		"id":    result.Song.ID,
+		"track": result.Song.Title,
+		"album": result.Song.Album,
+		"score": best.Score,
+	})
+
+	return result, nil
+}
+
+func (c *SpotifyClient) SearchTracks(ctx context.Context, query string) ([]entities.Song, error) {
+	c.Logger.Debug("Searching tracks", map[string]interface{}{
+		"query": query,
+	})
+
+	res, err := c.AuthenticatedClient.Search(
+		ctx,
+		query,
+		spotify.SearchTypeTrack,
+		c.searchOptions()...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Tracks == nil {
+		return nil, nil
+	}
+
+	songs := make([]entities.Song, len(res.Tracks.Tracks))
+	for i, t := range res.Tracks.Tracks {
+		songs[i] = *toSong(toCandidate(t))
+	}
+
+	return songs, nil
+}
+
+func (c *SpotifyClient) GetTracks(ctx context.Context, ids []string) ([]entities.Song, error) {
+	c.Logger.Debug("Getting tracks", map[string]interface{}{
+		"ids": ids,
+	})
+
+	songs := make([]entities.Song, 0, len(ids))
+
+	for start := 0; start < len(ids); start += MaxTracksPerLookup {
+		end := min(start+MaxTracksPerLookup, len(ids))
+
+		chunk := make([]spotify.ID, 0, end-start)
+		for _, id := range ids[start:end] {
+			chunk = append(chunk, spotify.ID(id))
+		}
+
+		tracks, err := c.AuthenticatedClient.GetTracks(ctx, chunk)
+		if err != nil {
 			return nil, err
 		}
 
-		if res.Tracks != nil {
-			song := entities.Song{
-				ID:    res.Tracks.Tracks[0].ID.String(),
-				Title: res.Tracks.Tracks[0].Name,
-				Album: res.Tracks.Tracks[0].Album.Name,
+		for _, t := range tracks {
+			if t != nil {
+				songs = append(songs, *toSong(toCandidate(*t)))
 			}
+		}
+	}
 
-			c.Logger.Debug("Found track", map[string]interface{}{
-				"id":    song.ID,
-				"track": song.Title,
-				"album": song.Album,
-			})
+	return songs, nil
+}
 
-			result.Songs = append(result.Songs, song)
-		}
+func (c *SpotifyClient) searchOptions() []spotify.RequestOption {
+	opts := []spotify.RequestOption{spotify.Limit(c.SearchLimit)}
+
+	if c.Market != "" {
+		opts = append(opts, spotify.Market(c.Market))
 	}
 
-	return result, nil
+	return opts
+}
+
+func toCandidate(t spotify.FullTrack) matching.Candidate {
+	artists := make([]string, len(t.Artists))
+	for i, a := range t.Artists {
+		artists[i] = a.Name
+	}
+
+	return matching.Candidate{
+		ID:          t.ID.String(),
+		Title:       t.Name,
+		Artists:     artists,
+		Album:       t.Album.Name,
+		AlbumType:   t.Album.AlbumType,
+		ReleaseDate: t.Album.ReleaseDate,
+		Popularity:  int(t.Popularity),
+	}
+}
+
+func toSong(c matching.Candidate) *entities.Song {
+	return &entities.Song{
+		ID:      c.ID,
+		Title:   c.Title,
+		Album:   c.Album,
+		Artists: c.Artists,
+	}
+}
+
+func stripQuotes(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
 }
 
 func (c *SpotifyClient) CreatePlaylist(
 	ctx context.Context,
 	title string,
 	description string,
+	visibility entities.PlaylistVisibility,
 ) (*entities.CreatePlaylistOutput, error) {
 	user, err := c.CurrentUser(ctx)
 	if err != nil {
@@ -177,16 +454,17 @@ func (c *SpotifyClient) CreatePlaylist(
 		user.ID,
 		title,
 		description,
-		true,
-		false,
+		visibility.IsPublic(),
+		visibility.Collaborative,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	out := &entities.CreatePlaylistOutput{
-		ID:  playlist.ID.String(),
-		URL: playlist.ExternalURLs["spotify"],
+		ID:         playlist.ID.String(),
+		URL:        playlist.ExternalURLs["spotify"],
+		Visibility: entities.NewPlaylistVisibility(!playlist.IsPublic, playlist.Collaborative),
 	}
 
 	return out, nil
@@ -201,9 +479,130 @@ func (c *SpotifyClient) AddTracksToPlaylist(
 		"song_ids":    input.Tracks,
 	})
 
-	if _, err := c.AuthenticatedClient.AddTracksToPlaylist(ctx, input.GetPlaylistID(), input.GetTrackIDs()...); err != nil {
+	ids := input.GetTrackIDs()
+
+	return c.addTracksInChunks(ctx, input.GetPlaylistID(), ids, 0, len(ids))
+}
+
+func (c *SpotifyClient) GetPlaylist(ctx context.Context, playlistID string) (*entities.CreatePlaylistOutput, error) {
+	playlist, err := c.AuthenticatedClient.GetPlaylist(
+		ctx,
+		spotify.ID(playlistID),
+		spotify.Fields("id,external_urls,public,collaborative"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CreatePlaylistOutput{
+		ID:         playlist.ID.String(),
+		URL:        playlist.ExternalURLs["spotify"],
+		Visibility: entities.NewPlaylistVisibility(!playlist.IsPublic, playlist.Collaborative),
+	}, nil
+}
+
+func (c *SpotifyClient) ReplacePlaylistTracks(
+	ctx context.Context,
+	input entities.AddTracksToPlaylistClientInput,
+) error {
+	c.Logger.Debug("Replacing playlist tracks...", map[string]interface{}{
+		"playlist_id": input.PlaylistID,
+		"song_ids":    input.Tracks,
+	})
+
+	ids := input.GetTrackIDs()
+	first := ids[:min(len(ids), MaxTracksPerRequest)]
+
+	if err := c.retryChunk(ctx, func() error {
+		return c.AuthenticatedClient.ReplacePlaylistTracks(ctx, input.GetPlaylistID(), first...)
+	}); err != nil {
 		return err
 	}
 
+	return c.addTracksInChunks(ctx, input.GetPlaylistID(), ids[len(first):], len(first), len(ids))
+}
+
+// GetPlaylistTracks returns the tracks of a playlist in order. Episodes and local files are
+// skipped, playlists made by this tool only hold catalog tracks.
+func (c *SpotifyClient) GetPlaylistTracks(ctx context.Context, playlistID string) ([]entities.Song, error) {
+	page, err := c.AuthenticatedClient.GetPlaylistItems(
+		ctx,
+		spotify.ID(playlistID),
+		spotify.Limit(MaxTracksPerRequest),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var songs []entities.Song
+
+	for {
+		for _, item := range page.Items {
+			if item.Track.Track == nil || item.Track.Track.ID == "" {
+				continue
+			}
+
+			songs = append(songs, *toSong(toCandidate(*item.Track.Track)))
+		}
+
+		if err := c.AuthenticatedClient.NextPage(ctx, page); err != nil {
+			if errors.Is(err, spotify.ErrNoMorePages) {
+				return songs, nil
+			}
+
+			return nil, err
+		}
+	}
+}
+
+func (c *SpotifyClient) RemovePlaylistTracks(
+	ctx context.Context,
+	playlistID string,
+	items []entities.PlaylistItem,
+) error {
+	c.Logger.Debug("Removing tracks from playlist...", map[string]interface{}{
+		"playlist_id": playlistID,
+		"items":       items,
+	})
+
+	// Removing from the end first keeps the positions of the remaining chunks valid.
+	sorted := slices.Clone(items)
+	slices.SortFunc(sorted, func(a, b entities.PlaylistItem) int {
+		return b.Position - a.Position
+	})
+
+	for start := 0; start < len(sorted); start += MaxTracksPerRequest {
+		end := min(start+MaxTracksPerRequest, len(sorted))
+
+		tracks := make([]spotify.TrackToRemove, 0, end-start)
+		for _, item := range sorted[start:end] {
+			tracks = append(tracks, spotify.NewTrackToRemove(item.Song.ID, []int{item.Position}))
+		}
+
+		if _, err := c.AuthenticatedClient.RemoveTracksFromPlaylistOpt(ctx, spotify.ID(playlistID), tracks, ""); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func (c *SpotifyClient) ReorderPlaylistTrack(
+	ctx context.Context,
+	playlistID string,
+	from int,
+	insertBefore int,
+) error {
+	_, err := c.AuthenticatedClient.ReorderPlaylistTracks(ctx, spotify.ID(playlistID), spotify.PlaylistReorderOptions{
+		RangeStart:   spotify.Numeric(from),
+		RangeLength:  1,
+		InsertBefore: spotify.Numeric(insertBefore),
+	})
+
+	return err
+}
+
+// UploadPlaylistCover sets a JPEG as the playlist image, it needs the ugc-image-upload scope.
+func (c *SpotifyClient) UploadPlaylistCover(ctx context.Context, playlistID string, image []byte) error {
+	return c.AuthenticatedClient.SetPlaylistImage(ctx, spotify.ID(playlistID), bytes.NewReader(image))
+}
@@ -0,0 +1,41 @@
+package spotify_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SpotifyClientTestSuite struct {
+	suite.Suite
+}
+
+func TestSpotifyClient(t *testing.T) {
+	suite.Run(t, new(SpotifyClientTestSuite))
+}
+
+func (s *SpotifyClientTestSuite) TestNewSpotifyClient() {
+	build := func(concurrency int, limit int) *client.SpotifyClient {
+		c := client.NewSpotifyClient(new(mocks.LoggerMock), "", "", "", concurrency, limit, "", nil)
+		return c.(*client.SpotifyClient)
+	}
+
+	s.Run("Should keep the search settings within range", func() {
+		s.Equal(4, build(4, 10).SearchConcurrency)
+		s.Equal(10, build(4, 10).SearchLimit)
+	})
+
+	s.Run("Should search at least one at a time for one result", func() {
+		c := build(0, 0)
+
+		s.Equal(1, c.SearchConcurrency)
+		s.Equal(1, c.SearchLimit)
+	})
+
+	s.Run("Should cap the search limit at what Spotify accepts", func() {
+		s.Equal(client.MaxSearchLimit, build(4, 500).SearchLimit)
+	})
+}
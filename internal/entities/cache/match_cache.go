@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+type Entry struct {
+	Result   spotify.SongResult `json:"result"`
+	StoredAt time.Time          `json:"stored_at"`
+}
+
+type MatchCache struct {
+	Entries map[string]Entry `json:"entries"`
+}
+
+type Stats struct {
+	Entries int
+	Expired int
+	Matched int
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// Search holds the settings every search is made with, as configured in the [spotify] and
+// [matching] sections.
+type Search struct {
+	Market     string
+	Threshold  float64
+	Candidates int
+}
+
+// Key identifies a search by everything that can change its outcome: the search settings, the
+// normalized artist and title and the version policy. Changing the threshold or the number of
+// candidates misses the entries stored before, so songs that weren't found are searched again.
+func Key(search Search, artist string, query spotify.SongQuery, policy matching.Policy) string {
+	exclude := make([]string, len(policy.Exclude))
+	for i, v := range policy.Exclude {
+		exclude[i] = string(v)
+	}
+
+	slices.Sort(exclude)
+
+	return strings.Join([]string{
+		strings.ToLower(search.Market),
+		fmt.Sprintf("%g,%d", search.Threshold, search.Candidates),
+		matching.NormalizeArtist(artist),
+		matching.NormalizeTitle(query.Title),
+		matching.NormalizeArtist(query.OriginalArtist),
+		fmt.Sprintf("%s,%s,%s", policy.Preference, strings.Join(exclude, "+"), policy.Covers),
+	}, "|")
+}
+
+func (c *MatchCache) Get(key string, ttl time.Duration, now time.Time) (*spotify.SongResult, bool) {
+	entry, ok := c.Entries[key]
+	if !ok || entry.expired(ttl, now) {
+		return nil, false
+	}
+
+	return &entry.Result, true
+}
+
+func (c *MatchCache) Put(key string, result spotify.SongResult, now time.Time) {
+	if c.Entries == nil {
+		c.Entries = map[string]Entry{}
+	}
+
+	c.Entries[key] = Entry{
+		Result:   result,
+		StoredAt: now,
+	}
+}
+
+func (c *MatchCache) Prune(ttl time.Duration, now time.Time) int {
+	pruned := 0
+
+	for key, entry := range c.Entries {
+		if entry.expired(ttl, now) {
+			delete(c.Entries, key)
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+func (c *MatchCache) Stats(ttl time.Duration, now time.Time) Stats {
+	stats := Stats{Entries: len(c.Entries)}
+
+	for _, entry := range c.Entries {
+		if entry.expired(ttl, now) {
+			stats.Expired++
+		}
+
+		if entry.Result.Status == spotify.MatchStatusMatched {
+			stats.Matched++
+		}
+
+		if stats.Oldest.IsZero() || entry.StoredAt.Before(stats.Oldest) {
+			stats.Oldest = entry.StoredAt
+		}
+
+		if entry.StoredAt.After(stats.Newest) {
+			stats.Newest = entry.StoredAt
+		}
+	}
+
+	return stats
+}
+
+func (e Entry) expired(ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && now.Sub(e.StoredAt) > ttl
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+type MatchCacheTestSuite struct {
+	suite.Suite
+}
+
+func TestMatchCache(t *testing.T) {
+	suite.Run(t, new(MatchCacheTestSuite))
+}
+
+func (s *MatchCacheTestSuite) TestKey() {
+	policy := matching.Policy{Preference: matching.PreferAny, Covers: matching.CoversFallback}
+	br := Search{Market: "BR", Threshold: 0.6, Candidates: 10}
+
+	s.Run("Should ignore case and punctuation", func() {
+		a := Key(br, "The Beatles", spotify.SongQuery{Title: "Here Comes the Sun"}, policy)
+		b := Key(Search{Market: "br", Threshold: 0.6, Candidates: 10}, "beatles", spotify.SongQuery{Title: "here comes the sun!"}, policy)
+
+		s.Equal(a, b)
+	})
+
+	s.Run("Should ignore the order of excluded variants", func() {
+		a := Key(Search{}, "blink-182", spotify.SongQuery{Title: "Dammit"}, matching.Policy{
+			Exclude: []matching.Variant{matching.VariantKaraoke, matching.VariantLive},
+		})
+		b := Key(Search{}, "blink-182", spotify.SongQuery{Title: "Dammit"}, matching.Policy{
+			Exclude: []matching.Variant{matching.VariantLive, matching.VariantKaraoke},
+		})
+
+		s.Equal(a, b)
+	})
+
+	s.Run("Should change with the search settings and the policy", func() {
+		base := Key(br, "blink-182", spotify.SongQuery{Title: "Dammit"}, policy)
+
+		s.NotEqual(base, Key(Search{Market: "US", Threshold: 0.6, Candidates: 10}, "blink-182", spotify.SongQuery{Title: "Dammit"}, policy))
+		s.NotEqual(base, Key(br, "blink-182", spotify.SongQuery{Title: "Dammit"}, matching.Policy{
+			Preference: matching.PreferLive,
+			Covers:     matching.CoversFallback,
+		}))
+		s.NotEqual(base, Key(Search{Market: "BR", Threshold: 0.5, Candidates: 10}, "blink-182", spotify.SongQuery{Title: "Dammit"}, policy))
+		s.NotEqual(base, Key(Search{Market: "BR", Threshold: 0.6, Candidates: 20}, "blink-182", spotify.SongQuery{Title: "Dammit"}, policy))
+		s.NotEqual(base, Key(br, "blink-182", spotify.SongQuery{Title: "Dammit", OriginalArtist: "Someone"}, policy))
+	})
+}
+
+func (s *MatchCacheTestSuite) TestGetAndPut() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	result := spotify.SongResult{Query: "Dammit", Status: spotify.MatchStatusMatched}
+
+	s.Run("Should return fresh entries", func() {
+		c := &MatchCache{}
+		c.Put("key", result, now)
+
+		got, ok := c.Get("key", time.Hour, now.Add(30*time.Minute))
+
+		s.True(ok)
+		s.Equal(result, *got)
+	})
+
+	s.Run("Should not return expired entries", func() {
+		c := &MatchCache{}
+		c.Put("key", result, now)
+
+		_, ok := c.Get("key", time.Hour, now.Add(2*time.Hour))
+
+		s.False(ok)
+	})
+
+	s.Run("Should never expire entries without a TTL", func() {
+		c := &MatchCache{}
+		c.Put("key", result, now)
+
+		_, ok := c.Get("key", 0, now.Add(24*365*time.Hour))
+
+		s.True(ok)
+	})
+}
+
+func (s *MatchCacheTestSuite) TestStatsAndPrune() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	c := &MatchCache{}
+	c.Put("old", spotify.SongResult{Status: spotify.MatchStatusNotFound}, now.Add(-48*time.Hour))
+	c.Put("new", spotify.SongResult{Status: spotify.MatchStatusMatched}, now)
+
+	stats := c.Stats(24*time.Hour, now)
+
+	s.Equal(Stats{Entries: 2, Expired: 1, Matched: 1, Oldest: now.Add(-48 * time.Hour), Newest: now}, stats)
+	s.Equal(1, c.Prune(24*time.Hour, now))
+	s.Len(c.Entries, 1)
+}
@@ -0,0 +1,190 @@
+package history
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionSynced  Action = "synced"
+)
+
+type Entry struct {
+	SetlistID   string    `json:"setlist_id"`
+	SetlistURL  string    `json:"setlist_url"`
+	Part        string    `json:"part,omitempty"`
+	VersionID   string    `json:"version_id"`
+	Artist      string    `json:"artist"`
+	EventDate   string    `json:"event_date"`
+	Title       string    `json:"title"`
+	PlaylistID  string    `json:"playlist_id"`
+	PlaylistURL string    `json:"playlist_url"`
+	Action      Action    `json:"action"`
+	Matched     int       `json:"matched"`
+	Unmatched   int       `json:"unmatched"`
+	Options     *Options  `json:"options,omitempty"`
+	Picks       []Pick    `json:"picks,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Pick is the track a song of the setlist was turned into, as confirmed in the match review.
+// A pick without a track is a song that was skipped.
+type Pick struct {
+	Song    string   `json:"song"`
+	TrackID string   `json:"track_id,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Album   string   `json:"album,omitempty"`
+	Artists []string `json:"artists,omitempty"`
+}
+
+// Options are the matching options a playlist was made with, so syncing it picks its tracks the
+// same way, and its visibility, so syncing asks for the scope needed to change it. Entries
+// recorded before they were kept have none.
+type Options struct {
+	VersionPreference string   `json:"version_preference"`
+	Exclude           []string `json:"exclude,omitempty"`
+	Covers            string   `json:"covers"`
+	IncludeTapes      bool     `json:"include_tapes,omitempty"`
+	Sets              string   `json:"sets,omitempty"`
+	Visibility        string   `json:"visibility,omitempty"`
+}
+
+func NewOptions(policy matching.Policy, includeTapes bool, sets string, visibility string) *Options {
+	var exclude []string
+	for _, v := range policy.Exclude {
+		exclude = append(exclude, string(v))
+	}
+
+	return &Options{
+		VersionPreference: string(policy.Preference),
+		Exclude:           exclude,
+		Covers:            string(policy.Covers),
+		IncludeTapes:      includeTapes,
+		Sets:              sets,
+		Visibility:        visibility,
+	}
+}
+
+func (o Options) Policy() (matching.Policy, error) {
+	return matching.NewPolicy(o.VersionPreference, o.Exclude, o.Covers)
+}
+
+type History struct {
+	Entries []Entry `json:"entries"`
+}
+
+type Filter struct {
+	Artist     string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	LatestOnly bool
+}
+
+func (e Entry) EventTime() (time.Time, bool) {
+	t, err := time.Parse(setlistfm.EventDateLayout, e.EventDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+func (h *History) Add(entry Entry) {
+	h.Entries = append(h.Entries, entry)
+}
+
+// BySetlist returns every run of the given setlist, newest first.
+func (h *History) BySetlist(setlistID string) []Entry {
+	var entries []Entry
+
+	for _, e := range h.Entries {
+		if e.SetlistID == setlistID {
+			entries = append(entries, e)
+		}
+	}
+
+	return newestFirst(entries)
+}
+
+// Latest returns the newest run of every setlist, newest first. Each set of a show split
+// into several playlists counts on its own.
+func (h *History) Latest() []Entry {
+	seen := map[[2]string]bool{}
+
+	var entries []Entry
+
+	for _, e := range newestFirst(slices.Clone(h.Entries)) {
+		key := [2]string{e.SetlistID, e.Part}
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+// Filter returns the entries matching every non-zero field of the filter, newest first.
+// Artist is a case-insensitive substring and From/To bound the event date, both inclusive.
+// LatestOnly keeps only the newest run of every setlist.
+func (h *History) Filter(f Filter) []Entry {
+	artist := strings.ToLower(strings.TrimSpace(f.Artist))
+
+	source := h.Entries
+	if f.LatestOnly {
+		source = h.Latest()
+	}
+
+	var entries []Entry
+
+	for _, e := range source {
+		if artist != "" && !strings.Contains(strings.ToLower(e.Artist), artist) {
+			continue
+		}
+
+		if !f.From.IsZero() || !f.To.IsZero() {
+			date, ok := e.EventTime()
+			if !ok {
+				continue
+			}
+
+			if !f.From.IsZero() && date.Before(f.From) {
+				continue
+			}
+
+			if !f.To.IsZero() && date.After(f.To) {
+				continue
+			}
+		}
+
+		entries = append(entries, e)
+	}
+
+	entries = newestFirst(entries)
+
+	if f.Limit > 0 && len(entries) > f.Limit {
+		entries = entries[:f.Limit]
+	}
+
+	return entries
+}
+
+func newestFirst(entries []Entry) []Entry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+
+	return entries
+}
@@ -0,0 +1,135 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+type HistoryTestSuite struct {
+	suite.Suite
+
+	History History
+}
+
+func (s *HistoryTestSuite) SetupTest() {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	s.History = History{}
+	s.History.Add(Entry{SetlistID: "a", Artist: "blink-182", EventDate: "07-04-2024", CreatedAt: base})
+	s.History.Add(Entry{SetlistID: "b", Artist: "Green Day", EventDate: "25-02-2024", CreatedAt: base.Add(time.Hour)})
+	s.History.Add(Entry{SetlistID: "a", Artist: "blink-182", EventDate: "07-04-2024", CreatedAt: base.Add(2 * time.Hour)})
+	s.History.Add(Entry{SetlistID: "c", Artist: "Blink-182", EventDate: "", CreatedAt: base.Add(3 * time.Hour)})
+}
+
+func TestHistory(t *testing.T) {
+	suite.Run(t, new(HistoryTestSuite))
+}
+
+func (s *HistoryTestSuite) TestBySetlist() {
+	s.Run("Should return every run of the setlist, newest first", func() {
+		entries := s.History.BySetlist("a")
+
+		s.Len(entries, 2)
+		s.True(entries[0].CreatedAt.After(entries[1].CreatedAt))
+	})
+
+	s.Run("Should return nothing for an unknown setlist", func() {
+		s.Empty(s.History.BySetlist("unknown"))
+	})
+}
+
+func (s *HistoryTestSuite) TestLatest() {
+	s.Run("Should return the newest run of every setlist", func() {
+		entries := s.History.Latest()
+
+		s.Len(entries, 3)
+		s.Equal("c", entries[0].SetlistID)
+		s.Equal("a", entries[1].SetlistID)
+		s.Equal(time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC), entries[1].CreatedAt)
+		s.Equal("b", entries[2].SetlistID)
+	})
+
+	s.Run("Should keep the newest run of every set of a split show", func() {
+		h := History{Entries: []Entry{
+			{SetlistID: "a", Part: "Main Set", CreatedAt: time.Date(2024, 5, 1, 14, 0, 0, 0, time.UTC)},
+			{SetlistID: "a", Part: "Encore", CreatedAt: time.Date(2024, 5, 1, 14, 0, 1, 0, time.UTC)},
+			{SetlistID: "a", Part: "Encore", PlaylistID: "newer", CreatedAt: time.Date(2024, 5, 2, 14, 0, 0, 0, time.UTC)},
+		}}
+
+		entries := h.Latest()
+
+		s.Len(entries, 2)
+		s.Equal("newer", entries[0].PlaylistID)
+		s.Equal("Main Set", entries[1].Part)
+	})
+}
+
+func (s *HistoryTestSuite) TestFilter() {
+	s.Run("Should return every entry, newest first, without a filter", func() {
+		entries := s.History.Filter(Filter{})
+
+		s.Len(entries, 4)
+		s.Equal("c", entries[0].SetlistID)
+	})
+
+	s.Run("Should filter by artist ignoring case", func() {
+		entries := s.History.Filter(Filter{Artist: "BLINK"})
+
+		s.Len(entries, 3)
+	})
+
+	s.Run("Should filter by event date and skip entries without one", func() {
+		entries := s.History.Filter(Filter{
+			From: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC),
+		})
+
+		s.Len(entries, 2)
+
+		for _, e := range entries {
+			s.Equal("a", e.SetlistID)
+		}
+	})
+
+	s.Run("Should keep only the newest run of every setlist", func() {
+		entries := s.History.Filter(Filter{Artist: "blink", LatestOnly: true})
+
+		s.Len(entries, 2)
+	})
+
+	s.Run("Should limit the number of entries", func() {
+		entries := s.History.Filter(Filter{Limit: 1})
+
+		s.Len(entries, 1)
+		s.Equal("c", entries[0].SetlistID)
+	})
+}
+
+func (s *HistoryTestSuite) TestOptions() {
+	s.Run("Should give back the policy the playlist was made with", func() {
+		policy := matching.Policy{
+			Preference: matching.StudioOnly,
+			Exclude:    []matching.Variant{matching.VariantKaraoke, matching.VariantLive},
+			Covers:     matching.CoversOriginal,
+		}
+
+		options := NewOptions(policy, true, "split", "private")
+		restored, err := options.Policy()
+
+		s.NoError(err)
+		s.Equal(policy, restored)
+		s.True(options.IncludeTapes)
+		s.Equal("split", options.Sets)
+		s.Equal("private", options.Visibility)
+	})
+
+	s.Run("Should return an error for an unknown option", func() {
+		_, err := Options{Covers: "unknown"}.Policy()
+
+		s.Error(err)
+	})
+}
@@ -0,0 +1,137 @@
+package overrides
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	SkipTrack = "skip"
+)
+
+var (
+	trackIDRegex = regexp.MustCompile(`^[0-9A-Za-z]{22}$`)
+)
+
+type Override struct {
+	Artist string `toml:"artist"`
+	Song   string `toml:"song"`
+	Track  string `toml:"track"`
+}
+
+type Overrides struct {
+	Overrides []Override `toml:"override"`
+}
+
+func NewOverride(artist, song, track string) (Override, error) {
+	artist = strings.TrimSpace(artist)
+	song = strings.TrimSpace(song)
+
+	if artist == "" || song == "" {
+		return Override{}, fmt.Errorf("an override needs both an artist and a song")
+	}
+
+	id, err := ParseTrack(track)
+	if err != nil {
+		return Override{}, err
+	}
+
+	return Override{
+		Artist: artist,
+		Song:   song,
+		Track:  id,
+	}, nil
+}
+
+// ParseTrack accepts "skip", a bare track ID, a spotify:track URI or an open.spotify.com track URL.
+func ParseTrack(track string) (string, error) {
+	track = strings.TrimSpace(track)
+
+	if strings.EqualFold(track, SkipTrack) {
+		return SkipTrack, nil
+	}
+
+	id := track
+
+	switch {
+	case strings.HasPrefix(track, "spotify:track:"):
+		id = strings.TrimPrefix(track, "spotify:track:")
+	case strings.HasPrefix(track, "http://"), strings.HasPrefix(track, "https://"):
+		u, err := url.Parse(track)
+		if err != nil {
+			return "", fmt.Errorf("invalid Spotify track URL %q: %w", track, err)
+		}
+
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if u.Host != "open.spotify.com" || len(parts) < 2 || parts[len(parts)-2] != "track" {
+			return "", fmt.Errorf("%q is not a Spotify track URL", track)
+		}
+
+		id = parts[len(parts)-1]
+	}
+
+	if !trackIDRegex.MatchString(id) {
+		return "", fmt.Errorf("%q is neither a Spotify track ID nor %q", track, SkipTrack)
+	}
+
+	return id, nil
+}
+
+func (o Override) IsSkip() bool {
+	return o.Track == SkipTrack
+}
+
+func (o Override) Matches(artist, song string) bool {
+	return key(o.Artist) == key(artist) && key(o.Song) == key(song)
+}
+
+func (o *Overrides) Find(artist, song string) (*Override, bool) {
+	for i := range o.Overrides {
+		if o.Overrides[i].Matches(artist, song) {
+			return &o.Overrides[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func (o *Overrides) Set(override Override) {
+	if existing, ok := o.Find(override.Artist, override.Song); ok {
+		*existing = override
+		return
+	}
+
+	o.Overrides = append(o.Overrides, override)
+}
+
+func (o *Overrides) Remove(artist, song string) bool {
+	for i := range o.Overrides {
+		if o.Overrides[i].Matches(artist, song) {
+			o.Overrides = append(o.Overrides[:i], o.Overrides[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *Overrides) ByArtist(artist string) []Override {
+	if artist == "" {
+		return o.Overrides
+	}
+
+	var found []Override
+	for _, override := range o.Overrides {
+		if key(override.Artist) == key(artist) {
+			found = append(found, override)
+		}
+	}
+
+	return found
+}
+
+func key(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,105 @@
+package overrides
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OverridesTestSuite struct {
+	suite.Suite
+}
+
+func TestOverrides(t *testing.T) {
+	suite.Run(t, new(OverridesTestSuite))
+}
+
+func (s *OverridesTestSuite) TestParseTrack() {
+	cases := []struct {
+		in       string
+		expected string
+		err      bool
+	}{
+		{in: "skip", expected: SkipTrack},
+		{in: " SKIP ", expected: SkipTrack},
+		{in: "4uLU6hMCjMI75M1A2tKUQC", expected: "4uLU6hMCjMI75M1A2tKUQC"},
+		{in: "spotify:track:4uLU6hMCjMI75M1A2tKUQC", expected: "4uLU6hMCjMI75M1A2tKUQC"},
+		{in: "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC?si=abc", expected: "4uLU6hMCjMI75M1A2tKUQC"},
+		{in: "https://open.spotify.com/intl-pt/track/4uLU6hMCjMI75M1A2tKUQC", expected: "4uLU6hMCjMI75M1A2tKUQC"},
+		{in: "https://open.spotify.com/album/4uLU6hMCjMI75M1A2tKUQC", err: true},
+		{in: "https://example.com/track/4uLU6hMCjMI75M1A2tKUQC", err: true},
+		{in: "not-a-track", err: true},
+		{in: "", err: true},
+	}
+
+	for _, c := range cases {
+		s.Run(c.in, func() {
+			id, err := ParseTrack(c.in)
+
+			if c.err {
+				s.Error(err)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(c.expected, id)
+		})
+	}
+}
+
+func (s *OverridesTestSuite) TestNewOverride() {
+	s.Run("Should require an artist and a song", func() {
+		_, err := NewOverride(" ", "Dammit", "skip")
+
+		s.ErrorContains(err, "needs both an artist and a song")
+	})
+
+	s.Run("Should trim the artist and song", func() {
+		o, err := NewOverride(" blink-182 ", " Dammit ", "skip")
+
+		s.NoError(err)
+		s.Equal(Override{Artist: "blink-182", Song: "Dammit", Track: SkipTrack}, o)
+		s.True(o.IsSkip())
+	})
+}
+
+func (s *OverridesTestSuite) TestOverrides() {
+	s.Run("Should find overrides ignoring case and spacing", func() {
+		o := Overrides{Overrides: []Override{{Artist: "blink-182", Song: "All the Small Things", Track: "skip"}}}
+
+		found, ok := o.Find("Blink-182", "all  the small things")
+
+		s.True(ok)
+		s.Equal("skip", found.Track)
+	})
+
+	s.Run("Should replace an existing override", func() {
+		o := Overrides{Overrides: []Override{{Artist: "blink-182", Song: "Dammit", Track: "skip"}}}
+
+		o.Set(Override{Artist: "Blink-182", Song: "dammit", Track: "4uLU6hMCjMI75M1A2tKUQC"})
+
+		s.Len(o.Overrides, 1)
+		s.Equal("4uLU6hMCjMI75M1A2tKUQC", o.Overrides[0].Track)
+	})
+
+	s.Run("Should remove an override", func() {
+		o := Overrides{Overrides: []Override{
+			{Artist: "blink-182", Song: "Dammit", Track: "skip"},
+			{Artist: "Green Day", Song: "Basket Case", Track: "skip"},
+		}}
+
+		s.True(o.Remove("blink-182", "Dammit"))
+		s.False(o.Remove("blink-182", "Dammit"))
+		s.Equal([]Override{{Artist: "Green Day", Song: "Basket Case", Track: "skip"}}, o.Overrides)
+	})
+
+	s.Run("Should filter overrides by artist", func() {
+		o := Overrides{Overrides: []Override{
+			{Artist: "blink-182", Song: "Dammit", Track: "skip"},
+			{Artist: "Green Day", Song: "Basket Case", Track: "skip"},
+		}}
+
+		s.Len(o.ByArtist(""), 2)
+		s.Equal([]Override{{Artist: "Green Day", Song: "Basket Case", Track: "skip"}}, o.ByArtist("green day"))
+	})
+}
@@ -0,0 +1,40 @@
+package setlistfm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var mbidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsMBID tells whether the value is a MusicBrainz ID, e.g. "53a3e1de-9567-4eb8-9d4d-6e1e4b4b1c07".
+func IsMBID(value string) bool {
+	return mbidPattern.MatchString(strings.TrimSpace(value))
+}
+
+// AmbiguousArtistError is returned when several artists go by the searched name.
+type AmbiguousArtistError struct {
+	Name       string
+	Candidates []Artist
+}
+
+func (e *AmbiguousArtistError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+
+	for _, a := range e.Candidates {
+		if a.Disambiguation != "" {
+			candidates = append(candidates, fmt.Sprintf("%s (%s): %s", a.Name, a.Disambiguation, a.MBID))
+			continue
+		}
+
+		candidates = append(candidates, fmt.Sprintf("%s: %s", a.Name, a.MBID))
+	}
+
+	return fmt.Sprintf(
+		"%d artists are named %q on Setlist.fm, pass the MBID of the one you mean instead: %s",
+		len(e.Candidates),
+		e.Name,
+		strings.Join(candidates, "; "),
+	)
+}
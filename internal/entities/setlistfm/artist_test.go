@@ -0,0 +1,47 @@
+package setlistfm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ArtistTestSuite struct {
+	suite.Suite
+}
+
+func TestArtist(t *testing.T) {
+	suite.Run(t, new(ArtistTestSuite))
+}
+
+func (s *ArtistTestSuite) TestIsMBID() {
+	s.Run("Should accept a MusicBrainz ID", func() {
+		s.True(IsMBID("b10bbbfc-cf9e-42e0-be17-e2c3e1d2600d"))
+		s.True(IsMBID(" 728EA90D-279D-4ABD-A3C4-F2C3F4B1C1B3 "))
+	})
+
+	s.Run("Should reject anything else", func() {
+		s.False(IsMBID("Paramore"))
+		s.False(IsMBID("b10bbbfc-cf9e-42e0-be17"))
+		s.False(IsMBID(""))
+	})
+}
+
+func (s *ArtistTestSuite) TestAmbiguousArtistError() {
+	s.Run("Should list the candidates with their MBIDs", func() {
+		err := &AmbiguousArtistError{
+			Name: "Nirvana",
+			Candidates: []Artist{
+				{MBID: "5b11f4ce-a62d-471e-81fc-a69a8278c7da", Name: "Nirvana", Disambiguation: "90s US grunge band"},
+				{MBID: "9282c8b4-ca0b-4c6b-b7e3-4f7762dfc4d6", Name: "Nirvana"},
+			},
+		}
+
+		s.Equal(
+			`2 artists are named "Nirvana" on Setlist.fm, pass the MBID of the one you mean instead: `+
+				"Nirvana (90s US grunge band): 5b11f4ce-a62d-471e-81fc-a69a8278c7da; "+
+				"Nirvana: 9282c8b4-ca0b-4c6b-b7e3-4f7762dfc4d6",
+			err.Error(),
+		)
+	})
+}
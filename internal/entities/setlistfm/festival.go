@@ -0,0 +1,271 @@
+package setlistfm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxFestivalSpan is how far apart the shows of a festival can be.
+const MaxFestivalSpan = 14 * 24 * time.Hour
+
+// Festival is an edition of a festival on Setlist.fm and the setlists of its lineup.
+type Festival struct {
+	ID    string
+	Name  string
+	URL   string
+	Shows Shows
+}
+
+// FestivalDays keeps the shows played at the venue of the anchor show on the days of its
+// festival: the run of days around the anchor's own, at most one day off between them and no
+// longer than MaxFestivalSpan, as the venue may host other events the same year. The anchor
+// is kept even when the shows miss it. They are returned in date order, in the order they were
+// given within a day.
+func FestivalDays(anchor *Set, shows Shows) Shows {
+	anchorDate, ok := anchor.EventTime()
+	if !ok {
+		return Shows{anchor}
+	}
+
+	var candidates Shows
+	days := map[time.Time]bool{anchorDate: true}
+	missed := true
+
+	for _, set := range shows {
+		date, ok := set.EventTime()
+		if !ok || set.Venue.ID != anchor.Venue.ID {
+			continue
+		}
+
+		candidates = append(candidates, set)
+		days[date] = true
+		missed = missed && set.ID != anchor.ID
+	}
+
+	if missed {
+		candidates = append(candidates, anchor)
+	}
+
+	first, last := anchorDate, anchorDate
+
+	for next := nextDay(days, first, -1); !next.IsZero() && last.Sub(next) <= MaxFestivalSpan; next = nextDay(days, first, -1) {
+		first = next
+	}
+
+	for next := nextDay(days, last, 1); !next.IsZero() && next.Sub(first) <= MaxFestivalSpan; next = nextDay(days, last, 1) {
+		last = next
+	}
+
+	var kept Shows
+
+	for _, set := range candidates {
+		if date, _ := set.EventTime(); !date.Before(first) && !date.After(last) {
+			kept = append(kept, set)
+		}
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		a, _ := kept[i].EventTime()
+		b, _ := kept[j].EventTime()
+
+		return a.Before(b)
+	})
+
+	return kept
+}
+
+// nextDay returns the closest day with shows one or two days away from the given one, going
+// back or forth as dir says, or the zero time when there is none.
+func nextDay(days map[time.Time]bool, from time.Time, dir int) time.Time {
+	for _, n := range []int{1, 2} {
+		if day := from.AddDate(0, 0, n*dir); days[day] {
+			return day
+		}
+	}
+
+	return time.Time{}
+}
+
+// LineupSetlist is a setlist linked from a festival page, as read off the link, e.g.
+// "setlist/paramore/2024/donington-park-castle-donington-england-3bd5a4c8.html".
+type LineupSetlist struct {
+	ID        string
+	Year      string
+	VenueSlug string
+}
+
+// ArtistFilter picks artists out of a lineup. Names are compared ignoring case, accents and
+// extra spaces. An empty Include keeps every artist that isn't excluded.
+type ArtistFilter struct {
+	Include []string
+	Exclude []string
+}
+
+func (f ArtistFilter) Allows(artist string) bool {
+	key := nameKey(artist)
+
+	for _, name := range f.Exclude {
+		if nameKey(name) == key {
+			return false
+		}
+	}
+
+	if len(f.Include) == 0 {
+		return true
+	}
+
+	for _, name := range f.Include {
+		if nameKey(name) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+type GetFestivalSetlistsInput struct {
+	URL string
+	// Date keeps the shows of a single day of the festival, as DD-MM-YYYY or YYYY-MM-DD.
+	Date    string
+	Artists ArtistFilter
+}
+
+func (in GetFestivalSetlistsInput) Validate() error {
+	if _, err := NewGetSetlistByIDInput(in.URL).FestivalID(); err != nil {
+		return err
+	}
+
+	if _, err := normalizeEventDate(in.Date); err != nil {
+		return err
+	}
+
+	for _, name := range in.Artists.Include {
+		if !in.Artists.Allows(name) {
+			return errors.New("the same artist can't be both included and excluded")
+		}
+	}
+
+	return nil
+}
+
+// Path returns the path of the festival page on the Setlist.fm website.
+func (in GetFestivalSetlistsInput) Path() (string, error) {
+	ref, err := NewGetSetlistByIDInput(in.URL).festivalRef()
+	if err != nil {
+		return "", err
+	}
+
+	return ref.Path, nil
+}
+
+// Anchor picks a setlist of the festival out of the ones linked from its page, to find out
+// where and when the festival was played: the first one of the festival year at the venue most
+// of them share, as the page also links to setlists of other events.
+func (in GetFestivalSetlistsInput) Anchor(links []LineupSetlist) (LineupSetlist, bool) {
+	year := ""
+	if ref, err := NewGetSetlistByIDInput(in.URL).festivalRef(); err == nil {
+		year = festivalYear(ref)
+	}
+
+	var sameYear []LineupSetlist
+	venues := make(map[string]int)
+	venue := ""
+
+	for _, l := range links {
+		if year != "" && l.Year != year {
+			continue
+		}
+
+		sameYear = append(sameYear, l)
+
+		venues[l.VenueSlug]++
+		if venues[l.VenueSlug] > venues[venue] {
+			venue = l.VenueSlug
+		}
+	}
+
+	for _, l := range sameYear {
+		if l.VenueSlug == venue {
+			return l, true
+		}
+	}
+
+	return LineupSetlist{}, false
+}
+
+// Search returns the search for the setlists played at the venue of the anchor show, on the
+// festival day when one was given or else over the year of the festival.
+func (in GetFestivalSetlistsInput) Search(anchor *Set) (SearchSetlistsInput, error) {
+	anchorDate, ok := anchor.EventTime()
+	if anchor.Venue.ID == "" || !ok {
+		return SearchSetlistsInput{}, fmt.Errorf("the festival setlist %s has no venue or date", anchor.ID)
+	}
+
+	search := SearchSetlistsInput{VenueID: anchor.Venue.ID}
+
+	date, err := normalizeEventDate(in.Date)
+	if err != nil {
+		return SearchSetlistsInput{}, err
+	}
+
+	if date == "" {
+		search.Year = fmt.Sprint(anchorDate.Year())
+		return search, nil
+	}
+
+	if day, _ := time.Parse(EventDateLayout, date); day.Sub(anchorDate).Abs() > MaxFestivalSpan {
+		return SearchSetlistsInput{}, fmt.Errorf("%s isn't a day of the festival, it was played around %s", in.Date, anchor.EventDate)
+	}
+
+	search.Date = date
+
+	return search, nil
+}
+
+// festivalYear returns the year in the path of a festival page, e.g. "/festival/2024/...".
+func festivalYear(ref Ref) string {
+	segments := strings.Split(strings.Trim(ref.Path, "/"), "/")
+	if len(segments) < 3 || len(segments[1]) != 4 {
+		return ""
+	}
+
+	for _, r := range segments[1] {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+
+	return segments[1]
+}
+
+// Name guesses the festival name from its URL, e.g. "Download Festival 2024" out of
+// https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html.
+func (in GetFestivalSetlistsInput) Name() string {
+	ref, err := NewGetSetlistByIDInput(in.URL).festivalRef()
+	if err != nil {
+		return ""
+	}
+
+	words := strings.FieldsFunc(ref.Slug(), func(r rune) bool { return r == '-' })
+
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Matches tells whether the show belongs to the festival day and to an artist the filter allows.
+func (in GetFestivalSetlistsInput) Matches(set Set) bool {
+	if date, _ := normalizeEventDate(in.Date); date != "" && set.EventDate != date {
+		return false
+	}
+
+	return in.Artists.Allows(set.Artist.Name)
+}
@@ -0,0 +1,182 @@
+package setlistfm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FestivalTestSuite struct {
+	suite.Suite
+}
+
+func TestFestival(t *testing.T) {
+	suite.Run(t, new(FestivalTestSuite))
+}
+
+const anyFestivalURL = "https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html"
+
+func (s *FestivalTestSuite) TestArtistFilter() {
+	s.Run("Should allow every artist without a filter", func() {
+		s.True(ArtistFilter{}.Allows("Paramore"))
+	})
+
+	s.Run("Should allow only the included artists", func() {
+		filter := ArtistFilter{Include: []string{"mÅneskin", "Paramore"}}
+
+		s.True(filter.Allows("Måneskin"))
+		s.True(filter.Allows(" paramore "))
+		s.False(filter.Allows("Avenged Sevenfold"))
+	})
+
+	s.Run("Should leave the excluded artists out", func() {
+		filter := ArtistFilter{Exclude: []string{"avenged  sevenfold"}}
+
+		s.False(filter.Allows("Avenged Sevenfold"))
+		s.True(filter.Allows("Paramore"))
+	})
+}
+
+func (s *FestivalTestSuite) TestAnchor() {
+	link := func(id string, year string, venue string) LineupSetlist {
+		return LineupSetlist{ID: id, Year: year, VenueSlug: venue}
+	}
+
+	s.Run("Should pick a setlist of the festival year at the venue most of them share", func() {
+		anchor, ok := GetFestivalSetlistsInput{URL: anyFestivalURL}.Anchor([]LineupSetlist{
+			link("1", "2023", "donington-park"),
+			link("2", "2024", "wembley-stadium"),
+			link("3", "2024", "donington-park"),
+			link("4", "2024", "donington-park"),
+		})
+
+		s.True(ok)
+		s.Equal("3", anchor.ID)
+	})
+
+	s.Run("Should find none without setlists of the festival year", func() {
+		_, ok := GetFestivalSetlistsInput{URL: anyFestivalURL}.Anchor([]LineupSetlist{link("1", "2023", "donington-park")})
+
+		s.False(ok)
+	})
+}
+
+func (s *FestivalTestSuite) TestFestivalDays() {
+	show := func(id string, venue string, date string) *Set {
+		return &Set{ID: id, Venue: Venue{ID: venue}, EventDate: date}
+	}
+
+	anchor := show("anchor", "any-venue-id", "14-06-2024")
+
+	ids := func(shows Shows) []string {
+		var out []string
+		for _, set := range shows {
+			out = append(out, set.ID)
+		}
+
+		return out
+	}
+
+	s.Run("Should keep the shows at the venue on the days next to each other, in date order", func() {
+		shows := FestivalDays(anchor, Shows{
+			show("later-event", "any-venue-id", "23-08-2024"),
+			show("day-4", "any-venue-id", "17-06-2024"),
+			show("day-2", "any-venue-id", "15-06-2024"),
+			show("other-venue", "another-venue-id", "15-06-2024"),
+			anchor,
+			show("day-0", "any-venue-id", "13-06-2024"),
+		})
+
+		s.Equal([]string{"day-0", "anchor", "day-2", "day-4"}, ids(shows))
+	})
+
+	s.Run("Should keep the anchor when the shows miss it", func() {
+		shows := FestivalDays(anchor, Shows{show("day-2", "any-venue-id", "15-06-2024")})
+
+		s.Equal([]string{"anchor", "day-2"}, ids(shows))
+	})
+
+	s.Run("Should not go beyond the longest a festival can last", func() {
+		var shows Shows
+		for day := 1; day <= 30; day++ {
+			shows = append(shows, show(fmt.Sprint(day), "any-venue-id", fmt.Sprintf("%02d-06-2024", day)))
+		}
+
+		kept := FestivalDays(anchor, shows)
+
+		s.Equal("1", kept[0].ID)
+		s.Equal("15", kept[len(kept)-1].ID)
+	})
+}
+
+func (s *FestivalTestSuite) TestSearch() {
+	anchor := &Set{ID: "any-id", Venue: Venue{ID: "any-venue-id"}, EventDate: "14-06-2024"}
+
+	s.Run("Should search the venue over the festival year", func() {
+		search, err := GetFestivalSetlistsInput{URL: anyFestivalURL}.Search(anchor)
+
+		s.NoError(err)
+		s.Equal(SearchSetlistsInput{VenueID: "any-venue-id", Year: "2024"}, search)
+	})
+
+	s.Run("Should search the venue on the festival day", func() {
+		search, err := GetFestivalSetlistsInput{URL: anyFestivalURL, Date: "2024-06-15"}.Search(anchor)
+
+		s.NoError(err)
+		s.Equal(SearchSetlistsInput{VenueID: "any-venue-id", Date: "15-06-2024"}, search)
+	})
+
+	s.Run("Should reject a day far from the festival", func() {
+		_, err := GetFestivalSetlistsInput{URL: anyFestivalURL, Date: "2024-08-23"}.Search(anchor)
+
+		s.ErrorContains(err, "isn't a day of the festival")
+	})
+
+	s.Run("Should reject a setlist without venue", func() {
+		_, err := GetFestivalSetlistsInput{URL: anyFestivalURL}.Search(&Set{ID: "any-id", EventDate: "14-06-2024"})
+
+		s.ErrorContains(err, "has no venue or date")
+	})
+}
+
+func (s *FestivalTestSuite) TestGetFestivalSetlistsInput() {
+	s.Run("Should accept a festival URL", func() {
+		s.NoError(GetFestivalSetlistsInput{URL: anyFestivalURL, Date: "2024-06-14"}.Validate())
+	})
+
+	s.Run("Should reject an invalid day", func() {
+		err := GetFestivalSetlistsInput{URL: anyFestivalURL, Date: "june 14"}.Validate()
+
+		s.ErrorContains(err, `invalid date "june 14"`)
+	})
+
+	s.Run("Should reject an artist both included and excluded", func() {
+		err := GetFestivalSetlistsInput{
+			URL:     anyFestivalURL,
+			Artists: ArtistFilter{Include: []string{"Paramore"}, Exclude: []string{"paramore"}},
+		}.Validate()
+
+		s.ErrorContains(err, "both included and excluded")
+	})
+
+	s.Run("Should name the festival after its URL", func() {
+		in := GetFestivalSetlistsInput{URL: anyFestivalURL}
+
+		s.Equal("Download Festival 2024", in.Name())
+	})
+
+	s.Run("Should return the festival page path", func() {
+		path, err := GetFestivalSetlistsInput{URL: anyFestivalURL}.Path()
+
+		s.NoError(err)
+		s.Equal("/festival/2024/download-festival-2024-73d44e99.html", path)
+	})
+
+	s.Run("Should match the shows of the festival day", func() {
+		in := GetFestivalSetlistsInput{URL: anyFestivalURL, Date: "14-06-2024"}
+
+		s.True(in.Matches(Set{EventDate: "14-06-2024", Artist: Artist{Name: "Paramore"}}))
+		s.False(in.Matches(Set{EventDate: "15-06-2024", Artist: Artist{Name: "Paramore"}}))
+	})
+}
@@ -0,0 +1,17 @@
+package setlistfm
+
+import "errors"
+
+type GetLatestSetlistInput struct {
+	ArtistMBID string
+	// MaxPages caps how many pages of the artist setlists are read, 0 meaning no limit.
+	MaxPages int
+}
+
+func (in GetLatestSetlistInput) Validate() error {
+	if in.ArtistMBID == "" {
+		return errors.New("artist MBID is empty")
+	}
+
+	return nil
+}
@@ -1,11 +1,5 @@
 package setlistfm
 
-import (
-	"errors"
-	"net/url"
-	"strings"
-)
-
 type GetSetlistByIDInput struct {
 	URL string
 }
@@ -16,32 +10,52 @@ func NewGetSetlistByIDInput(url string) GetSetlistByIDInput {
 	}
 }
 
+// IsFestival tells whether the URL points to a festival, which holds the setlists of every
+// artist of its lineup, instead of a single setlist.
+func (in GetSetlistByIDInput) IsFestival() bool {
+	ref, err := ParseRef(in.URL)
+	return err == nil && ref.Kind == RefFestival
+}
+
+// SetlistID returns the ID of the setlist the URL (or bare ID) points to.
 func (in GetSetlistByIDInput) SetlistID() (*string, error) {
-	if err := in.Validate(); err != nil {
+	ref, err := ParseRef(in.URL)
+	if err != nil {
 		return nil, err
 	}
 
-	parsedURL, err := url.Parse(in.URL)
+	if ref.Kind == RefFestival {
+		return nil, &RefError{Input: in.URL, Err: ErrFestivalURL}
+	}
+
+	return &ref.ID, nil
+}
+
+// FestivalID returns the ID at the end of a festival URL, e.g. "73d44e99" in
+// https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html.
+func (in GetSetlistByIDInput) FestivalID() (*string, error) {
+	ref, err := in.festivalRef()
 	if err != nil {
 		return nil, err
 	}
 
-	path := parsedURL.EscapedPath()
-	splittedPath := strings.Split(path, "/")
-	noDashes := strings.Split(splittedPath[len(splittedPath)-1], "-")
-	id := strings.Replace(noDashes[len(noDashes)-1], ".html", "", -1)
-
-	return &id, nil
+	return &ref.ID, nil
 }
 
 func (in GetSetlistByIDInput) Validate() error {
-	if in.URL == "" {
-		return errors.New("URL is empty")
+	_, err := ParseRef(in.URL)
+	return err
+}
+
+func (in GetSetlistByIDInput) festivalRef() (Ref, error) {
+	ref, err := ParseRef(in.URL)
+	if err != nil {
+		return Ref{}, err
 	}
 
-	if !strings.Contains(in.URL, "https://www.setlist.fm/setlist") {
-		return errors.New("URL is not a valid setlist.fm set")
+	if ref.Kind != RefFestival {
+		return Ref{}, &RefError{Input: in.URL, Err: ErrNotFestival}
 	}
 
-	return nil
+	return ref, nil
 }
@@ -10,6 +10,7 @@ type GetSetlistByIDInputTestSuite struct {
 	suite.Suite
 
 	ValidInput    GetSetlistByIDInput
+	FestivalInput GetSetlistByIDInput
 	WrongURLInput GetSetlistByIDInput
 	NoIDInput     GetSetlistByIDInput
 	EmptyInput    GetSetlistByIDInput
@@ -17,7 +18,8 @@ type GetSetlistByIDInputTestSuite struct {
 
 func (s *GetSetlistByIDInputTestSuite) SetupTest() {
 	s.ValidInput = NewGetSetlistByIDInput("https://www.setlist.fm/setlist/blink182/2024/autodromo-de-interlagos-sao-paulo-brazil-53aa1325.html")
-	s.WrongURLInput = NewGetSetlistByIDInput("https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html")
+	s.FestivalInput = NewGetSetlistByIDInput("https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html")
+	s.WrongURLInput = NewGetSetlistByIDInput("https://www.setlist.fm/venue/donington-park-castle-donington-england-73d6a6c5.html")
 	s.NoIDInput = NewGetSetlistByIDInput("https://www.setlist.fm/festival/2024/download-festival-2024.html")
 	s.EmptyInput = NewGetSetlistByIDInput("")
 }
@@ -50,10 +52,39 @@ func (s *GetSetlistByIDInputTestSuite) TestSetlistID() {
 		s.Nil(result)
 	})
 
+	s.Run("Should return an error when URL is a festival", func() {
+		result, err := s.FestivalInput.SetlistID()
+
+		s.ErrorContains(err, "URL is a festival, not a single setlist")
+		s.Nil(result)
+	})
+}
+
+func (s *GetSetlistByIDInputTestSuite) TestFestivalID() {
+	s.Run("Should accept festival URLs", func() {
+		s.NoError(s.FestivalInput.Validate())
+		s.True(s.FestivalInput.IsFestival())
+		s.False(s.ValidInput.IsFestival())
+	})
+
+	s.Run("Should return the festival ID", func() {
+		result, err := s.FestivalInput.FestivalID()
+
+		s.NoError(err)
+		s.Equal("73d44e99", *result)
+	})
+
 	s.Run("Should return an error when URL does not contain an ID", func() {
-		result, err := s.NoIDInput.SetlistID()
+		result, err := s.NoIDInput.FestivalID()
 
-		s.Error(err)
+		s.ErrorIs(err, ErrMissingID)
+		s.Nil(result)
+	})
+
+	s.Run("Should return an error when URL is a single setlist", func() {
+		result, err := s.ValidInput.FestivalID()
+
+		s.ErrorContains(err, "URL is not a setlist.fm festival")
 		s.Nil(result)
 	})
 }
@@ -0,0 +1,33 @@
+package setlistfm
+
+import (
+	"errors"
+	"strings"
+)
+
+type GetTourSetlistsInput struct {
+	ArtistMBID string
+	Tour       string
+	// MaxPages caps how many pages of the artist setlists are read, 0 meaning no limit.
+	MaxPages int
+}
+
+func (in GetTourSetlistsInput) Validate() error {
+	if in.ArtistMBID == "" {
+		return errors.New("artist MBID is empty")
+	}
+
+	if strings.TrimSpace(in.Tour) == "" {
+		return errors.New("tour name is empty")
+	}
+
+	return nil
+}
+
+// Matches tells whether a setlist was played on the tour, ignoring case and extra spaces.
+func (in GetTourSetlistsInput) Matches(set Set) bool {
+	return strings.EqualFold(
+		strings.Join(strings.Fields(set.Tour.Name), " "),
+		strings.Join(strings.Fields(in.Tour), " "),
+	)
+}
@@ -0,0 +1,128 @@
+package setlistfm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	medleySeparatorRegex = regexp.MustCompile(`\s+/\s*|\s*/\s+`)
+	annotationRegex      = regexp.MustCompile(
+		`(?i)\s*[\(\[](acoustic|snippet|partial|intro|outro|reprise|extended|short|electric|unplugged|piano|a cappella|solo|instrumental|tease|jam)( version| snippet| intro)?[\)\]]\s*$`,
+	)
+)
+
+type NormalizeOptions struct {
+	IncludeTapes bool
+}
+
+// SetPart is one of the sets of a show, like the main set or an encore, with its songs normalized.
+type SetPart struct {
+	Label  string
+	Encore int
+	Songs  []Song
+}
+
+func (s *Set) NormalizedTracks(opts NormalizeOptions) []Song {
+	return normalizeSongs(s.Tracks(), opts)
+}
+
+// Parts returns the sets of the show in order, leaving out the ones without any song. Sets
+// named on Setlist.fm (e.g. "Acoustic") keep their name, the others are labeled "Main Set",
+// "Set 2", "Encore" or "Encore 2".
+func (s *Set) Parts(opts NormalizeOptions) []SetPart {
+	var mainSets, encores int
+
+	for _, set := range s.Sets.Set {
+		if set.Encore > 0 {
+			encores++
+		} else {
+			mainSets++
+		}
+	}
+
+	var (
+		parts         []SetPart
+		setN, encoreN int
+	)
+
+	for _, set := range s.Sets.Set {
+		var label string
+
+		if set.Encore > 0 {
+			encoreN++
+			label = "Encore"
+			if encores > 1 {
+				label = fmt.Sprintf("Encore %d", encoreN)
+			}
+		} else {
+			setN++
+			label = "Main Set"
+			if mainSets > 1 {
+				label = fmt.Sprintf("Set %d", setN)
+			}
+		}
+
+		if name := strings.TrimSpace(set.Name); name != "" {
+			label = name
+		}
+
+		songs := normalizeSongs(set.Song, opts)
+		if len(songs) == 0 {
+			continue
+		}
+
+		parts = append(parts, SetPart{Label: label, Encore: set.Encore, Songs: songs})
+	}
+
+	return parts
+}
+
+func normalizeSongs(tracks []Song, opts NormalizeOptions) []Song {
+	var songs []Song
+
+	for _, song := range tracks {
+		if song.Tape && !opts.IncludeTapes {
+			continue
+		}
+
+		for _, name := range SplitMedley(song.Name) {
+			name = StripAnnotations(name)
+			if name == "" {
+				continue
+			}
+
+			part := song
+			part.Name = name
+
+			songs = append(songs, part)
+		}
+	}
+
+	return songs
+}
+
+func SplitMedley(name string) []string {
+	parts := medleySeparatorRegex.Split(name, -1)
+
+	songs := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			songs = append(songs, p)
+		}
+	}
+
+	return songs
+}
+
+func StripAnnotations(name string) string {
+	for {
+		stripped := annotationRegex.ReplaceAllString(name, "")
+		if stripped == name {
+			return strings.TrimSpace(name)
+		}
+
+		name = stripped
+	}
+}
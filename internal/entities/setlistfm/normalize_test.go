@@ -0,0 +1,196 @@
+package setlistfm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NormalizeTestSuite struct {
+	suite.Suite
+}
+
+func TestNormalize(t *testing.T) {
+	suite.Run(t, new(NormalizeTestSuite))
+}
+
+func (s *NormalizeTestSuite) loadSet(name string) *Set {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	s.Require().NoError(err)
+
+	var set Set
+	s.Require().NoError(json.Unmarshal(data, &set))
+
+	return &set
+}
+
+func (s *NormalizeTestSuite) TestNormalizedTracks() {
+	cases := []struct {
+		name     string
+		payload  string
+		opts     NormalizeOptions
+		expected []string
+	}{
+		{
+			name:    "skips tapes, splits medleys and strips annotations",
+			payload: "tapes_and_medleys.json",
+			opts:    NormalizeOptions{},
+			expected: []string{
+				"Anthem Part Two",
+				"The Rock Show",
+				"Family Reunion",
+				"Dammit",
+				"Happy Holidays, You Bastard",
+				"Stay Together for the Kids",
+				"All the Small Things",
+			},
+		},
+		{
+			name:    "keeps tapes when asked to",
+			payload: "tapes_and_medleys.json",
+			opts:    NormalizeOptions{IncludeTapes: true},
+			expected: []string{
+				"Ride of the Valkyries",
+				"Anthem Part Two",
+				"The Rock Show",
+				"Family Reunion",
+				"Dammit",
+				"Happy Holidays, You Bastard",
+				"Stay Together for the Kids",
+				"All the Small Things",
+				"Mutt",
+			},
+		},
+		{
+			name:    "keeps covers and repeated songs",
+			payload: "covers_and_guests.json",
+			opts:    NormalizeOptions{},
+			expected: []string{
+				"Get Back",
+				"Don't Let Me Down",
+				"I've Got a Feeling",
+				"Danny Boy",
+				"God Save the Queen",
+				"Get Back",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			set := s.loadSet(c.payload)
+
+			var names []string
+			for _, song := range set.NormalizedTracks(c.opts) {
+				names = append(names, song.Name)
+			}
+
+			s.Equal(c.expected, names)
+		})
+	}
+
+	s.Run("Should keep song metadata on medley parts and stripped songs", func() {
+		set := s.loadSet("tapes_and_medleys.json")
+		tracks := set.NormalizedTracks(NormalizeOptions{IncludeTapes: true})
+
+		s.Equal("Richard Wagner", tracks[0].OriginalArtistName())
+		s.Equal("Played as a medley", tracks[4].Info)
+		s.Equal("Played as a medley", tracks[5].Info)
+
+		beatles := s.loadSet("covers_and_guests.json").NormalizedTracks(NormalizeOptions{})
+
+		s.Equal("Billy Preston", beatles[1].With.Name)
+		s.Equal("Traditional", beatles[4].OriginalArtistName())
+	})
+}
+
+func (s *NormalizeTestSuite) TestParts() {
+	s.Run("Should split the main set from the encore", func() {
+		parts := s.loadSet("tapes_and_medleys.json").Parts(NormalizeOptions{})
+
+		s.Len(parts, 2)
+		s.Equal("Main Set", parts[0].Label)
+		s.Len(parts[0].Songs, 6)
+		s.Equal("Encore", parts[1].Label)
+		s.Equal(1, parts[1].Encore)
+		s.Equal("All the Small Things", parts[1].Songs[0].Name)
+	})
+
+	s.Run("Should number sets and encores and keep set names", func() {
+		set := &Set{
+			Sets: Sets{
+				Set: []Songs{
+					{Song: []Song{{Name: "a"}}},
+					{Name: "Acoustic", Song: []Song{{Name: "b"}}},
+					{Song: []Song{{Name: "c"}}},
+					{Song: []Song{{Name: "d"}}, Encore: 1},
+					{Song: []Song{{Name: "Outro", Tape: true}}, Encore: 2},
+					{Song: []Song{{Name: "e"}}, Encore: 3},
+				},
+			},
+		}
+
+		var labels []string
+		for _, p := range set.Parts(NormalizeOptions{}) {
+			labels = append(labels, p.Label)
+		}
+
+		s.Equal([]string{"Set 1", "Acoustic", "Set 3", "Encore 1", "Encore 3"}, labels)
+	})
+
+	s.Run("Should have the same songs as the normalized tracks", func() {
+		set := s.loadSet("tapes_and_medleys.json")
+
+		var songs []Song
+		for _, p := range set.Parts(NormalizeOptions{IncludeTapes: true}) {
+			songs = append(songs, p.Songs...)
+		}
+
+		s.Equal(set.NormalizedTracks(NormalizeOptions{IncludeTapes: true}), songs)
+	})
+}
+
+func (s *NormalizeTestSuite) TestSplitMedley() {
+	cases := []struct {
+		in       string
+		expected []string
+	}{
+		{"Dammit", []string{"Dammit"}},
+		{"Song A / Song B", []string{"Song A", "Song B"}},
+		{"Song A / Song B / Song C", []string{"Song A", "Song B", "Song C"}},
+		{"Love/Hate", []string{"Love/Hate"}},
+		{"Song A /  ", []string{"Song A"}},
+		{"", []string{}},
+	}
+
+	for _, c := range cases {
+		s.Run(c.in, func() {
+			s.Equal(c.expected, SplitMedley(c.in))
+		})
+	}
+}
+
+func (s *NormalizeTestSuite) TestStripAnnotations() {
+	cases := []struct {
+		in       string
+		expected string
+	}{
+		{"Dammit", "Dammit"},
+		{"Dammit (acoustic)", "Dammit"},
+		{"Dammit (Acoustic Version)", "Dammit"},
+		{"Family Reunion (snippet)", "Family Reunion"},
+		{"Hey Jude [partial]", "Hey Jude"},
+		{"Hey Jude (reprise) (snippet)", "Hey Jude"},
+		{"(I Can't Get No) Satisfaction", "(I Can't Get No) Satisfaction"},
+		{"Yesterday (Remix)", "Yesterday (Remix)"},
+	}
+
+	for _, c := range cases {
+		s.Run(c.in, func() {
+			s.Equal(c.expected, StripAnnotations(c.in))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package setlistfm
+
+// ArtistPage is a page of the artists search.
+type ArtistPage struct {
+	Artist       []Artist `json:"artist"`
+	Total        int      `json:"total"`
+	Page         int      `json:"page"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+}
+
+// SetlistPage is a page of setlists, newest first.
+type SetlistPage struct {
+	Setlist      []Set `json:"setlist"`
+	Total        int   `json:"total"`
+	Page         int   `json:"page"`
+	ItemsPerPage int   `json:"itemsPerPage"`
+}
+
+func (p SetlistPage) IsLast() bool {
+	return len(p.Setlist) == 0 || p.ItemsPerPage == 0 || p.Page*p.ItemsPerPage >= p.Total
+}
@@ -0,0 +1,147 @@
+package setlistfm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+type PredictOptions struct {
+	// MinFrequency is the share of shows, from 0 to 1, a song must have been played at.
+	MinFrequency float64
+	IncludeTapes bool
+}
+
+func (o PredictOptions) Validate() error {
+	if o.MinFrequency <= 0 || o.MinFrequency > 1 {
+		return fmt.Errorf("the minimum frequency must be between 0 and 1, got %g", o.MinFrequency)
+	}
+
+	return nil
+}
+
+// PredictedSong is a song played on the tour, with how often and where in the set it was played.
+type PredictedSong struct {
+	Song      Song
+	Shows     int
+	Frequency float64
+	// Position is where the song usually shows up, from 0 (opener) to 1 (closer).
+	Position float64
+}
+
+type Prediction struct {
+	Artist Artist
+	Tour   string
+	// Shows is the number of shows with a setlist the prediction is based on.
+	Shows int
+	Songs []PredictedSong
+}
+
+// Predict counts how many of the shows every song was played at and keeps the ones played
+// at least as often as opts.MinFrequency, ordered by their usual position in the set. Shows
+// without songs (e.g. not played yet) are left out.
+func (sh Shows) Predict(opts PredictOptions) (*Prediction, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	type tally struct {
+		song      Song
+		shows     int
+		positions float64
+	}
+
+	var (
+		order   []string
+		tallies = map[string]*tally{}
+		played  int
+	)
+
+	for _, s := range sh {
+		songs := s.NormalizedTracks(NormalizeOptions{IncludeTapes: opts.IncludeTapes})
+		if len(songs) == 0 {
+			continue
+		}
+
+		played++
+		seen := map[string]bool{}
+
+		for i, song := range songs {
+			key := nameKey(song.Name)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			t, ok := tallies[key]
+			if !ok {
+				t = &tally{song: song}
+				tallies[key] = t
+				order = append(order, key)
+			}
+
+			t.shows++
+			t.positions += relativePosition(i, len(songs))
+		}
+	}
+
+	if played == 0 {
+		return nil, errors.New("none of the shows has a setlist yet")
+	}
+
+	prediction := &Prediction{Artist: sh[0].Artist, Tour: strings.TrimSpace(sh[0].Tour.Name), Shows: played}
+
+	for _, key := range order {
+		t := tallies[key]
+		frequency := float64(t.shows) / float64(played)
+
+		if frequency < opts.MinFrequency {
+			continue
+		}
+
+		prediction.Songs = append(prediction.Songs, PredictedSong{
+			Song:      t.song,
+			Shows:     t.shows,
+			Frequency: frequency,
+			Position:  t.positions / float64(t.shows),
+		})
+	}
+
+	sort.SliceStable(prediction.Songs, func(i, j int) bool {
+		return prediction.Songs[i].Position < prediction.Songs[j].Position
+	})
+
+	return prediction, nil
+}
+
+// Set turns the prediction into a setlist, so it can be converted like any other show. Its
+// ID is the same for every prediction of the tour, so the playlist is updated on later runs.
+func (p *Prediction) Set() *Set {
+	songs := make([]Song, len(p.Songs))
+	for i, s := range p.Songs {
+		songs[i] = s.Song
+	}
+
+	return &Set{
+		ID:     fmt.Sprintf("predicted:%s:%s", p.Artist.MBID, nameKey(p.Tour)),
+		Artist: p.Artist,
+		Tour:   Tour{Name: p.Tour},
+		Sets:   Sets{Set: []Songs{{Song: songs}}},
+	}
+}
+
+func relativePosition(index int, total int) float64 {
+	if total <= 1 {
+		return 0
+	}
+
+	return float64(index) / float64(total-1)
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(matching.FoldAccents(name)), " "))
+}
@@ -0,0 +1,91 @@
+package setlistfm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PredictTestSuite struct {
+	suite.Suite
+}
+
+func TestPredict(t *testing.T) {
+	suite.Run(t, new(PredictTestSuite))
+}
+
+func show(songs ...string) *Set {
+	set := &Set{Artist: Artist{MBID: "any-mbid", Name: "any-artist"}, Tour: Tour{Name: "Any Tour "}}
+
+	var played []Song
+	for _, s := range songs {
+		played = append(played, Song{Name: s})
+	}
+
+	set.Sets.Set = []Songs{{Song: played}}
+	return set
+}
+
+func (s *PredictTestSuite) TestPredict() {
+	s.Run("Should keep the songs played often enough, in their usual order", func() {
+		shows := Shows{
+			show("Opener", "Hit", "Deep Cut", "Closer"),
+			show("Opener", "Rarity", "Hit", "Closer"),
+			show("Hit", "Opener", "Closer"),
+			show(),
+		}
+
+		prediction, err := shows.Predict(PredictOptions{MinFrequency: 0.5})
+
+		s.NoError(err)
+		s.Equal(3, prediction.Shows)
+		s.Equal("Any Tour", prediction.Tour)
+
+		var names []string
+		for _, p := range prediction.Songs {
+			names = append(names, p.Song.Name)
+		}
+
+		s.Equal([]string{"Opener", "Hit", "Closer"}, names)
+		s.Equal(3, prediction.Songs[0].Shows)
+		s.Equal(1.0, prediction.Songs[2].Frequency)
+		s.Equal(1.0, prediction.Songs[2].Position)
+	})
+
+	s.Run("Should count a song once per show, whatever its spelling", func() {
+		shows := Shows{
+			show("Café", "cafe (acoustic)"),
+			show("Other"),
+		}
+
+		prediction, err := shows.Predict(PredictOptions{MinFrequency: 0.5})
+
+		s.NoError(err)
+		s.Len(prediction.Songs, 2)
+		s.Equal(1, prediction.Songs[0].Shows)
+	})
+
+	s.Run("Should return an error when no show has a setlist", func() {
+		_, err := Shows{show()}.Predict(PredictOptions{MinFrequency: 0.5})
+
+		s.ErrorContains(err, "none of the shows has a setlist yet")
+	})
+
+	s.Run("Should return an error for an invalid frequency", func() {
+		_, err := Shows{show("any-song")}.Predict(PredictOptions{MinFrequency: 1.5})
+
+		s.ErrorContains(err, "between 0 and 1")
+	})
+}
+
+func (s *PredictTestSuite) TestSet() {
+	s.Run("Should turn the prediction into a setlist", func() {
+		prediction, _ := Shows{show("Opener", "Closer")}.Predict(PredictOptions{MinFrequency: 1})
+
+		set := prediction.Set()
+
+		s.Equal("predicted:any-mbid:any tour", set.ID)
+		s.Equal([]string{"Opener", "Closer"}, set.Songs())
+		s.Equal("any-artist", set.ArtistName())
+	})
+}
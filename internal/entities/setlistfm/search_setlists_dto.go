@@ -0,0 +1,110 @@
+package setlistfm
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var yearRegex = regexp.MustCompile(`^\d{4}$`)
+
+// SearchSetlistsInput are the criteria of a setlists search. At least one of them, besides
+// the page, must be set.
+type SearchSetlistsInput struct {
+	ArtistName string
+	ArtistMBID string
+	CityName   string
+	VenueName  string
+	// VenueID is the Setlist.fm ID of the venue, as found on one of its setlists.
+	VenueID  string
+	TourName string
+	// Date is the day of the show, as DD-MM-YYYY (like on Setlist.fm) or YYYY-MM-DD.
+	Date string
+	Year string
+	Page int
+}
+
+func (in SearchSetlistsInput) Validate() error {
+	if in.isEmpty() {
+		return errors.New("give at least one of artist, MBID, city, venue, tour, date or year to search for")
+	}
+
+	if _, err := in.eventDate(); err != nil {
+		return err
+	}
+
+	if in.Year != "" && !yearRegex.MatchString(in.Year) {
+		return fmt.Errorf("invalid year %q", in.Year)
+	}
+
+	return nil
+}
+
+// Query returns the search parameters as Setlist.fm expects them.
+func (in SearchSetlistsInput) Query() (url.Values, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	date, _ := in.eventDate()
+	query := url.Values{}
+
+	for key, value := range map[string]string{
+		"artistName": in.ArtistName,
+		"artistMbid": in.ArtistMBID,
+		"cityName":   in.CityName,
+		"venueName":  in.VenueName,
+		"venueId":    in.VenueID,
+		"tourName":   in.TourName,
+		"date":       date,
+		"year":       in.Year,
+	} {
+		if value = strings.TrimSpace(value); value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	page := in.Page
+	if page < 1 {
+		page = 1
+	}
+
+	query.Set("p", fmt.Sprint(page))
+
+	return query, nil
+}
+
+func (in SearchSetlistsInput) isEmpty() bool {
+	for _, v := range []string{
+		in.ArtistName, in.ArtistMBID, in.CityName, in.VenueName, in.VenueID, in.TourName, in.Date, in.Year,
+	} {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (in SearchSetlistsInput) eventDate() (string, error) {
+	return normalizeEventDate(in.Date)
+}
+
+// normalizeEventDate turns a date given as DD-MM-YYYY or YYYY-MM-DD into EventDateLayout.
+func normalizeEventDate(date string) (string, error) {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return "", nil
+	}
+
+	for _, layout := range []string{EventDateLayout, time.DateOnly} {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t.Format(EventDateLayout), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid date %q, use DD-MM-YYYY or YYYY-MM-DD", date)
+}
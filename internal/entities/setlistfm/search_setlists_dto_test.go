@@ -0,0 +1,53 @@
+package setlistfm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SearchSetlistsInputTestSuite struct {
+	suite.Suite
+}
+
+func TestSearchSetlistsInput(t *testing.T) {
+	suite.Run(t, new(SearchSetlistsInputTestSuite))
+}
+
+func (s *SearchSetlistsInputTestSuite) TestQuery() {
+	s.Run("Should only send the given criteria", func() {
+		query, err := SearchSetlistsInput{ArtistName: " Paramore ", CityName: "São Paulo", Page: 2}.Query()
+
+		s.NoError(err)
+		s.Equal("artistName=Paramore&cityName=S%C3%A3o+Paulo&p=2", query.Encode())
+	})
+
+	s.Run("Should search the setlists of a venue by its ID", func() {
+		query, err := SearchSetlistsInput{VenueID: "any-venue-id", Year: "2024"}.Query()
+
+		s.NoError(err)
+		s.Equal("p=1&venueId=any-venue-id&year=2024", query.Encode())
+	})
+
+	s.Run("Should send dates as Setlist.fm expects them", func() {
+		query, err := SearchSetlistsInput{Date: "2023-03-09"}.Query()
+
+		s.NoError(err)
+		s.Equal("09-03-2023", query.Get("date"))
+		s.Equal("1", query.Get("p"))
+	})
+
+	s.Run("Should return an error without any criteria", func() {
+		_, err := SearchSetlistsInput{Page: 3}.Query()
+
+		s.ErrorContains(err, "give at least one of")
+	})
+
+	s.Run("Should return an error for invalid dates and years", func() {
+		_, err := SearchSetlistsInput{Date: "March 9"}.Query()
+		s.ErrorContains(err, `invalid date "March 9"`)
+
+		_, err = SearchSetlistsInput{Year: "23"}.Query()
+		s.ErrorContains(err, `invalid year "23"`)
+	})
+}
@@ -1,6 +1,13 @@
 package setlistfm
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EventDateLayout is the format setlist.fm uses for event dates.
+const EventDateLayout = "02-01-2006"
 
 type Artist struct {
 	MBID           string `json:"mbid"`
@@ -41,10 +48,15 @@ type Tour struct {
 }
 
 type Song struct {
-	Name string `json:"name"`
+	Name  string  `json:"name"`
+	With  *Artist `json:"with,omitempty"`
+	Cover *Artist `json:"cover,omitempty"`
+	Info  string  `json:"info,omitempty"`
+	Tape  bool    `json:"tape,omitempty"`
 }
 
 type Songs struct {
+	Name   string `json:"name,omitempty"`
 	Song   []Song `json:"song"`
 	Encore int    `json:"encore,omitempty"`
 }
@@ -66,7 +78,41 @@ type Set struct {
 }
 
 func (s *Set) Title() string {
-	return fmt.Sprintf("%s %s @ %s, %s - %s", s.Artist.Name, s.Tour.Name, s.Venue.Name, s.Venue.City.Name, s.Venue.City.Country.Name)
+	title := fmt.Sprintf("%s %s @ %s, %s - %s", s.Artist.Name, s.Tour.Name, s.Venue.Name, s.Venue.City.Name, s.Venue.City.Country.Name)
+
+	// Collapses the gap left behind by shows that aren't part of a tour.
+	return strings.Join(strings.Fields(title), " ")
+}
+
+func (s *Set) EventTime() (time.Time, bool) {
+	t, err := time.Parse(EventDateLayout, s.EventDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+func (s Song) IsCover() bool {
+	return s.Cover != nil && s.Cover.Name != ""
+}
+
+func (s Song) OriginalArtistName() string {
+	if !s.IsCover() {
+		return ""
+	}
+
+	return s.Cover.Name
+}
+
+func (s *Set) Tracks() []Song {
+	var songs []Song
+
+	for _, set := range s.Sets.Set {
+		songs = append(songs, set.Song...)
+	}
+
+	return songs
 }
 
 func (s *Set) Songs() []string {
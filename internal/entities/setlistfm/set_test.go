@@ -0,0 +1,89 @@
+package setlistfm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SetTestSuite struct {
+	suite.Suite
+}
+
+func TestSet(t *testing.T) {
+	suite.Run(t, new(SetTestSuite))
+}
+
+func (s *SetTestSuite) TestDecode() {
+	s.Run("Should decode song metadata", func() {
+		payload := `{
+			"id": "63de4613",
+			"artist": {"mbid": "b10bbbfc-cf9e-42e0-be17-e2c3e1d2600d", "name": "The Beatles"},
+			"sets": {
+				"set": [
+					{
+						"song": [
+							{"name": "Intro", "tape": true},
+							{"name": "Roll Over Beethoven", "cover": {"mbid": "ca2a5bd5-0bb0-4bd9-9d8a-c4bd1bd9f8e7", "name": "Chuck Berry"}},
+							{"name": "Something", "with": {"name": "Eric Clapton"}, "info": "Extended outro"}
+						]
+					}
+				]
+			}
+		}`
+
+		var set Set
+		err := json.Unmarshal([]byte(payload), &set)
+
+		s.NoError(err)
+
+		tracks := set.Tracks()
+		s.Len(tracks, 3)
+
+		s.True(tracks[0].Tape)
+		s.False(tracks[0].IsCover())
+
+		s.True(tracks[1].IsCover())
+		s.Equal("Chuck Berry", tracks[1].OriginalArtistName())
+
+		s.Equal("Eric Clapton", tracks[2].With.Name)
+		s.Equal("Extended outro", tracks[2].Info)
+		s.Equal("", tracks[2].OriginalArtistName())
+	})
+}
+
+func (s *SetTestSuite) TestTitle() {
+	venue := Venue{Name: "Allianz Parque", City: City{Name: "São Paulo", Country: Country{Name: "Brazil"}}}
+
+	s.Run("Should include the tour", func() {
+		set := Set{Artist: Artist{Name: "Paramore"}, Tour: Tour{Name: "This Is Why"}, Venue: venue}
+
+		s.Equal("Paramore This Is Why @ Allianz Parque, São Paulo - Brazil", set.Title())
+	})
+
+	s.Run("Should leave the tour out when there is none", func() {
+		set := Set{Artist: Artist{Name: "Paramore"}, Venue: venue}
+
+		s.Equal("Paramore @ Allianz Parque, São Paulo - Brazil", set.Title())
+	})
+}
+
+func (s *SetTestSuite) TestTracks() {
+	s.Run("Should flatten every set in order", func() {
+		set := Set{
+			Sets: Sets{
+				Set: []Songs{
+					{Song: []Song{{Name: "any-song-1"}, {Name: "any-song-2"}}},
+					{Song: []Song{{Name: "any-song-3"}}, Encore: 1},
+				},
+			},
+		}
+
+		tracks := set.Tracks()
+
+		s.Len(tracks, 3)
+		s.Equal("any-song-3", tracks[2].Name)
+		s.Equal([]string{"any-song-1", "any-song-2", "any-song-3"}, set.Songs())
+	})
+}
@@ -0,0 +1,279 @@
+package setlistfm
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetlistFileFormat is how a local setlist file is written, see ParseSetlistFile.
+type SetlistFileFormat string
+
+const (
+	SetlistFileJSON SetlistFileFormat = "json"
+	SetlistFileYAML SetlistFileFormat = "yaml"
+	SetlistFileText SetlistFileFormat = "text"
+)
+
+// SetlistFileFormatOf picks the format by the file extension, plain text being the fallback.
+func SetlistFileFormatOf(path string) SetlistFileFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return SetlistFileJSON
+	case ".yaml", ".yml":
+		return SetlistFileYAML
+	default:
+		return SetlistFileText
+	}
+}
+
+// ParseSetlistFile builds a setlist out of a local file, for shows that aren't on Setlist.fm.
+// JSON files are shaped like the Setlist.fm API, YAML files like setlistDocument and text files
+// have one song per line, see parseTextSetlist. Without an ID, the setlist gets one derived from
+// the location of the file, so converting it again after editing it updates the same playlist
+// while every other file gets its own.
+func ParseSetlistFile(path string, data []byte) (*Set, error) {
+	var (
+		set *Set
+		err error
+	)
+
+	switch SetlistFileFormatOf(path) {
+	case SetlistFileJSON:
+		set, err = parseJSONSetlist(data)
+	case SetlistFileYAML:
+		set, err = parseYAMLSetlist(data)
+	default:
+		set, err = parseTextSetlist(data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	if set.EventDate, err = normalizeEventDate(set.EventDate); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	if strings.TrimSpace(set.Artist.Name) == "" {
+		return nil, fmt.Errorf("%s has no artist, it's needed to search the songs on Spotify", filepath.Base(path))
+	}
+
+	if len(set.Songs()) == 0 {
+		return nil, fmt.Errorf("%s has no songs", filepath.Base(path))
+	}
+
+	if set.ID == "" {
+		if set.ID, err = fileSetlistID(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+func parseJSONSetlist(data []byte) (*Set, error) {
+	var set Set
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	return &set, nil
+}
+
+// setlistDocument is the YAML shape of a setlist. Songs holds a show played as a single set,
+// Sets the ones with several sets or encores. A song is either its name or a mapping with the
+// fields of documentSong.
+type setlistDocument struct {
+	ID      string         `yaml:"id"`
+	Artist  string         `yaml:"artist"`
+	Date    string         `yaml:"date"`
+	Venue   string         `yaml:"venue"`
+	City    string         `yaml:"city"`
+	Country string         `yaml:"country"`
+	Tour    string         `yaml:"tour"`
+	Songs   []documentSong `yaml:"songs"`
+	Sets    []documentSet  `yaml:"sets"`
+}
+
+type documentSet struct {
+	Name   string         `yaml:"name"`
+	Encore int            `yaml:"encore"`
+	Songs  []documentSong `yaml:"songs"`
+}
+
+type documentSong struct {
+	Name  string `yaml:"name"`
+	Cover string `yaml:"cover"`
+	With  string `yaml:"with"`
+	Info  string `yaml:"info"`
+	Tape  bool   `yaml:"tape"`
+}
+
+func (s *documentSong) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		s.Name = value.Value
+		return nil
+	}
+
+	type plain documentSong
+
+	return value.Decode((*plain)(s))
+}
+
+func (s documentSong) song() Song {
+	song := Song{Name: strings.TrimSpace(s.Name), Info: s.Info, Tape: s.Tape}
+
+	if s.Cover != "" {
+		song.Cover = &Artist{Name: s.Cover}
+	}
+
+	if s.With != "" {
+		song.With = &Artist{Name: s.With}
+	}
+
+	return song
+}
+
+func parseYAMLSetlist(data []byte) (*Set, error) {
+	var doc setlistDocument
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Songs) > 0 && len(doc.Sets) > 0 {
+		return nil, errors.New("use either songs or sets, not both")
+	}
+
+	sets := doc.Sets
+	if len(doc.Songs) > 0 {
+		sets = []documentSet{{Songs: doc.Songs}}
+	}
+
+	set := &Set{
+		ID:        doc.ID,
+		EventDate: doc.Date,
+		Artist:    Artist{Name: doc.Artist},
+		Venue:     Venue{Name: doc.Venue, City: City{Name: doc.City, Country: Country{Name: doc.Country}}},
+		Tour:      Tour{Name: doc.Tour},
+	}
+
+	for _, s := range sets {
+		songs := Songs{Name: s.Name, Encore: s.Encore}
+
+		for _, song := range s.Songs {
+			songs.Song = append(songs.Song, song.song())
+		}
+
+		set.Sets.Set = append(set.Sets.Set, songs)
+	}
+
+	return set, nil
+}
+
+// listMarkerRegex matches the numbering or bullet in front of a song, e.g. "1. ", "2) " or "- ".
+var listMarkerRegex = regexp.MustCompile(`^(\d+[.)]|[-*•])\s+`)
+
+// parseTextSetlist reads one song per line. The lines at the top may set the artist, date,
+// venue, city, country and tour, e.g. "Artist: Paramore". A line ending with a colon, e.g.
+// "Encore:", starts a new set. Blank lines and lines starting with # are skipped.
+func parseTextSetlist(data []byte) (*Set, error) {
+	set := &Set{}
+	current := Songs{}
+	encores := 0
+	inHeader := true
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if inHeader && setHeader(set, line) {
+			continue
+		}
+
+		inHeader = false
+
+		if name, ok := strings.CutSuffix(line, ":"); ok {
+			if len(current.Song) > 0 {
+				set.Sets.Set = append(set.Sets.Set, current)
+			}
+
+			current = Songs{Name: strings.TrimSpace(name)}
+
+			if strings.HasPrefix(strings.ToLower(current.Name), "encore") {
+				encores++
+				current.Encore = encores
+			}
+
+			continue
+		}
+
+		current.Song = append(current.Song, Song{Name: listMarkerRegex.ReplaceAllString(line, "")})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(current.Song) > 0 {
+		set.Sets.Set = append(set.Sets.Set, current)
+	}
+
+	return set, nil
+}
+
+// setHeader fills the field named by a "Key: value" line, telling whether it was one.
+func setHeader(set *Set, line string) bool {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok || strings.TrimSpace(value) == "" {
+		return false
+	}
+
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "artist":
+		set.Artist.Name = value
+	case "date":
+		set.EventDate = value
+	case "venue":
+		set.Venue.Name = value
+	case "city":
+		set.Venue.City.Name = value
+	case "country":
+		set.Venue.City.Country.Name = value
+	case "tour":
+		set.Tour.Name = value
+	default:
+		return false
+	}
+
+	return true
+}
+
+func fileSetlistID(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha1.Sum([]byte(abs))
+
+	return "file:" + hex.EncodeToString(sum[:])[:8], nil
+}
@@ -0,0 +1,141 @@
+package setlistfm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SetlistFileTestSuite struct {
+	suite.Suite
+}
+
+func TestSetlistFile(t *testing.T) {
+	suite.Run(t, new(SetlistFileTestSuite))
+}
+
+func (s *SetlistFileTestSuite) parseFile(name string) (*Set, error) {
+	path := filepath.Join("testdata", "files", name)
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	return ParseSetlistFile(path, data)
+}
+
+func (s *SetlistFileTestSuite) TestSetlistFileFormatOf() {
+	s.Equal(SetlistFileJSON, SetlistFileFormatOf("show.JSON"))
+	s.Equal(SetlistFileYAML, SetlistFileFormatOf("show.yml"))
+	s.Equal(SetlistFileYAML, SetlistFileFormatOf("show.yaml"))
+	s.Equal(SetlistFileText, SetlistFileFormatOf("show.txt"))
+	s.Equal(SetlistFileText, SetlistFileFormatOf("setlist"))
+}
+
+func (s *SetlistFileTestSuite) TestParseSetlistFile() {
+	for _, name := range []string{"rehearsal.txt", "rehearsal.yaml", "rehearsal.json"} {
+		s.Run("Should read the show details and sets of "+name, func() {
+			set, err := s.parseFile(name)
+
+			s.NoError(err)
+			s.Equal("The Garage Band", set.Artist.Name)
+			s.Equal("18-05-2024", set.EventDate)
+			s.Equal("Studio 4", set.Venue.Name)
+			s.Equal("Porto Alegre", set.Venue.City.Name)
+			s.Equal("Brazil", set.Venue.City.Country.Name)
+			s.Len(set.Sets.Set, 2)
+			s.Equal(1, set.Sets.Set[1].Encore)
+			s.Equal([]string{"Closing Song"}, songNames(set.Sets.Set[1]))
+			s.Regexp(`^file:[0-9a-f]{8}$`, set.ID)
+		})
+	}
+
+	s.Run("Should strip list markers from text songs", func() {
+		set, err := s.parseFile("rehearsal.txt")
+
+		s.NoError(err)
+		s.Equal([]string{"Opening Song", "Second Song", "Third Song"}, songNames(set.Sets.Set[0]))
+		s.Equal("Encore", set.Sets.Set[1].Name)
+	})
+
+	s.Run("Should read the song details of YAML files", func() {
+		set, err := s.parseFile("rehearsal.yaml")
+
+		s.NoError(err)
+		s.Equal("Basement Tour", set.Tour.Name)
+		s.Equal("Another Band", set.Sets.Set[0].Song[1].OriginalArtistName())
+		s.True(set.Sets.Set[0].Song[2].Tape)
+	})
+
+	s.Run("Should give an edited file the same ID", func() {
+		before, _ := ParseSetlistFile("show.txt", []byte("Artist: Any Artist\nAny Song\n"))
+		after, _ := ParseSetlistFile("show.txt", []byte("Artist: Any Artist\nDate: 2024-05-18\nAny Song\nOther Song\n"))
+
+		s.Equal(before.ID, after.ID)
+	})
+
+	s.Run("Should give files that differ only in their songs their own IDs", func() {
+		data := "Artist: The Garage Band\nDate: 2024-05-18\nVenue: Studio 4\n\n"
+
+		morning, _ := ParseSetlistFile(filepath.Join("rehearsals", "morning.txt"), []byte(data+"Opening Song\n"))
+		evening, _ := ParseSetlistFile(filepath.Join("rehearsals", "evening.txt"), []byte(data+"Closing Song\n"))
+
+		s.NotEqual(morning.ID, evening.ID)
+	})
+
+	s.Run("Should keep the ID given in the file", func() {
+		set, err := ParseSetlistFile("show.yaml", []byte("id: my-show\nartist: Any Artist\nsongs: [Any Song]\n"))
+
+		s.NoError(err)
+		s.Equal("my-show", set.ID)
+		s.Equal([]string{"Any Song"}, set.Songs())
+	})
+
+	s.Run("Should read a text file with songs only as long as it has an artist", func() {
+		set, err := ParseSetlistFile("show.txt", []byte("Artist: Any Artist\nAny Song: Part 1\n"))
+
+		s.NoError(err)
+		s.Equal([]string{"Any Song: Part 1"}, set.Songs())
+	})
+
+	s.Run("Should return an error without an artist", func() {
+		_, err := ParseSetlistFile("show.txt", []byte("Any Song\nOther Song\n"))
+
+		s.ErrorContains(err, "show.txt has no artist")
+	})
+
+	s.Run("Should return an error without songs", func() {
+		_, err := ParseSetlistFile("show.txt", []byte("Artist: Any Artist\n"))
+
+		s.ErrorContains(err, "show.txt has no songs")
+	})
+
+	s.Run("Should return an error for an invalid date", func() {
+		_, err := ParseSetlistFile("show.txt", []byte("Artist: Any Artist\nDate: May 18\nAny Song\n"))
+
+		s.ErrorContains(err, `invalid date "May 18"`)
+	})
+
+	s.Run("Should return an error for a YAML file with songs and sets", func() {
+		_, err := ParseSetlistFile("show.yml", []byte("artist: Any Artist\nsongs: [A]\nsets: [{songs: [B]}]\n"))
+
+		s.ErrorContains(err, "use either songs or sets, not both")
+	})
+
+	s.Run("Should return an error for malformed files", func() {
+		_, err := ParseSetlistFile("show.json", []byte("{"))
+
+		s.ErrorContains(err, "failed to read show.json")
+	})
+}
+
+func songNames(songs Songs) []string {
+	names := make([]string, 0, len(songs.Song))
+
+	for _, song := range songs.Song {
+		names = append(names, song.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,139 @@
+package setlistfm
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrEmptyURL        = errors.New("URL is empty")
+	ErrNotSetlistFM    = errors.New("URL is not a setlist.fm address")
+	ErrUnsupportedPage = errors.New("URL is not a valid setlist.fm set or festival")
+	ErrMissingID       = errors.New("URL does not contain a setlist.fm ID")
+	ErrInvalidID       = errors.New("invalid setlist.fm ID")
+	ErrFestivalURL     = errors.New("URL is a festival, not a single setlist")
+	ErrNotFestival     = errors.New("URL is not a setlist.fm festival")
+)
+
+// RefError tells why a setlist URL or ID couldn't be parsed. It wraps one of the Err* errors
+// above, so they can be checked with errors.Is.
+type RefError struct {
+	Input string
+	Err   error
+}
+
+func (e *RefError) Error() string {
+	return fmt.Sprintf("%s: %q", e.Err, e.Input)
+}
+
+func (e *RefError) Unwrap() error {
+	return e.Err
+}
+
+// RefKind is what a setlist.fm URL points to.
+type RefKind int
+
+const (
+	RefSetlist RefKind = iota + 1
+	RefFestival
+)
+
+// Setlist.fm IDs are 8 hexadecimal digits, some older ones only 7.
+var refIDRegex = regexp.MustCompile(`^[0-9a-f]{7,8}$`)
+
+// Ref is a setlist or festival, as given on the command line.
+type Ref struct {
+	Kind RefKind
+	ID   string
+	// Path is the page path on the website, e.g. "/festival/2024/download-festival-2024-73d44e99.html".
+	// It is empty when a bare ID was given.
+	Path string
+}
+
+// ParseRef reads a setlist.fm setlist or festival URL, or a bare setlist ID. URLs may come
+// without scheme, over http, from any setlist.fm subdomain (www, m, or a language such as
+// de), with a query string, fragment or trailing slash.
+func ParseRef(raw string) (Ref, error) {
+	input := strings.TrimSpace(raw)
+	if input == "" {
+		return Ref{}, &RefError{Input: raw, Err: ErrEmptyURL}
+	}
+
+	if id := strings.ToLower(input); refIDRegex.MatchString(id) {
+		return Ref{Kind: RefSetlist, ID: id}, nil
+	}
+
+	if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+
+	parsed, err := url.Parse(input)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || !isSetlistFMHost(parsed.Hostname()) {
+		return Ref{}, &RefError{Input: raw, Err: ErrNotSetlistFM}
+	}
+
+	segments := strings.FieldsFunc(parsed.Path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return Ref{}, &RefError{Input: raw, Err: ErrUnsupportedPage}
+	}
+
+	var kind RefKind
+
+	switch strings.ToLower(segments[0]) {
+	case "setlist":
+		kind = RefSetlist
+	case "festival":
+		kind = RefFestival
+	default:
+		return Ref{}, &RefError{Input: raw, Err: ErrUnsupportedPage}
+	}
+
+	if len(segments) < 2 {
+		return Ref{}, &RefError{Input: raw, Err: ErrMissingID}
+	}
+
+	page := segments[len(segments)-1]
+	if !strings.HasSuffix(strings.ToLower(page), ".html") {
+		return Ref{}, &RefError{Input: raw, Err: ErrMissingID}
+	}
+
+	slug := page[:len(page)-len(".html")]
+	id := strings.ToLower(slug[strings.LastIndex(slug, "-")+1:])
+
+	// Anything shorter than an ID is the end of the slug, e.g. the year in "download-festival-2024".
+	if len(id) < 7 {
+		return Ref{}, &RefError{Input: raw, Err: ErrMissingID}
+	}
+
+	if !refIDRegex.MatchString(id) {
+		return Ref{}, &RefError{Input: raw, Err: ErrInvalidID}
+	}
+
+	return Ref{Kind: kind, ID: id, Path: "/" + strings.Join(segments, "/")}, nil
+}
+
+// Slug is the last part of the page path without the ID, e.g. "download-festival-2024".
+func (r Ref) Slug() string {
+	page := strings.TrimSuffix(r.Path[strings.LastIndex(r.Path, "/")+1:], ".html")
+
+	if i := strings.LastIndex(page, "-"); i >= 0 {
+		return page[:i]
+	}
+
+	return ""
+}
+
+func isSetlistFMHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if host == "setlist.fm" {
+		return true
+	}
+
+	sub, ok := strings.CutSuffix(host, ".setlist.fm")
+
+	return ok && sub != "" && !strings.Contains(sub, ".")
+}
@@ -0,0 +1,177 @@
+package setlistfm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SetlistRefTestSuite struct {
+	suite.Suite
+}
+
+func TestSetlistRef(t *testing.T) {
+	suite.Run(t, new(SetlistRefTestSuite))
+}
+
+func (s *SetlistRefTestSuite) TestParseRef() {
+	const setlistPath = "/setlist/blink182/2024/autodromo-de-interlagos-sao-paulo-brazil-53aa1325.html"
+	const festivalPath = "/festival/2024/download-festival-2024-73d44e99.html"
+
+	valid := []struct {
+		name  string
+		input string
+		want  Ref
+	}{
+		{"canonical setlist URL", "https://www.setlist.fm" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"http", "http://www.setlist.fm" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"no www", "https://setlist.fm" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"no scheme", "www.setlist.fm" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"no scheme nor www", "setlist.fm" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"mobile host", "https://m.setlist.fm" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"language host", "https://de.setlist.fm" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"uppercase host", "https://WWW.Setlist.FM" + setlistPath, Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"query string", "https://www.setlist.fm" + setlistPath + "?utm_source=share&p=1", Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"fragment", "https://www.setlist.fm" + setlistPath + "#encore", Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"trailing slash", "https://www.setlist.fm" + setlistPath + "/", Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"surrounding spaces", "  https://www.setlist.fm" + setlistPath + "\n", Ref{RefSetlist, "53aa1325", setlistPath}},
+		{"uppercase ID", "https://www.setlist.fm/setlist/paramore/2023/allianz-parque-63DE4613.html", Ref{RefSetlist, "63de4613", "/setlist/paramore/2023/allianz-parque-63DE4613.html"}},
+		{"seven digit ID", "https://www.setlist.fm/setlist/the-beatles/1965/shea-stadium-bd6ad22.html", Ref{RefSetlist, "bd6ad22", "/setlist/the-beatles/1965/shea-stadium-bd6ad22.html"}},
+		{"bare ID", "53aa1325", Ref{Kind: RefSetlist, ID: "53aa1325"}},
+		{"bare uppercase ID", " 53AA1325 ", Ref{Kind: RefSetlist, ID: "53aa1325"}},
+		{"festival URL", "https://www.setlist.fm" + festivalPath, Ref{RefFestival, "73d44e99", festivalPath}},
+		{"festival URL with query", "setlist.fm" + festivalPath + "?day=1", Ref{RefFestival, "73d44e99", festivalPath}},
+	}
+
+	for _, tc := range valid {
+		s.Run("Should parse a "+tc.name, func() {
+			ref, err := ParseRef(tc.input)
+
+			s.NoError(err)
+			s.Equal(tc.want, ref)
+		})
+	}
+
+	invalid := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"empty input", "", ErrEmptyURL},
+		{"blank input", "   ", ErrEmptyURL},
+		{"other host", "https://www.example.com" + setlistPath, ErrNotSetlistFM},
+		{"look-alike host", "https://setlist.fm.example.com" + setlistPath, ErrNotSetlistFM},
+		{"host suffix", "https://notsetlist.fm" + setlistPath, ErrNotSetlistFM},
+		{"nested subdomain", "https://a.b.setlist.fm" + setlistPath, ErrNotSetlistFM},
+		{"unsupported scheme", "ftp://www.setlist.fm" + setlistPath, ErrNotSetlistFM},
+		{"API URL", "https://api.setlist.fm/rest/1.0/setlist/53aa1325", ErrUnsupportedPage},
+		{"venue page", "https://www.setlist.fm/venue/donington-park-castle-donington-england-73d6a6c5.html", ErrUnsupportedPage},
+		{"home page", "https://www.setlist.fm/", ErrUnsupportedPage},
+		{"setlists index", "https://www.setlist.fm/setlist", ErrMissingID},
+		{"artist page", "https://www.setlist.fm/setlists/blink-182-13d6ad51.html", ErrUnsupportedPage},
+		{"page without ID", "https://www.setlist.fm/festival/2024/download-festival-2024.html", ErrMissingID},
+		{"page without .html", "https://www.setlist.fm/setlist/blink182/2024/interlagos-53aa1325", ErrMissingID},
+		{"dash before .html", "https://www.setlist.fm/setlist/blink182/2024/interlagos-.html", ErrMissingID},
+		{"ID too long", "https://www.setlist.fm/setlist/blink182/2024/interlagos-53aa13250.html", ErrInvalidID},
+		{"ID not hexadecimal", "https://www.setlist.fm/setlist/blink182/2024/interlagos-53aa132z.html", ErrInvalidID},
+		{"bare ID too short", "53aa13", ErrNotSetlistFM},
+		{"bare ID not hexadecimal", "zzzzzzzz", ErrNotSetlistFM},
+	}
+
+	for _, tc := range invalid {
+		s.Run("Should reject a "+tc.name, func() {
+			ref, err := ParseRef(tc.input)
+
+			s.ErrorIs(err, tc.want)
+			s.Zero(ref)
+
+			var refErr *RefError
+			s.ErrorAs(err, &refErr)
+			s.Equal(tc.input, refErr.Input)
+		})
+	}
+}
+
+func (s *SetlistRefTestSuite) TestSlug() {
+	s.Run("Should return the page name without the ID", func() {
+		ref, _ := ParseRef("https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html")
+
+		s.Equal("download-festival-2024", ref.Slug())
+	})
+
+	s.Run("Should be empty for a bare ID", func() {
+		ref, _ := ParseRef("73d44e99")
+
+		s.Empty(ref.Slug())
+	})
+}
+
+func FuzzParseRef(f *testing.F) {
+	for _, seed := range []string{
+		"53aa1325",
+		"https://www.setlist.fm/setlist/blink182/2024/autodromo-de-interlagos-sao-paulo-brazil-53aa1325.html",
+		"http://m.setlist.fm/setlist/paramore/2023/allianz-parque-63de4613.html?x=1#y",
+		"setlist.fm/festival/2024/download-festival-2024-73d44e99.html/",
+		"https://de.setlist.fm/setlist/a/b/c-bd6ad22.html",
+		"https://www.setlist.fm/venue/x-73d6a6c5.html",
+		"https://www.example.com/setlist/a/b/c-53aa1325.html",
+		"://",
+		"%zz",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	refErrors := []error{
+		ErrEmptyURL,
+		ErrNotSetlistFM,
+		ErrUnsupportedPage,
+		ErrMissingID,
+		ErrInvalidID,
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ref, err := ParseRef(input)
+
+		if err != nil {
+			var refErr *RefError
+			if !errors.As(err, &refErr) {
+				t.Fatalf("ParseRef(%q) returned an untyped error: %v", input, err)
+			}
+
+			known := false
+			for _, e := range refErrors {
+				known = known || errors.Is(err, e)
+			}
+
+			if !known {
+				t.Fatalf("ParseRef(%q) returned an unknown error: %v", input, err)
+			}
+
+			if ref != (Ref{}) {
+				t.Fatalf("ParseRef(%q) returned %+v along with an error", input, ref)
+			}
+
+			return
+		}
+
+		if !refIDRegex.MatchString(ref.ID) {
+			t.Fatalf("ParseRef(%q) returned the invalid ID %q", input, ref.ID)
+		}
+
+		if ref.Kind != RefSetlist && ref.Kind != RefFestival {
+			t.Fatalf("ParseRef(%q) returned the unknown kind %d", input, ref.Kind)
+		}
+
+		if ref.Path != "" && !strings.HasSuffix(strings.ToLower(ref.Path), ref.ID+".html") {
+			t.Fatalf("ParseRef(%q) returned the path %q, which doesn't end with its ID %q", input, ref.Path, ref.ID)
+		}
+
+		again, err := ParseRef(ref.ID)
+		if err != nil || again.ID != ref.ID {
+			t.Fatalf("the ID %q parsed from %q doesn't parse back: %+v, %v", ref.ID, input, again, err)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package setlistfm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Shows are setlists turned into a single playlist, e.g. a festival weekend or a residency.
+type Shows []*Set
+
+// Merge combines the shows into one set holding all of their songs, in order. Details the
+// shows share (artist, tour, venue) are kept, the ones they don't are listed together. The
+// merged set has no URL, as it doesn't exist on Setlist.fm.
+func (sh Shows) Merge() *Set {
+	if len(sh) == 1 {
+		return sh[0]
+	}
+
+	merged := &Set{
+		ID:        strings.Join(sh.collect(func(s *Set) string { return s.ID }), "+"),
+		VersionID: strings.Join(sh.collect(func(s *Set) string { return s.VersionID }), "+"),
+	}
+
+	if len(sh) == 0 {
+		return merged
+	}
+
+	merged.EventDate = sh[0].EventDate
+	merged.Artist = sh.artist()
+	merged.Tour = Tour{Name: sh.shared(func(s *Set) string { return s.Tour.Name })}
+	merged.Venue = sh.venue()
+
+	for _, s := range sh {
+		merged.Sets.Set = append(merged.Sets.Set, s.Sets.Set...)
+	}
+
+	return merged
+}
+
+// SameArtist tells whether every show was played by the same artist.
+func (sh Shows) SameArtist() bool {
+	return len(unique(sh.collect(func(s *Set) string { return s.Artist.Name }))) <= 1
+}
+
+// Dates returns the earliest and latest dates of the shows. ok is false when none of them
+// has a valid date.
+func (sh Shows) Dates() (first time.Time, last time.Time, ok bool) {
+	for _, s := range sh {
+		date, valid := s.EventTime()
+		if !valid {
+			continue
+		}
+
+		if !ok || date.Before(first) {
+			first = date
+		}
+
+		if !ok || date.After(last) {
+			last = date
+		}
+
+		ok = true
+	}
+
+	return first, last, ok
+}
+
+// DateRange summarizes the dates of the shows, e.g. "March 9, 2023", "March 9-11, 2023" or
+// "March 30 - April 2, 2023".
+func (sh Shows) DateRange() string {
+	first, last, ok := sh.Dates()
+	if !ok {
+		return ""
+	}
+
+	switch {
+	case first.Equal(last):
+		return first.Format("January 2, 2006")
+	case first.Year() != last.Year():
+		return fmt.Sprintf("%s - %s", first.Format("January 2, 2006"), last.Format("January 2, 2006"))
+	case first.Month() != last.Month():
+		return fmt.Sprintf("%s - %s", first.Format("January 2"), last.Format("January 2, 2006"))
+	default:
+		return fmt.Sprintf("%s-%d, %d", first.Format("January 2"), last.Day(), last.Year())
+	}
+}
+
+// ByArtist groups the shows by artist, in the order each artist first shows up.
+func (sh Shows) ByArtist() []Shows {
+	var groups []Shows
+	index := make(map[string]int)
+
+	for _, s := range sh {
+		key := nameKey(s.Artist.Name)
+
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], s)
+	}
+
+	return groups
+}
+
+func (sh Shows) artist() Artist {
+	if sh.SameArtist() {
+		return sh[0].Artist
+	}
+
+	return Artist{Name: strings.Join(unique(sh.collect(func(s *Set) string { return s.Artist.Name })), ", ")}
+}
+
+func (sh Shows) venue() Venue {
+	if len(unique(sh.collect(func(s *Set) string { return s.Venue.Name }))) == 1 {
+		return sh[0].Venue
+	}
+
+	return Venue{
+		Name: strings.Join(unique(sh.collect(func(s *Set) string { return s.Venue.Name })), ", "),
+		City: City{
+			Name:  strings.Join(unique(sh.collect(func(s *Set) string { return s.Venue.City.Name })), ", "),
+			State: sh.shared(func(s *Set) string { return s.Venue.City.State }),
+			Country: Country{
+				Code: sh.shared(func(s *Set) string { return s.Venue.City.Country.Code }),
+				Name: strings.Join(unique(sh.collect(func(s *Set) string { return s.Venue.City.Country.Name })), ", "),
+			},
+		},
+	}
+}
+
+// shared returns the field when the shows that have it agree on its value, or an empty string.
+func (sh Shows) shared(field func(*Set) string) string {
+	values := unique(sh.collect(field))
+	if len(values) != 1 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (sh Shows) collect(field func(*Set) string) []string {
+	values := make([]string, 0, len(sh))
+
+	for _, s := range sh {
+		values = append(values, field(s))
+	}
+
+	return values
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+
+		if v == "" || seen[v] {
+			continue
+		}
+
+		seen[v] = true
+		out = append(out, v)
+	}
+
+	return out
+}
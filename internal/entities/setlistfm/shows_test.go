@@ -0,0 +1,111 @@
+package setlistfm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ShowsTestSuite struct {
+	suite.Suite
+}
+
+func TestShows(t *testing.T) {
+	suite.Run(t, new(ShowsTestSuite))
+}
+
+func (s *ShowsTestSuite) TestMerge() {
+	venue := Venue{Name: "Allianz Parque", City: City{Name: "São Paulo", Country: Country{Code: "BR", Name: "Brazil"}}}
+
+	s.Run("Should keep a single show as it is", func() {
+		set := &Set{ID: "any-set-id", URL: "any-url"}
+
+		s.Same(set, Shows{set}.Merge())
+	})
+
+	s.Run("Should combine the songs of a residency in order", func() {
+		shows := Shows{
+			{
+				ID:        "set-1",
+				VersionID: "v1",
+				EventDate: "09-03-2023",
+				Artist:    Artist{Name: "Paramore"},
+				Tour:      Tour{Name: "This Is Why"},
+				Venue:     venue,
+				Sets:      Sets{Set: []Songs{{Song: []Song{{Name: "any-song-1"}}}}},
+				URL:       "any-url-1",
+			},
+			{
+				ID:        "set-2",
+				VersionID: "v2",
+				EventDate: "10-03-2023",
+				Artist:    Artist{Name: "Paramore"},
+				Tour:      Tour{Name: "This Is Why"},
+				Venue:     venue,
+				Sets:      Sets{Set: []Songs{{Song: []Song{{Name: "any-song-2"}}}}},
+				URL:       "any-url-2",
+			},
+		}
+
+		merged := shows.Merge()
+
+		s.Equal("set-1+set-2", merged.ID)
+		s.Equal("v1+v2", merged.VersionID)
+		s.Equal("09-03-2023", merged.EventDate)
+		s.Equal("Paramore This Is Why @ Allianz Parque, São Paulo - Brazil", merged.Title())
+		s.Equal([]string{"any-song-1", "any-song-2"}, merged.Songs())
+		s.Empty(merged.URL)
+	})
+
+	s.Run("Should list the artists and venues of a festival", func() {
+		shows := Shows{
+			{Artist: Artist{Name: "Pixies"}, Venue: Venue{Name: "Stage A", City: City{Name: "Lisbon"}}},
+			{Artist: Artist{Name: "Muse"}, Venue: Venue{Name: "Stage B", City: City{Name: "Lisbon"}}},
+		}
+
+		merged := shows.Merge()
+
+		s.Equal("Pixies, Muse", merged.Artist.Name)
+		s.Equal("Stage A, Stage B", merged.Venue.Name)
+		s.Equal("Lisbon", merged.Venue.City.Name)
+		s.False(shows.SameArtist())
+	})
+}
+
+func (s *ShowsTestSuite) TestDateRange() {
+	testCases := []struct {
+		name     string
+		dates    []string
+		expected string
+	}{
+		{"Should show a single date", []string{"09-03-2023"}, "March 9, 2023"},
+		{"Should join days of the same month", []string{"11-03-2023", "09-03-2023"}, "March 9-11, 2023"},
+		{"Should span months", []string{"30-03-2023", "02-04-2023"}, "March 30 - April 2, 2023"},
+		{"Should span years", []string{"30-12-2023", "02-01-2024"}, "December 30, 2023 - January 2, 2024"},
+		{"Should be empty without valid dates", []string{"", "not-a-date"}, ""},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			var shows Shows
+
+			for _, date := range tc.dates {
+				shows = append(shows, &Set{EventDate: date})
+			}
+
+			s.Equal(tc.expected, shows.DateRange())
+		})
+	}
+}
+
+func (s *ShowsTestSuite) TestByArtist() {
+	s.Run("Should group the shows by artist, in order", func() {
+		first := &Set{ID: "any-set-id-1", Artist: Artist{Name: "Paramore"}}
+		second := &Set{ID: "any-set-id-2", Artist: Artist{Name: "Måneskin"}}
+		third := &Set{ID: "any-set-id-3", Artist: Artist{Name: "paramore"}}
+
+		groups := Shows{first, second, third}.ByArtist()
+
+		s.Equal([]Shows{{first, third}, {second}}, groups)
+	})
+}
@@ -1,6 +1,10 @@
 package spotify
 
-import "github.com/zmb3/spotify/v2"
+import (
+	"fmt"
+
+	"github.com/zmb3/spotify/v2"
+)
 
 type AddTracksToPlaylistInput struct {
 	PlaylistID string
@@ -25,3 +29,19 @@ func (cin AddTracksToPlaylistClientInput) GetTrackIDs() []spotify.ID {
 
 	return songIDs
 }
+
+// PartialAddError is returned when some chunks of tracks were added to a playlist before one failed.
+type PartialAddError struct {
+	PlaylistID string
+	Added      int
+	Total      int
+	Err        error
+}
+
+func (e *PartialAddError) Error() string {
+	return fmt.Sprintf("added %d of %d tracks to the playlist before failing: %s", e.Added, e.Total, e.Err)
+}
+
+func (e *PartialAddError) Unwrap() error {
+	return e.Err
+}
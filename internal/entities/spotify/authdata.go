@@ -2,16 +2,33 @@ package spotify
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
 )
 
+var (
+	// LegacyScopes were granted to tokens saved before the scope was persisted along with them.
+	LegacyScopes = []string{"user-read-email", "playlist-modify-public"}
+)
+
 type SpotifyUserAuthData struct {
 	AccessToken  string `json:"access_token"`
 	Expiry       string `json:"expiry"`
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+type MissingScopesError struct {
+	Scopes []string
+}
+
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("the saved Spotify authorization is missing the %s scope", strings.Join(e.Scopes, ", "))
 }
 
 func NewSpotifyUserAuthData(
@@ -19,12 +36,14 @@ func NewSpotifyUserAuthData(
 	expiry string,
 	refreshToken string,
 	tokenType string,
+	scope string,
 ) SpotifyUserAuthData {
 	return SpotifyUserAuthData{
 		AccessToken:  accessToken,
 		Expiry:       expiry,
 		RefreshToken: refreshToken,
 		TokenType:    tokenType,
+		Scope:        scope,
 	}
 }
 
@@ -53,6 +72,28 @@ func (data *SpotifyUserAuthData) Validate() error {
 	return nil
 }
 
+func (d SpotifyUserAuthData) GrantedScopes() []string {
+	if d.Scope == "" {
+		return LegacyScopes
+	}
+
+	return strings.Fields(d.Scope)
+}
+
+func (d SpotifyUserAuthData) MissingScopes(required []string) []string {
+	granted := d.GrantedScopes()
+
+	var missing []string
+
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
+
 func (d SpotifyUserAuthData) ToOauth2Token() (*oauth2.Token, error) {
 	exp, err := time.Parse(time.RFC3339, d.Expiry)
 	if err != nil {
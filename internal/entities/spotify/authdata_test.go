@@ -0,0 +1,68 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SpotifyUserAuthDataTestSuite struct {
+	suite.Suite
+}
+
+func TestSpotifyUserAuthData(t *testing.T) {
+	suite.Run(t, new(SpotifyUserAuthDataTestSuite))
+}
+
+func (s *SpotifyUserAuthDataTestSuite) TestMissingScopes() {
+	s.Run("Should return nothing when every scope was granted", func() {
+		data := SpotifyUserAuthData{Scope: "user-read-email playlist-modify-public playlist-modify-private"}
+
+		s.Empty(data.MissingScopes([]string{ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate}))
+	})
+
+	s.Run("Should return the scopes that were not granted", func() {
+		data := SpotifyUserAuthData{Scope: "user-read-email playlist-modify-public"}
+
+		s.Equal(
+			[]string{ScopePlaylistModifyPrivate},
+			data.MissingScopes([]string{ScopePlaylistModifyPublic, ScopePlaylistModifyPrivate}),
+		)
+	})
+
+	s.Run("Should assume the legacy scopes for tokens saved without them", func() {
+		data := SpotifyUserAuthData{}
+
+		s.Empty(data.MissingScopes([]string{ScopePlaylistModifyPublic}))
+		s.Equal([]string{ScopePlaylistModifyPrivate}, data.MissingScopes([]string{ScopePlaylistModifyPrivate}))
+	})
+}
+
+func (s *SpotifyUserAuthDataTestSuite) TestNewPlaylistVisibility() {
+	s.True(NewPlaylistVisibility(false, false).IsPublic())
+	s.Equal(PlaylistVisibility{Private: true}, NewPlaylistVisibility(true, false))
+	s.Equal(PlaylistVisibility{Private: true, Collaborative: true}, NewPlaylistVisibility(false, true))
+	s.Equal([]string{ScopePlaylistModifyPrivate}, NewPlaylistVisibility(false, true).RequiredScopes())
+}
+
+func (s *SpotifyUserAuthDataTestSuite) TestParsePlaylistVisibility() {
+	for _, v := range []PlaylistVisibility{
+		NewPlaylistVisibility(false, false),
+		NewPlaylistVisibility(true, false),
+		NewPlaylistVisibility(false, true),
+	} {
+		parsed, err := ParsePlaylistVisibility(v.String())
+
+		s.NoError(err)
+		s.Equal(v, parsed)
+	}
+
+	_, err := ParsePlaylistVisibility("secret")
+	s.Error(err)
+}
+
+func (s *SpotifyUserAuthDataTestSuite) TestUpdateScopes() {
+	s.Nil(UpdateScopes("public"))
+	s.Equal([]string{ScopePlaylistModifyPrivate}, UpdateScopes("collaborative"))
+	s.Equal([]string{ScopePlaylistModifyPrivate}, UpdateScopes(""))
+}
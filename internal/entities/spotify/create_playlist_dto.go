@@ -1,17 +1,38 @@
 package spotify
 
+import "fmt"
+
 var (
 	DefaultPlaylistDescription = "Generated by 'Setlist to Playlist' script by @mathcale"
 )
 
+const (
+	ScopePlaylistModifyPublic  = "playlist-modify-public"
+	ScopePlaylistModifyPrivate = "playlist-modify-private"
+	ScopeUGCImageUpload        = "ugc-image-upload"
+)
+
+// PlaylistVisibility is public unless Private is set. Spotify only allows private playlists to be collaborative.
+type PlaylistVisibility struct {
+	Private       bool
+	Collaborative bool
+}
+
 type CreatePlaylistInput struct {
 	Title       string
 	Description *string
+	Visibility  PlaylistVisibility
+}
+
+type UploadPlaylistCoverInput struct {
+	PlaylistID string
+	Image      []byte
 }
 
 type CreatePlaylistOutput struct {
-	ID  string
-	URL string
+	ID         string
+	URL        string
+	Visibility PlaylistVisibility
 }
 
 func (in CreatePlaylistInput) GetDescription() string {
@@ -21,3 +42,63 @@ func (in CreatePlaylistInput) GetDescription() string {
 
 	return *in.Description
 }
+
+func NewPlaylistVisibility(private bool, collaborative bool) PlaylistVisibility {
+	return PlaylistVisibility{
+		Private:       private || collaborative,
+		Collaborative: collaborative,
+	}
+}
+
+// ParsePlaylistVisibility reads a visibility as written by String.
+func ParsePlaylistVisibility(s string) (PlaylistVisibility, error) {
+	switch s {
+	case "public":
+		return NewPlaylistVisibility(false, false), nil
+	case "private":
+		return NewPlaylistVisibility(true, false), nil
+	case "collaborative":
+		return NewPlaylistVisibility(false, true), nil
+	default:
+		return PlaylistVisibility{}, fmt.Errorf("invalid playlist visibility %q", s)
+	}
+}
+
+// UpdateScopes are the scopes needed, besides the public one, to change the tracks of a playlist
+// with the recorded visibility. A playlist recorded without one may be private, Spotify can only
+// be asked once the app is authorized.
+func UpdateScopes(recorded string) []string {
+	v, err := ParsePlaylistVisibility(recorded)
+	if err != nil {
+		return []string{ScopePlaylistModifyPrivate}
+	}
+
+	if v.IsPublic() {
+		return nil
+	}
+
+	return v.RequiredScopes()
+}
+
+func (v PlaylistVisibility) IsPublic() bool {
+	return !v.Private && !v.Collaborative
+}
+
+func (v PlaylistVisibility) RequiredScopes() []string {
+	if v.IsPublic() {
+		return []string{ScopePlaylistModifyPublic}
+	}
+
+	return []string{ScopePlaylistModifyPrivate}
+}
+
+func (v PlaylistVisibility) String() string {
+	switch {
+	case v.Collaborative:
+		return "collaborative"
+	case v.Private:
+		return "private"
+	default:
+		return "public"
+	}
+}
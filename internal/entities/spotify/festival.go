@@ -0,0 +1,25 @@
+package spotify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FestivalMode is how the setlists of a festival lineup are turned into playlists.
+type FestivalMode string
+
+const (
+	FestivalCombined  FestivalMode = "combined"
+	FestivalPerArtist FestivalMode = "per-artist"
+)
+
+func ParseFestivalMode(mode string) (FestivalMode, error) {
+	switch m := FestivalMode(strings.ToLower(strings.TrimSpace(mode))); m {
+	case "":
+		return FestivalCombined, nil
+	case FestivalCombined, FestivalPerArtist:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown festival mode %q, use combined or per-artist", mode)
+	}
+}
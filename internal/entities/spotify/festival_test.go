@@ -0,0 +1,37 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FestivalModeTestSuite struct {
+	suite.Suite
+}
+
+func TestFestivalMode(t *testing.T) {
+	suite.Run(t, new(FestivalModeTestSuite))
+}
+
+func (s *FestivalModeTestSuite) TestParseFestivalMode() {
+	s.Run("Should default to a combined playlist", func() {
+		mode, err := ParseFestivalMode("")
+
+		s.NoError(err)
+		s.Equal(FestivalCombined, mode)
+	})
+
+	s.Run("Should accept per-artist", func() {
+		mode, err := ParseFestivalMode(" Per-Artist ")
+
+		s.NoError(err)
+		s.Equal(FestivalPerArtist, mode)
+	})
+
+	s.Run("Should return an error for unknown modes", func() {
+		_, err := ParseFestivalMode("per-day")
+
+		s.ErrorContains(err, "unknown festival mode")
+	})
+}
@@ -1,12 +1,126 @@
 package spotify
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+type MatchStatus string
+
+const (
+	MatchStatusMatched   MatchStatus = "matched"
+	MatchStatusNotFound  MatchStatus = "not_found"
+	MatchStatusAmbiguous MatchStatus = "ambiguous"
+	MatchStatusSkipped   MatchStatus = "skipped"
+)
+
 type Song struct {
-	ID    string
-	Title string
-	Album string
+	ID      string
+	Title   string
+	Album   string
+	Artists []string
 }
 
-type FindAllSongsOutput struct {
+type SongResult struct {
+	Query          string
+	Status         MatchStatus
+	Song           *Song
+	Score          float64
+	SearchedArtist string
+	OriginalArtist string
+	Candidates     []Song
+	Overridden     bool
+}
+
+type SongQuery struct {
+	Title          string
+	OriginalArtist string
+}
+
+type FindAllSongsInput struct {
+	Songs  []SongQuery
 	Artist string
-	Songs  []Song
+	Policy matching.Policy
+}
+
+type FindAllSongsOutput struct {
+	Artist  string
+	Songs   []Song
+	Results []SongResult
+}
+
+func NewFindAllSongsOutput(artist string, results []SongResult) *FindAllSongsOutput {
+	out := &FindAllSongsOutput{
+		Artist:  artist,
+		Results: results,
+	}
+
+	for _, r := range results {
+		if r.Status == MatchStatusMatched && r.Song != nil {
+			out.Songs = append(out.Songs, *r.Song)
+		}
+	}
+
+	return out
+}
+
+func NewSongQueries(songs []setlistfm.Song) []SongQuery {
+	queries := make([]SongQuery, len(songs))
+
+	for i, s := range songs {
+		queries[i] = SongQuery{
+			Title:          s.Name,
+			OriginalArtist: s.OriginalArtistName(),
+		}
+	}
+
+	return queries
+}
+
+func (q SongQuery) IsCover() bool {
+	return q.OriginalArtist != ""
+}
+
+func (r SongResult) IsBetterThan(other SongResult) bool {
+	if (r.Status == MatchStatusMatched) != (other.Status == MatchStatusMatched) {
+		return r.Status == MatchStatusMatched
+	}
+
+	return r.Score > other.Score
+}
+
+func (s MatchStatus) Reason() string {
+	switch s {
+	case MatchStatusNotFound:
+		return "no track found on Spotify"
+	case MatchStatusAmbiguous:
+		return "no confident match among Spotify results"
+	case MatchStatusSkipped:
+		return "skipped by you"
+	default:
+		return ""
+	}
+}
+
+func (s Song) String() string {
+	if len(s.Artists) == 0 {
+		return fmt.Sprintf("%s (%s)", s.Title, s.Album)
+	}
+
+	return fmt.Sprintf("%s - %s (%s)", s.Title, strings.Join(s.Artists, ", "), s.Album)
+}
+
+func (out FindAllSongsOutput) Unmatched() []SongResult {
+	var unmatched []SongResult
+
+	for _, r := range out.Results {
+		if r.Status != MatchStatusMatched {
+			unmatched = append(unmatched, r)
+		}
+	}
+
+	return unmatched
 }
@@ -0,0 +1,87 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+)
+
+type FindAllSongsOutputTestSuite struct {
+	suite.Suite
+}
+
+func TestFindAllSongsOutput(t *testing.T) {
+	suite.Run(t, new(FindAllSongsOutputTestSuite))
+}
+
+func (s *FindAllSongsOutputTestSuite) TestUnmatched() {
+	s.Run("Should return only songs that were not matched", func() {
+		out := FindAllSongsOutput{
+			Results: []SongResult{
+				{Query: "any-song-1", Status: MatchStatusMatched, Song: &Song{ID: "any-song-id-1"}},
+				{Query: "any-song-2", Status: MatchStatusNotFound},
+				{Query: "any-song-3", Status: MatchStatusAmbiguous},
+			},
+		}
+
+		unmatched := out.Unmatched()
+
+		s.Len(unmatched, 2)
+		s.Equal("any-song-2", unmatched[0].Query)
+		s.Equal("any-song-3", unmatched[1].Query)
+	})
+
+	s.Run("Should return nothing when every song was matched", func() {
+		out := FindAllSongsOutput{
+			Results: []SongResult{
+				{Query: "any-song-1", Status: MatchStatusMatched, Song: &Song{ID: "any-song-id-1"}},
+			},
+		}
+
+		s.Empty(out.Unmatched())
+	})
+}
+
+func (s *FindAllSongsOutputTestSuite) TestReason() {
+	s.Run("Should explain why a song was left out", func() {
+		s.NotEmpty(MatchStatusNotFound.Reason())
+		s.NotEmpty(MatchStatusAmbiguous.Reason())
+		s.Empty(MatchStatusMatched.Reason())
+	})
+}
+
+func (s *FindAllSongsOutputTestSuite) TestNewSongQueries() {
+	s.Run("Should keep the original artist of covers", func() {
+		queries := NewSongQueries([]setlistfm.Song{
+			{Name: "any-song-1"},
+			{Name: "any-song-2", Cover: &setlistfm.Artist{Name: "any-original-artist"}},
+		})
+
+		s.Equal([]SongQuery{
+			{Title: "any-song-1"},
+			{Title: "any-song-2", OriginalArtist: "any-original-artist"},
+		}, queries)
+		s.False(queries[0].IsCover())
+		s.True(queries[1].IsCover())
+	})
+}
+
+func (s *FindAllSongsOutputTestSuite) TestIsBetterThan() {
+	s.Run("Should prefer a matched result over an unmatched one", func() {
+		matched := SongResult{Status: MatchStatusMatched, Score: 0.8}
+		ambiguous := SongResult{Status: MatchStatusAmbiguous, Score: 0.7}
+
+		s.True(matched.IsBetterThan(ambiguous))
+		s.False(ambiguous.IsBetterThan(matched))
+	})
+
+	s.Run("Should prefer the higher score between matched results", func() {
+		a := SongResult{Status: MatchStatusMatched, Score: 0.9}
+		b := SongResult{Status: MatchStatusMatched, Score: 0.85}
+
+		s.True(a.IsBetterThan(b))
+		s.False(b.IsBetterThan(a))
+	})
+}
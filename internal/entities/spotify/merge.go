@@ -0,0 +1,69 @@
+package spotify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeMode is how the songs of several shows are combined into one playlist.
+type MergeMode string
+
+const (
+	MergeConcat MergeMode = "concat"
+	MergeDedupe MergeMode = "dedupe"
+)
+
+func ParseMergeMode(mode string) (MergeMode, error) {
+	switch m := MergeMode(strings.ToLower(strings.TrimSpace(mode))); m {
+	case "":
+		return MergeConcat, nil
+	case MergeConcat, MergeDedupe:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown merge mode %q, use concat or dedupe", mode)
+	}
+}
+
+// ConcatSongs puts the songs found for several shows one after the other.
+func ConcatSongs(artist string, outputs []*FindAllSongsOutput) *FindAllSongsOutput {
+	out := &FindAllSongsOutput{Artist: artist}
+
+	for _, o := range outputs {
+		out.Songs = append(out.Songs, o.Songs...)
+		out.Results = append(out.Results, o.Results...)
+	}
+
+	return out
+}
+
+// Dedupe keeps only the first time each track shows up, so songs played on several nights
+// are added once.
+func (out FindAllSongsOutput) Dedupe() *FindAllSongsOutput {
+	seen := make(map[string]bool, len(out.Songs))
+	deduped := &FindAllSongsOutput{Artist: out.Artist}
+
+	for _, s := range out.Songs {
+		if seen[s.ID] {
+			continue
+		}
+
+		seen[s.ID] = true
+		deduped.Songs = append(deduped.Songs, s)
+	}
+
+	added := make(map[string]bool, len(deduped.Songs))
+
+	for _, r := range out.Results {
+		if r.Status == MatchStatusMatched && r.Song != nil {
+			if added[r.Song.ID] {
+				continue
+			}
+
+			added[r.Song.ID] = true
+		}
+
+		deduped.Results = append(deduped.Results, r)
+	}
+
+	return deduped
+}
@@ -0,0 +1,59 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MergeTestSuite struct {
+	suite.Suite
+}
+
+func TestMerge(t *testing.T) {
+	suite.Run(t, new(MergeTestSuite))
+}
+
+func (s *MergeTestSuite) TestParseMergeMode() {
+	s.Run("Should default to concatenating the shows", func() {
+		mode, err := ParseMergeMode("")
+
+		s.NoError(err)
+		s.Equal(MergeConcat, mode)
+	})
+
+	s.Run("Should accept dedupe", func() {
+		mode, err := ParseMergeMode(" Dedupe ")
+
+		s.NoError(err)
+		s.Equal(MergeDedupe, mode)
+	})
+
+	s.Run("Should return an error for unknown modes", func() {
+		_, err := ParseMergeMode("shuffle")
+
+		s.ErrorContains(err, "unknown merge mode")
+	})
+}
+
+func (s *MergeTestSuite) TestDedupe() {
+	s.Run("Should keep the first time each track shows up", func() {
+		first := Song{ID: "any-song-id-1"}
+		second := Song{ID: "any-song-id-2"}
+
+		night1 := NewFindAllSongsOutput("any-artist", []SongResult{
+			{Status: MatchStatusMatched, Song: &first},
+			{Status: MatchStatusNotFound, Query: "any-missing-song"},
+		})
+		night2 := NewFindAllSongsOutput("any-artist", []SongResult{
+			{Status: MatchStatusMatched, Song: &second},
+			{Status: MatchStatusMatched, Song: &first},
+		})
+
+		out := ConcatSongs("any-artist", []*FindAllSongsOutput{night1, night2}).Dedupe()
+
+		s.Equal([]Song{first, second}, out.Songs)
+		s.Len(out.Results, 3)
+		s.Len(out.Unmatched(), 1)
+	})
+}
@@ -0,0 +1,189 @@
+package spotify
+
+type PlaylistItem struct {
+	Position int
+	Song     Song
+}
+
+// PlaylistMove moves the item at From so it sits before the item currently at InsertBefore,
+// the same semantics as Spotify's reorder endpoint.
+type PlaylistMove struct {
+	From         int
+	InsertBefore int
+	Song         Song
+	Added        bool
+}
+
+// PlaylistDiff lists the changes that turn a playlist into the desired track list.
+// They must be applied in order: removals, then additions (appended), then moves.
+type PlaylistDiff struct {
+	Removed []PlaylistItem
+	Added   []Song
+	Moves   []PlaylistMove
+}
+
+type SyncPlaylistInput struct {
+	PlaylistID string
+	Tracks     []Song
+}
+
+func (d PlaylistDiff) IsEmpty() bool {
+	return len(d.Removed) == 0 && len(d.Added) == 0 && len(d.Moves) == 0
+}
+
+// Reordered returns the songs that were already in the playlist but changed position.
+func (d PlaylistDiff) Reordered() []Song {
+	var songs []Song
+
+	for _, m := range d.Moves {
+		if !m.Added {
+			songs = append(songs, m.Song)
+		}
+	}
+
+	return songs
+}
+
+// DiffPlaylist computes the smallest set of removals and additions between the current and
+// desired tracks, and the moves needed to put them in order, keeping in place the longest
+// run of tracks that are already in the right relative order.
+func DiffPlaylist(current []Song, desired []Song) PlaylistDiff {
+	var diff PlaylistDiff
+
+	wanted := map[string]int{}
+	for _, s := range desired {
+		wanted[s.ID]++
+	}
+
+	working := make([]Song, 0, len(desired))
+	added := make([]bool, 0, len(desired))
+
+	for i, s := range current {
+		if wanted[s.ID] > 0 {
+			wanted[s.ID]--
+			working = append(working, s)
+			added = append(added, false)
+			continue
+		}
+
+		diff.Removed = append(diff.Removed, PlaylistItem{Position: i, Song: s})
+	}
+
+	kept := map[string]int{}
+	for _, s := range working {
+		kept[s.ID]++
+	}
+
+	for _, s := range desired {
+		if kept[s.ID] > 0 {
+			kept[s.ID]--
+			continue
+		}
+
+		diff.Added = append(diff.Added, s)
+		working = append(working, s)
+		added = append(added, true)
+	}
+
+	targets := targetPositions(working, desired)
+	inPlace := longestIncreasingRun(targets)
+
+	positionOf := func(target int) int {
+		for i, t := range targets {
+			if t == target {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	for target := range desired {
+		from := positionOf(target)
+		if inPlace[from] {
+			continue
+		}
+
+		insertBefore := 0
+		if target > 0 {
+			insertBefore = positionOf(target-1) + 1
+		}
+
+		if insertBefore == from || insertBefore == from+1 {
+			inPlace[from] = true
+			continue
+		}
+
+		diff.Moves = append(diff.Moves, PlaylistMove{
+			From:         from,
+			InsertBefore: insertBefore,
+			Song:         working[from],
+			Added:        added[from],
+		})
+
+		to := insertBefore
+		if insertBefore > from {
+			to--
+		}
+
+		targets = moveItem(targets, from, to)
+		working = moveItem(working, from, to)
+		added = moveItem(added, from, to)
+		inPlace = moveItem(inPlace, from, to)
+		inPlace[to] = true
+	}
+
+	return diff
+}
+
+// targetPositions maps each item to its index in desired, pairing repeated tracks in order.
+func targetPositions(items []Song, desired []Song) []int {
+	positions := map[string][]int{}
+	for i, s := range desired {
+		positions[s.ID] = append(positions[s.ID], i)
+	}
+
+	targets := make([]int, len(items))
+	for i, s := range items {
+		targets[i] = positions[s.ID][0]
+		positions[s.ID] = positions[s.ID][1:]
+	}
+
+	return targets
+}
+
+func longestIncreasingRun(values []int) []bool {
+	n := len(values)
+	length := make([]int, n)
+	prev := make([]int, n)
+	best := -1
+
+	for i := range values {
+		length[i], prev[i] = 1, -1
+
+		for j := 0; j < i; j++ {
+			if values[j] < values[i] && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+
+		if best == -1 || length[i] > length[best] {
+			best = i
+		}
+	}
+
+	in := make([]bool, n)
+	for i := best; i >= 0; i = prev[i] {
+		in[i] = true
+	}
+
+	return in
+}
+
+func moveItem[T any](items []T, from int, to int) []T {
+	item := items[from]
+	items = append(items[:from], items[from+1:]...)
+	items = append(items[:to], append([]T{item}, items[to:]...)...)
+
+	return items
+}
@@ -0,0 +1,142 @@
+package spotify
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PlaylistDiffTestSuite struct {
+	suite.Suite
+}
+
+func TestPlaylistDiff(t *testing.T) {
+	suite.Run(t, new(PlaylistDiffTestSuite))
+}
+
+func songs(ids ...string) []Song {
+	out := make([]Song, len(ids))
+	for i, id := range ids {
+		out[i] = Song{ID: id, Title: "title-" + id}
+	}
+
+	return out
+}
+
+func ids(songs []Song) []string {
+	out := make([]string, len(songs))
+	for i, s := range songs {
+		out[i] = s.ID
+	}
+
+	return out
+}
+
+// apply replays the diff the way Spotify would.
+func apply(current []Song, diff PlaylistDiff) []Song {
+	removed := map[int]bool{}
+	for _, r := range diff.Removed {
+		removed[r.Position] = true
+	}
+
+	var out []Song
+	for i, s := range current {
+		if !removed[i] {
+			out = append(out, s)
+		}
+	}
+
+	out = append(out, diff.Added...)
+
+	for _, m := range diff.Moves {
+		to := m.InsertBefore
+		if to > m.From {
+			to--
+		}
+
+		out = moveItem(out, m.From, to)
+	}
+
+	return out
+}
+
+func (s *PlaylistDiffTestSuite) TestDiffPlaylist() {
+	s.Run("Should return an empty diff when nothing changed", func() {
+		diff := DiffPlaylist(songs("a", "b", "c"), songs("a", "b", "c"))
+
+		s.True(diff.IsEmpty())
+	})
+
+	s.Run("Should remove and add songs without moving the others", func() {
+		current := songs("a", "b", "c")
+		desired := songs("a", "c", "d")
+
+		diff := DiffPlaylist(current, desired)
+
+		s.Equal([]PlaylistItem{{Position: 1, Song: current[1]}}, diff.Removed)
+		s.Equal(songs("d"), diff.Added)
+		s.Empty(diff.Moves)
+		s.Equal(ids(desired), ids(apply(current, diff)))
+	})
+
+	s.Run("Should insert an added song in the middle", func() {
+		current := songs("a", "b", "c")
+		desired := songs("a", "x", "b", "c")
+
+		diff := DiffPlaylist(current, desired)
+
+		s.Len(diff.Moves, 1)
+		s.True(diff.Moves[0].Added)
+		s.Empty(diff.Reordered())
+		s.Equal(ids(desired), ids(apply(current, diff)))
+	})
+
+	s.Run("Should move only the song that changed position", func() {
+		current := songs("a", "b", "c", "d")
+		desired := songs("b", "c", "d", "a")
+
+		diff := DiffPlaylist(current, desired)
+
+		s.Len(diff.Moves, 1)
+		s.Equal(songs("a"), diff.Reordered())
+		s.Equal(ids(desired), ids(apply(current, diff)))
+	})
+
+	s.Run("Should handle songs played twice", func() {
+		current := songs("a", "b", "a")
+		desired := songs("a", "a", "b", "a")
+
+		diff := DiffPlaylist(current, desired)
+
+		s.Empty(diff.Removed)
+		s.Equal(songs("a"), diff.Added)
+		s.Equal(ids(desired), ids(apply(current, diff)))
+	})
+
+	s.Run("Should always produce the desired playlist", func() {
+		r := rand.New(rand.NewSource(1))
+		pool := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+		for i := 0; i < 500; i++ {
+			current := songs(pick(r, pool)...)
+			desired := songs(pick(r, pool)...)
+
+			diff := DiffPlaylist(current, desired)
+
+			s.Equal(ids(desired), ids(apply(current, diff)), "from %v to %v", ids(current), ids(desired))
+			s.LessOrEqual(len(diff.Moves), len(desired))
+		}
+	})
+}
+
+func pick(r *rand.Rand, pool []string) []string {
+	n := r.Intn(len(pool) + 3)
+	out := make([]string, n)
+
+	for i := range out {
+		out[i] = pool[r.Intn(len(pool))]
+	}
+
+	return out
+}
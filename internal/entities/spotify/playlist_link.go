@@ -0,0 +1,76 @@
+package spotify
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	playlistIDRegex = regexp.MustCompile(`^[0-9A-Za-z]{22}$`)
+)
+
+type PlaylistLink struct {
+	SetlistID  string    `json:"setlist_id"`
+	PlaylistID string    `json:"playlist_id"`
+	URL        string    `json:"url"`
+	Visibility string    `json:"visibility,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type PlaylistLinks struct {
+	Links map[string]PlaylistLink `json:"links"`
+}
+
+type UpdatePlaylistInput struct {
+	PlaylistID string
+	Tracks     []Song
+}
+
+func (l *PlaylistLinks) Get(setlistID string) (*PlaylistLink, bool) {
+	link, ok := l.Links[setlistID]
+	if !ok {
+		return nil, false
+	}
+
+	return &link, true
+}
+
+func (l *PlaylistLinks) Set(link PlaylistLink) {
+	if l.Links == nil {
+		l.Links = map[string]PlaylistLink{}
+	}
+
+	l.Links[link.SetlistID] = link
+}
+
+// ParsePlaylistID accepts a bare playlist ID, a spotify:playlist URI or an open.spotify.com playlist URL.
+func ParsePlaylistID(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	id := ref
+
+	switch {
+	case strings.HasPrefix(ref, "spotify:playlist:"):
+		id = strings.TrimPrefix(ref, "spotify:playlist:")
+	case strings.HasPrefix(ref, "http://"), strings.HasPrefix(ref, "https://"):
+		u, err := url.Parse(ref)
+		if err != nil {
+			return "", fmt.Errorf("invalid Spotify playlist URL %q: %w", ref, err)
+		}
+
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if u.Host != "open.spotify.com" || len(parts) < 2 || parts[len(parts)-2] != "playlist" {
+			return "", fmt.Errorf("%q is not a Spotify playlist URL", ref)
+		}
+
+		id = parts[len(parts)-1]
+	}
+
+	if !playlistIDRegex.MatchString(id) {
+		return "", fmt.Errorf("%q is not a Spotify playlist ID or URL", ref)
+	}
+
+	return id, nil
+}
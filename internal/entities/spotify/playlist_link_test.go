@@ -0,0 +1,58 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PlaylistLinkTestSuite struct {
+	suite.Suite
+}
+
+func TestPlaylistLink(t *testing.T) {
+	suite.Run(t, new(PlaylistLinkTestSuite))
+}
+
+func (s *PlaylistLinkTestSuite) TestParsePlaylistID() {
+	cases := []struct {
+		in       string
+		expected string
+		err      bool
+	}{
+		{in: "37i9dQZF1DXcBWIGoYBM5M", expected: "37i9dQZF1DXcBWIGoYBM5M"},
+		{in: "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M", expected: "37i9dQZF1DXcBWIGoYBM5M"},
+		{in: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M?si=abc", expected: "37i9dQZF1DXcBWIGoYBM5M"},
+		{in: "https://open.spotify.com/track/37i9dQZF1DXcBWIGoYBM5M", err: true},
+		{in: "https://example.com/playlist/37i9dQZF1DXcBWIGoYBM5M", err: true},
+		{in: "my playlist", err: true},
+	}
+
+	for _, c := range cases {
+		s.Run(c.in, func() {
+			id, err := ParsePlaylistID(c.in)
+
+			if c.err {
+				s.Error(err)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(c.expected, id)
+		})
+	}
+}
+
+func (s *PlaylistLinkTestSuite) TestPlaylistLinks() {
+	links := &PlaylistLinks{}
+
+	_, ok := links.Get("any-setlist-id")
+	s.False(ok)
+
+	links.Set(PlaylistLink{SetlistID: "any-setlist-id", PlaylistID: "first"})
+	links.Set(PlaylistLink{SetlistID: "any-setlist-id", PlaylistID: "second"})
+
+	link, ok := links.Get("any-setlist-id")
+	s.True(ok)
+	s.Equal("second", link.PlaylistID)
+}
@@ -0,0 +1,167 @@
+package spotify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+)
+
+// DefaultTitleTemplate renders the same title as setlistfm.Set.Title, followed by the range of
+// dates when the playlist covers several shows.
+const DefaultTitleTemplate = "{{.Artist}}{{with .Tour}} {{.}}{{end}} @ {{.Venue}}, {{.City}} - {{.Country}}" +
+	"{{if gt (len .Shows) 1}} ({{.DateRange}}){{end}}"
+
+// PredictedTitleTemplate is the default title of playlists made by predicting a tour setlist.
+const PredictedTitleTemplate = "{{.Artist}}{{with .Tour}} - {{.}}{{end}} (predicted setlist)"
+
+// FestivalTitleTemplate is the default title of a playlist combining the lineup of a festival.
+const FestivalTitleTemplate = "{{.Festival}}{{with .DateRange}} ({{.}}){{end}}"
+
+// FestivalArtistTitleTemplate is the default title of the playlist of one artist of a festival.
+const FestivalArtistTitleTemplate = "{{.Artist}} @ {{.Festival}}"
+
+// PlaylistTemplateData is what title and description templates are executed against.
+type PlaylistTemplateData struct {
+	SetlistID   string
+	Artist      string
+	Tour        string
+	Venue       string
+	City        string
+	State       string
+	Country     string
+	CountryCode string
+	EventDate   string
+	Date        time.Time
+	URL         string
+	Set         *setlistfm.Set
+	// Shows, FirstDate, LastDate and DateRange describe every show of a combined playlist.
+	Shows     setlistfm.Shows
+	FirstDate time.Time
+	LastDate  time.Time
+	DateRange string
+	// Festival is the name of the festival the shows were played at, if any.
+	Festival string
+}
+
+type PlaylistTemplate struct {
+	title       *template.Template
+	description *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(layout string, t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format(layout)
+	},
+	"year": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return fmt.Sprint(t.Year())
+	},
+	"default": func(fallback string, value string) string {
+		if strings.TrimSpace(value) == "" {
+			return fallback
+		}
+
+		return value
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// NewPlaylistTemplate parses the title and description templates. An empty title uses
+// DefaultTitleTemplate and an empty description keeps DefaultPlaylistDescription.
+func NewPlaylistTemplate(title string, description string) (*PlaylistTemplate, error) {
+	if strings.TrimSpace(title) == "" {
+		title = DefaultTitleTemplate
+	}
+
+	titleTmpl, err := template.New("title").Funcs(templateFuncs).Option("missingkey=error").Parse(title)
+	if err != nil {
+		return nil, fmt.Errorf("invalid title template: %w", err)
+	}
+
+	tmpl := &PlaylistTemplate{title: titleTmpl}
+
+	if strings.TrimSpace(description) != "" {
+		tmpl.description, err = template.New("description").Funcs(templateFuncs).Option("missingkey=error").Parse(description)
+		if err != nil {
+			return nil, fmt.Errorf("invalid description template: %w", err)
+		}
+	}
+
+	return tmpl, nil
+}
+
+// NewShowsTemplateData fills the set fields from the shows merged together, see setlistfm.Shows.Merge.
+func NewShowsTemplateData(shows setlistfm.Shows) PlaylistTemplateData {
+	set := shows.Merge()
+	date, _ := set.EventTime()
+	first, last, _ := shows.Dates()
+
+	return PlaylistTemplateData{
+		SetlistID:   set.ID,
+		Artist:      set.Artist.Name,
+		Tour:        strings.TrimSpace(set.Tour.Name),
+		Venue:       set.Venue.Name,
+		City:        set.Venue.City.Name,
+		State:       set.Venue.City.State,
+		Country:     set.Venue.City.Country.Name,
+		CountryCode: set.Venue.City.Country.Code,
+		EventDate:   set.EventDate,
+		Date:        date,
+		URL:         set.URL,
+		Set:         set,
+		Shows:       shows,
+		FirstDate:   first,
+		LastDate:    last,
+		DateRange:   shows.DateRange(),
+	}
+}
+
+// RenderData builds the playlist title and description out of data filled by the caller, see
+// NewShowsTemplateData. Runs of whitespace are collapsed, so optional fields left empty don't
+// leave gaps behind.
+func (t *PlaylistTemplate) RenderData(data PlaylistTemplateData) (CreatePlaylistInput, error) {
+	title, err := execute(t.title, data)
+	if err != nil {
+		return CreatePlaylistInput{}, fmt.Errorf("failed to render the playlist title: %w", err)
+	}
+
+	if title == "" {
+		return CreatePlaylistInput{}, errors.New("the title template rendered an empty playlist title")
+	}
+
+	input := CreatePlaylistInput{Title: title}
+
+	if t.description != nil {
+		description, err := execute(t.description, data)
+		if err != nil {
+			return CreatePlaylistInput{}, fmt.Errorf("failed to render the playlist description: %w", err)
+		}
+
+		input.Description = &description
+	}
+
+	return input, nil
+}
+
+func execute(tmpl *template.Template, data PlaylistTemplateData) (string, error) {
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
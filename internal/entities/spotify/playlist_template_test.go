@@ -0,0 +1,171 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+)
+
+type PlaylistTemplateTestSuite struct {
+	suite.Suite
+	Set *setlistfm.Set
+}
+
+func (s *PlaylistTemplateTestSuite) SetupSubTest() {
+	s.Set = &setlistfm.Set{
+		ID:        "63de4613",
+		EventDate: "09-03-2023",
+		Artist:    setlistfm.Artist{Name: "Paramore"},
+		Tour:      setlistfm.Tour{Name: "This Is Why"},
+		Venue: setlistfm.Venue{
+			Name: "Allianz Parque",
+			City: setlistfm.City{
+				Name:    "São Paulo",
+				State:   "São Paulo",
+				Country: setlistfm.Country{Code: "BR", Name: "Brazil"},
+			},
+		},
+		URL: "https://www.setlist.fm/setlist/paramore/2023/allianz-parque-sao-paulo-brazil-63de4613.html",
+	}
+}
+
+func TestPlaylistTemplate(t *testing.T) {
+	suite.Run(t, new(PlaylistTemplateTestSuite))
+}
+
+func (s *PlaylistTemplateTestSuite) TestRender() {
+	s.Run("Should render the set title and default description when no template is given", func() {
+		tmpl, err := NewPlaylistTemplate("", "")
+		s.NoError(err)
+
+		input, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{s.Set}))
+
+		s.NoError(err)
+		s.Equal(s.Set.Title(), input.Title)
+		s.Nil(input.Description)
+		s.Equal(DefaultPlaylistDescription, input.GetDescription())
+	})
+
+	s.Run("Should match the set title when there is no tour", func() {
+		s.Set.Tour.Name = ""
+
+		tmpl, _ := NewPlaylistTemplate("", "")
+		input, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{s.Set}))
+
+		s.NoError(err)
+		s.Equal("Paramore @ Allianz Parque, São Paulo - Brazil", input.Title)
+		s.Equal(s.Set.Title(), input.Title)
+	})
+
+	s.Run("Should render the set fields and date helpers", func() {
+		tmpl, err := NewPlaylistTemplate(
+			`{{.Artist}} - {{.City}} {{year .Date}}`,
+			`{{.Tour | default "Live"}} at {{.Venue}} on {{.Date | date "January 2, 2006"}}. {{.URL}}`,
+		)
+		s.NoError(err)
+
+		input, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{s.Set}))
+
+		s.NoError(err)
+		s.Equal("Paramore - São Paulo 2023", input.Title)
+		s.Equal(
+			"This Is Why at Allianz Parque on March 9, 2023. https://www.setlist.fm/setlist/paramore/2023/allianz-parque-sao-paulo-brazil-63de4613.html",
+			input.GetDescription(),
+		)
+	})
+
+	s.Run("Should collapse the whitespace left by empty fields", func() {
+		s.Set.Tour.Name = ""
+
+		tmpl, _ := NewPlaylistTemplate("{{.Artist}} {{.Tour}}\n{{upper .CountryCode}}", "")
+		input, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{s.Set}))
+
+		s.NoError(err)
+		s.Equal("Paramore BR", input.Title)
+	})
+
+	s.Run("Should return an error when the title renders empty", func() {
+		tmpl, _ := NewPlaylistTemplate("{{.Tour}}", "")
+		s.Set.Tour.Name = ""
+
+		_, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{s.Set}))
+
+		s.ErrorContains(err, "empty playlist title")
+	})
+
+	s.Run("Should return an error for unknown fields", func() {
+		tmpl, _ := NewPlaylistTemplate("{{.Headliner}}", "")
+
+		_, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{s.Set}))
+
+		s.ErrorContains(err, "failed to render the playlist title")
+	})
+}
+
+func (s *PlaylistTemplateTestSuite) TestRenderDataWithShows() {
+	s.Run("Should add the range of dates to the default title", func() {
+		other := *s.Set
+		other.ID = "73de4613"
+		other.EventDate = "11-03-2023"
+
+		tmpl, _ := NewPlaylistTemplate("", "")
+		input, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{s.Set, &other}))
+
+		s.NoError(err)
+		s.Equal("Paramore This Is Why @ Allianz Parque, São Paulo - Brazil (March 9-11, 2023)", input.Title)
+	})
+
+	s.Run("Should render the range fields", func() {
+		other := *s.Set
+		other.EventDate = "02-04-2023"
+
+		tmpl, _ := NewPlaylistTemplate(`{{.Artist}}: {{len .Shows}} nights, {{.DateRange}}`, `Until {{.LastDate | date "Jan 2"}}`)
+		input, err := tmpl.RenderData(NewShowsTemplateData(setlistfm.Shows{&other, s.Set}))
+
+		s.NoError(err)
+		s.Equal("Paramore: 2 nights, March 9 - April 2, 2023", input.Title)
+		s.Equal("Until Apr 2", input.GetDescription())
+	})
+}
+
+func (s *PlaylistTemplateTestSuite) TestRenderData() {
+	s.Run("Should render the festival titles", func() {
+		other := *s.Set
+		other.Artist = setlistfm.Artist{Name: "Måneskin"}
+		other.EventDate = "10-03-2023"
+
+		data := NewShowsTemplateData(setlistfm.Shows{s.Set, &other})
+		data.Festival = "Lollapalooza Brasil 2023"
+
+		lineup, _ := NewPlaylistTemplate(FestivalTitleTemplate, "")
+		input, err := lineup.RenderData(data)
+
+		s.NoError(err)
+		s.Equal("Lollapalooza Brasil 2023 (March 9-10, 2023)", input.Title)
+
+		data = NewShowsTemplateData(setlistfm.Shows{s.Set})
+		data.Festival = "Lollapalooza Brasil 2023"
+
+		artist, _ := NewPlaylistTemplate(FestivalArtistTitleTemplate, "")
+		input, err = artist.RenderData(data)
+
+		s.NoError(err)
+		s.Equal("Paramore @ Lollapalooza Brasil 2023", input.Title)
+	})
+}
+
+func (s *PlaylistTemplateTestSuite) TestNewPlaylistTemplate() {
+	s.Run("Should return an error for an invalid title template", func() {
+		_, err := NewPlaylistTemplate("{{.Artist", "")
+
+		s.ErrorContains(err, "invalid title template")
+	})
+
+	s.Run("Should return an error for an invalid description template", func() {
+		_, err := NewPlaylistTemplate("", "{{nope .Date}}")
+
+		s.ErrorContains(err, "invalid description template")
+	})
+}
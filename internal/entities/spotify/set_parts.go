@@ -0,0 +1,65 @@
+package spotify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetsMode is how the sets of a show (main set, encores) end up on Spotify.
+type SetsMode string
+
+const (
+	SetsCombined SetsMode = "combined"
+	SetsSplit    SetsMode = "split"
+	SetsDescribe SetsMode = "describe"
+)
+
+func ParseSetsMode(mode string) (SetsMode, error) {
+	switch m := SetsMode(strings.ToLower(strings.TrimSpace(mode))); m {
+	case "":
+		return SetsCombined, nil
+	case SetsCombined, SetsSplit, SetsDescribe:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown sets mode %q, use combined, split or describe", mode)
+	}
+}
+
+// Split cuts the results back into the sets they were searched for, sizes being the number
+// of songs of each set, in order.
+func (out FindAllSongsOutput) Split(sizes []int) []*FindAllSongsOutput {
+	outputs := make([]*FindAllSongsOutput, 0, len(sizes))
+	start := 0
+
+	for _, size := range sizes {
+		end := min(start+size, len(out.Results))
+		outputs = append(outputs, NewFindAllSongsOutput(out.Artist, out.Results[start:end]))
+		start = end
+	}
+
+	return outputs
+}
+
+// DescribeSets tells the playlist positions every set spans, e.g. "Main Set: 1-12 · Encore: 13-15".
+// Sets without any track are left out.
+func DescribeSets(labels []string, counts []int) string {
+	var parts []string
+	position := 1
+
+	for i, label := range labels {
+		count := counts[i]
+
+		switch {
+		case count == 0:
+			continue
+		case count == 1:
+			parts = append(parts, fmt.Sprintf("%s: %d", label, position))
+		default:
+			parts = append(parts, fmt.Sprintf("%s: %d-%d", label, position, position+count-1))
+		}
+
+		position += count
+	}
+
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,55 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SetPartsTestSuite struct {
+	suite.Suite
+}
+
+func TestSetParts(t *testing.T) {
+	suite.Run(t, new(SetPartsTestSuite))
+}
+
+func (s *SetPartsTestSuite) TestParseSetsMode() {
+	mode, err := ParseSetsMode("")
+	s.NoError(err)
+	s.Equal(SetsCombined, mode)
+
+	mode, err = ParseSetsMode("Split")
+	s.NoError(err)
+	s.Equal(SetsSplit, mode)
+
+	_, err = ParseSetsMode("shuffle")
+	s.ErrorContains(err, "unknown sets mode")
+}
+
+func (s *SetPartsTestSuite) TestSplit() {
+	song := func(id string) *Song { return &Song{ID: id} }
+
+	out := NewFindAllSongsOutput("any-artist", []SongResult{
+		{Query: "a", Status: MatchStatusMatched, Song: song("a")},
+		{Query: "b", Status: MatchStatusNotFound},
+		{Query: "c", Status: MatchStatusMatched, Song: song("c")},
+		{Query: "d", Status: MatchStatusMatched, Song: song("d")},
+	})
+
+	parts := out.Split([]int{2, 2})
+
+	s.Len(parts, 2)
+	s.Equal([]Song{{ID: "a"}}, parts[0].Songs)
+	s.Len(parts[0].Unmatched(), 1)
+	s.Equal([]Song{{ID: "c"}, {ID: "d"}}, parts[1].Songs)
+	s.Equal("any-artist", parts[1].Artist)
+}
+
+func (s *SetPartsTestSuite) TestDescribeSets() {
+	s.Equal(
+		"Main Set: 1-12 · Encore 1: 13 · Encore 3: 14-15",
+		DescribeSets([]string{"Main Set", "Encore 1", "Encore 2", "Encore 3"}, []int{12, 1, 0, 2}),
+	)
+	s.Equal("", DescribeSets(nil, nil))
+}
@@ -12,7 +12,9 @@ type CLI struct {
 	RootCmd *cobra.Command
 }
 
-func NewCLI(rootCmd *cobra.Command) *CLI {
+func NewCLI(rootCmd *cobra.Command, subCmds ...*cobra.Command) *CLI {
+	rootCmd.AddCommand(subCmds...)
+
 	return &CLI{
 		RootCmd: rootCmd,
 	}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type CacheCmd struct {
+	Logger  logger.LoggerInterface
+	Gateway gateways.CacheCmdGatewayInterface
+}
+
+func NewCacheCmd(l logger.LoggerInterface, gw gateways.CacheCmdGatewayInterface) RootCmdInterface {
+	return &CacheCmd{
+		Logger:  l,
+		Gateway: gw,
+	}
+}
+
+func (cc *CacheCmd) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manages the local cache of Spotify matches",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "clear",
+			Short: "Removes every cached match",
+			RunE:  cc.clear,
+		},
+		&cobra.Command{
+			Use:   "stats",
+			Short: "Shows how many matches are cached",
+			RunE:  cc.stats,
+		},
+	)
+
+	return cmd
+}
+
+func (cc *CacheCmd) clear(cmd *cobra.Command, args []string) error {
+	cleared, err := cc.Gateway.ClearMatchCache()
+	if err != nil {
+		cc.Logger.Error("Failed to clear the match cache", err, nil)
+		return err
+	}
+
+	cc.Logger.Info(fmt.Sprintf("Match cache cleared, %d entries removed", cleared), nil)
+	return nil
+}
+
+func (cc *CacheCmd) stats(cmd *cobra.Command, args []string) error {
+	stats, err := cc.Gateway.GetMatchCacheStats()
+	if err != nil {
+		cc.Logger.Error("Failed to read the match cache", err, nil)
+		return err
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Entries:\t%d\n", stats.Entries)
+	fmt.Fprintf(w, "Matched:\t%d\n", stats.Matched)
+	fmt.Fprintf(w, "Expired:\t%d\n", stats.Expired)
+
+	if stats.Entries > 0 {
+		fmt.Fprintf(w, "Oldest:\t%s\n", stats.Oldest.Local().Format(time.DateTime))
+		fmt.Fprintf(w, "Newest:\t%s\n", stats.Newest.Local().Format(time.DateTime))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type CacheCmdTestSuite struct {
+	suite.Suite
+	LoggerMock          *mocks.LoggerMock
+	CacheCmdGatewayMock *mocks.CacheCmdGatewayMock
+
+	Cmd RootCmdInterface
+}
+
+func (s *CacheCmdTestSuite) SetupTest() {
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.CacheCmdGatewayMock = new(mocks.CacheCmdGatewayMock)
+
+	s.Cmd = NewCacheCmd(s.LoggerMock, s.CacheCmdGatewayMock)
+}
+
+func (s *CacheCmdTestSuite) cleanMocks() {
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+	s.CacheCmdGatewayMock.ExpectedCalls = nil
+	s.CacheCmdGatewayMock.Calls = nil
+}
+
+func TestCacheCmd(t *testing.T) {
+	suite.Run(t, new(CacheCmdTestSuite))
+}
+
+func (s *CacheCmdTestSuite) TestClear() {
+	s.Run("Should clear the cache", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.CacheCmdGatewayMock.On("ClearMatchCache").Return(12, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{"clear"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Info", "Match cache cleared, 12 entries removed", mock.Anything)
+	})
+
+	s.Run("Should return an error when clearing fails", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.CacheCmdGatewayMock.On("ClearMatchCache").Return(0, errors.New("any-error"))
+
+		cmd := s.Cmd.Build()
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{"clear"})
+
+		err := cmd.Execute()
+
+		s.ErrorContains(err, "any-error")
+	})
+}
+
+func (s *CacheCmdTestSuite) TestStats() {
+	s.Run("Should print the cache stats", func() {
+		defer s.cleanMocks()
+
+		s.CacheCmdGatewayMock.On("GetMatchCacheStats").Return(&cache.Stats{Entries: 0}, nil)
+
+		out := new(bytes.Buffer)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"stats"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.Contains(out.String(), "Entries:")
+		s.NotContains(out.String(), "Oldest:")
+	})
+}
@@ -0,0 +1,34 @@
+package gateways
+
+import (
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	cache_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/cache"
+)
+
+type CacheCmdGatewayInterface interface {
+	ClearMatchCache() (int, error)
+	GetMatchCacheStats() (*cache.Stats, error)
+}
+
+type CacheCmdGateway struct {
+	ClearMatchCacheUseCase    cache_ucs.ClearMatchCacheUseCaseInterface
+	GetMatchCacheStatsUseCase cache_ucs.GetMatchCacheStatsUseCaseInterface
+}
+
+func NewCacheCmdGateway(
+	clearMatchCacheUseCase cache_ucs.ClearMatchCacheUseCaseInterface,
+	getMatchCacheStatsUseCase cache_ucs.GetMatchCacheStatsUseCaseInterface,
+) CacheCmdGatewayInterface {
+	return &CacheCmdGateway{
+		ClearMatchCacheUseCase:    clearMatchCacheUseCase,
+		GetMatchCacheStatsUseCase: getMatchCacheStatsUseCase,
+	}
+}
+
+func (gw *CacheCmdGateway) ClearMatchCache() (int, error) {
+	return gw.ClearMatchCacheUseCase.Execute()
+}
+
+func (gw *CacheCmdGateway) GetMatchCacheStats() (*cache.Stats, error) {
+	return gw.GetMatchCacheStatsUseCase.Execute()
+}
@@ -0,0 +1,49 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type CacheCmdGatewayTestSuite struct {
+	suite.Suite
+	ClearMatchCacheUseCaseMock    *mocks.ClearMatchCacheUseCaseMock
+	GetMatchCacheStatsUseCaseMock *mocks.GetMatchCacheStatsUseCaseMock
+
+	Gateway CacheCmdGatewayInterface
+}
+
+func (s *CacheCmdGatewayTestSuite) SetupTest() {
+	s.ClearMatchCacheUseCaseMock = new(mocks.ClearMatchCacheUseCaseMock)
+	s.GetMatchCacheStatsUseCaseMock = new(mocks.GetMatchCacheStatsUseCaseMock)
+
+	s.Gateway = NewCacheCmdGateway(s.ClearMatchCacheUseCaseMock, s.GetMatchCacheStatsUseCaseMock)
+}
+
+func TestCacheCmdGateway(t *testing.T) {
+	suite.Run(t, new(CacheCmdGatewayTestSuite))
+}
+
+func (s *CacheCmdGatewayTestSuite) TestClearMatchCache() {
+	s.ClearMatchCacheUseCaseMock.On("Execute").Return(3, nil)
+
+	cleared, err := s.Gateway.ClearMatchCache()
+
+	s.NoError(err)
+	s.Equal(3, cleared)
+}
+
+func (s *CacheCmdGatewayTestSuite) TestGetMatchCacheStats() {
+	expected := &cache.Stats{Entries: 3}
+
+	s.GetMatchCacheStatsUseCaseMock.On("Execute").Return(expected, nil)
+
+	stats, err := s.Gateway.GetMatchCacheStats()
+
+	s.NoError(err)
+	s.Equal(expected, stats)
+}
@@ -0,0 +1,34 @@
+package gateways
+
+import (
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	history_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/history"
+)
+
+type HistoryCmdGatewayInterface interface {
+	ListHistory(filter history.Filter) ([]history.Entry, error)
+	GetSetlistHistory(setlistID string) ([]history.Entry, error)
+}
+
+type HistoryCmdGateway struct {
+	ListHistoryUseCase       history_ucs.ListHistoryUseCaseInterface
+	GetSetlistHistoryUseCase history_ucs.GetSetlistHistoryUseCaseInterface
+}
+
+func NewHistoryCmdGateway(
+	listHistoryUseCase history_ucs.ListHistoryUseCaseInterface,
+	getSetlistHistoryUseCase history_ucs.GetSetlistHistoryUseCaseInterface,
+) HistoryCmdGatewayInterface {
+	return &HistoryCmdGateway{
+		ListHistoryUseCase:       listHistoryUseCase,
+		GetSetlistHistoryUseCase: getSetlistHistoryUseCase,
+	}
+}
+
+func (gw *HistoryCmdGateway) ListHistory(filter history.Filter) ([]history.Entry, error) {
+	return gw.ListHistoryUseCase.Execute(filter)
+}
+
+func (gw *HistoryCmdGateway) GetSetlistHistory(setlistID string) ([]history.Entry, error) {
+	return gw.GetSetlistHistoryUseCase.Execute(setlistID)
+}
@@ -0,0 +1,52 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type HistoryCmdGatewayTestSuite struct {
+	suite.Suite
+	ListHistoryUseCaseMock       *mocks.ListHistoryUseCaseMock
+	GetSetlistHistoryUseCaseMock *mocks.GetSetlistHistoryUseCaseMock
+
+	Gateway HistoryCmdGatewayInterface
+}
+
+func (s *HistoryCmdGatewayTestSuite) SetupTest() {
+	s.ListHistoryUseCaseMock = new(mocks.ListHistoryUseCaseMock)
+	s.GetSetlistHistoryUseCaseMock = new(mocks.GetSetlistHistoryUseCaseMock)
+
+	s.Gateway = NewHistoryCmdGateway(s.ListHistoryUseCaseMock, s.GetSetlistHistoryUseCaseMock)
+}
+
+func TestHistoryCmdGateway(t *testing.T) {
+	suite.Run(t, new(HistoryCmdGatewayTestSuite))
+}
+
+func (s *HistoryCmdGatewayTestSuite) TestListHistory() {
+	filter := history.Filter{Artist: "blink"}
+	expected := []history.Entry{{SetlistID: "any-set-id"}}
+
+	s.ListHistoryUseCaseMock.On("Execute", filter).Return(expected, nil)
+
+	entries, err := s.Gateway.ListHistory(filter)
+
+	s.NoError(err)
+	s.Equal(expected, entries)
+}
+
+func (s *HistoryCmdGatewayTestSuite) TestGetSetlistHistory() {
+	expected := []history.Entry{{SetlistID: "any-set-id"}}
+
+	s.GetSetlistHistoryUseCaseMock.On("Execute", "any-set-id").Return(expected, nil)
+
+	entries, err := s.Gateway.GetSetlistHistory("any-set-id")
+
+	s.NoError(err)
+	s.Equal(expected, entries)
+}
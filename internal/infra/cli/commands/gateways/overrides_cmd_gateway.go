@@ -0,0 +1,51 @@
+package gateways
+
+import (
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	overrides_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/overrides"
+)
+
+type OverridesCmdGatewayInterface interface {
+	AddOverride(artist string, song string, track string) (*overrides.Override, error)
+	ListOverrides(artist string) ([]overrides.Override, error)
+	RemoveOverride(artist string, song string) error
+}
+
+type OverridesCmdGateway struct {
+	AddOverrideUseCase    overrides_ucs.AddOverrideUseCaseInterface
+	ListOverridesUseCase  overrides_ucs.ListOverridesUseCaseInterface
+	RemoveOverrideUseCase overrides_ucs.RemoveOverrideUseCaseInterface
+}
+
+func NewOverridesCmdGateway(
+	addOverrideUseCase overrides_ucs.AddOverrideUseCaseInterface,
+	listOverridesUseCase overrides_ucs.ListOverridesUseCaseInterface,
+	removeOverrideUseCase overrides_ucs.RemoveOverrideUseCaseInterface,
+) OverridesCmdGatewayInterface {
+	return &OverridesCmdGateway{
+		AddOverrideUseCase:    addOverrideUseCase,
+		ListOverridesUseCase:  listOverridesUseCase,
+		RemoveOverrideUseCase: removeOverrideUseCase,
+	}
+}
+
+func (gw *OverridesCmdGateway) AddOverride(artist string, song string, track string) (*overrides.Override, error) {
+	override, err := overrides.NewOverride(artist, song, track)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gw.AddOverrideUseCase.Execute(override); err != nil {
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (gw *OverridesCmdGateway) ListOverrides(artist string) ([]overrides.Override, error) {
+	return gw.ListOverridesUseCase.Execute(artist)
+}
+
+func (gw *OverridesCmdGateway) RemoveOverride(artist string, song string) error {
+	return gw.RemoveOverrideUseCase.Execute(artist, song)
+}
@@ -0,0 +1,109 @@
+package gateways
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type OverridesCmdGatewayTestSuite struct {
+	suite.Suite
+	AddOverrideUseCaseMock    *mocks.AddOverrideUseCaseMock
+	ListOverridesUseCaseMock  *mocks.ListOverridesUseCaseMock
+	RemoveOverrideUseCaseMock *mocks.RemoveOverrideUseCaseMock
+
+	Gateway OverridesCmdGatewayInterface
+}
+
+func (s *OverridesCmdGatewayTestSuite) SetupTest() {
+	s.AddOverrideUseCaseMock = new(mocks.AddOverrideUseCaseMock)
+	s.ListOverridesUseCaseMock = new(mocks.ListOverridesUseCaseMock)
+	s.RemoveOverrideUseCaseMock = new(mocks.RemoveOverrideUseCaseMock)
+
+	s.Gateway = NewOverridesCmdGateway(
+		s.AddOverrideUseCaseMock,
+		s.ListOverridesUseCaseMock,
+		s.RemoveOverrideUseCaseMock,
+	)
+}
+
+func (s *OverridesCmdGatewayTestSuite) cleanMocks() {
+	s.AddOverrideUseCaseMock.ExpectedCalls = nil
+	s.AddOverrideUseCaseMock.Calls = nil
+	s.ListOverridesUseCaseMock.ExpectedCalls = nil
+	s.ListOverridesUseCaseMock.Calls = nil
+	s.RemoveOverrideUseCaseMock.ExpectedCalls = nil
+	s.RemoveOverrideUseCaseMock.Calls = nil
+}
+
+func TestOverridesCmdGateway(t *testing.T) {
+	suite.Run(t, new(OverridesCmdGatewayTestSuite))
+}
+
+func (s *OverridesCmdGatewayTestSuite) TestAddOverride() {
+	s.Run("Should save the override with the parsed track ID", func() {
+		defer s.cleanMocks()
+
+		expected := overrides.Override{Artist: "blink-182", Song: "Dammit", Track: "4uLU6hMCjMI75M1A2tKUQC"}
+
+		s.AddOverrideUseCaseMock.On("Execute", expected).Return(nil)
+
+		out, err := s.Gateway.AddOverride("blink-182", "Dammit", "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC")
+
+		s.NoError(err)
+		s.Equal(&expected, out)
+	})
+
+	s.Run("Should return an error when the track is invalid", func() {
+		defer s.cleanMocks()
+
+		out, err := s.Gateway.AddOverride("blink-182", "Dammit", "not-a-track")
+
+		s.Error(err)
+		s.Nil(out)
+		s.AddOverrideUseCaseMock.AssertNotCalled(s.T(), "Execute", mock.Anything)
+	})
+
+	s.Run("Should return an error when saving fails", func() {
+		defer s.cleanMocks()
+
+		s.AddOverrideUseCaseMock.On("Execute", mock.Anything).Return(errors.New("any-error"))
+
+		out, err := s.Gateway.AddOverride("blink-182", "Dammit", "skip")
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
+
+func (s *OverridesCmdGatewayTestSuite) TestListOverrides() {
+	s.Run("Should list the overrides", func() {
+		defer s.cleanMocks()
+
+		expected := []overrides.Override{{Artist: "blink-182", Song: "Dammit", Track: "skip"}}
+
+		s.ListOverridesUseCaseMock.On("Execute", "blink-182").Return(expected, nil)
+
+		out, err := s.Gateway.ListOverrides("blink-182")
+
+		s.NoError(err)
+		s.Equal(expected, out)
+	})
+}
+
+func (s *OverridesCmdGatewayTestSuite) TestRemoveOverride() {
+	s.Run("Should remove the override", func() {
+		defer s.cleanMocks()
+
+		s.RemoveOverrideUseCaseMock.On("Execute", "blink-182", "Dammit").Return(nil)
+
+		err := s.Gateway.RemoveOverride("blink-182", "Dammit")
+
+		s.NoError(err)
+	})
+}
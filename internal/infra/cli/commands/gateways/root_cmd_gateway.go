@@ -4,11 +4,13 @@ import (
 	"context"
 
 	spotifyclient "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
 	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
 	spotify_entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
 	"github.com/mathcale/setlist-to-playlist/internal/infra/web"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
 	oauth2util "github.com/mathcale/setlist-to-playlist/internal/pkg/oauth2"
+	history_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/history"
 	setlistfm_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/setlistfm"
 	spotify_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/spotify"
 )
@@ -17,8 +19,28 @@ type RootCmdGatewayInterface interface {
 	GetTracksFromSetlist(setlistfmURL string) (*setlistfm.Set, error)
 	StartWebServer()
 	HandleSpotifyAuthentication(context.Context) error
-	FetchSongsOnSpotify(ctx context.Context, songTitles []string, artist string) (*spotify_entities.FindAllSongsOutput, error)
-	CreatePlaylistOnSpotify(ctx context.Context, playlistName string, songs []spotify_entities.Song) (*string, error)
+	RequireSpotifyScopes(scopes []string)
+	FetchSongsOnSpotify(ctx context.Context, input spotify_entities.FindAllSongsInput) (*spotify_entities.FindAllSongsOutput, error)
+	SearchTracksOnSpotify(ctx context.Context, query string) ([]spotify_entities.Song, error)
+	CreatePlaylistOnSpotify(
+		ctx context.Context,
+		input spotify_entities.CreatePlaylistInput,
+		songs []spotify_entities.Song,
+	) (*spotify_entities.CreatePlaylistOutput, error)
+	UpdatePlaylistOnSpotify(
+		ctx context.Context,
+		playlistID string,
+		songs []spotify_entities.Song,
+	) (*spotify_entities.CreatePlaylistOutput, error)
+	SyncPlaylistOnSpotify(
+		ctx context.Context,
+		playlistID string,
+		songs []spotify_entities.Song,
+	) (*spotify_entities.PlaylistDiff, error)
+	UploadPlaylistCover(ctx context.Context, playlistID string, image []byte) error
+	GetLinkedPlaylist(setlistID string) (*spotify_entities.PlaylistLink, error)
+	LinkPlaylist(setlistID string, playlist spotify_entities.CreatePlaylistOutput) error
+	RecordRun(entry history.Entry) error
 }
 
 type RootCmdGateway struct {
@@ -27,8 +49,15 @@ type RootCmdGateway struct {
 	SpotifyClient                     spotifyclient.SpotifyClientInterface
 	GetSetlistByIDUseCase             setlistfm_ucs.GetSetlistByIDUseCaseInterface
 	FetchSongsOnSpotifyUseCase        spotify_ucs.FetchSongsOnSpotifyUseCaseInterface
+	SearchTracksOnSpotifyUseCase      spotify_ucs.SearchTracksOnSpotifyUseCaseInterface
 	CreatePlaylistOnSpotifyUseCase    spotify_ucs.CreatePlaylistUseCaseInterface
 	AddTracksToSpotifyPlaylistUseCase spotify_ucs.AddTracksToPlaylistUseCaseInterface
+	UpdatePlaylistOnSpotifyUseCase    spotify_ucs.UpdatePlaylistUseCaseInterface
+	SyncPlaylistOnSpotifyUseCase      spotify_ucs.SyncPlaylistUseCaseInterface
+	UploadPlaylistCoverUseCase        spotify_ucs.UploadPlaylistCoverUseCaseInterface
+	GetLinkedPlaylistUseCase          spotify_ucs.GetLinkedPlaylistUseCaseInterface
+	LinkPlaylistUseCase               spotify_ucs.LinkPlaylistUseCaseInterface
+	RecordRunUseCase                  history_ucs.RecordRunUseCaseInterface
 	SpotifyUserAuthenticationUseCase  spotify_ucs.SpotifyUserAuthenticationUseCaseInterface
 	GeneratedPKCECodes                oauth2util.GenerateOutput
 	State                             string
@@ -41,8 +70,15 @@ func NewRootCmdGateway(
 	spotifyClient spotifyclient.SpotifyClientInterface,
 	getSetlistByIDUseCase setlistfm_ucs.GetSetlistByIDUseCaseInterface,
 	fetchSongsOnSpotifyUseCase spotify_ucs.FetchSongsOnSpotifyUseCaseInterface,
+	searchTracksOnSpotifyUseCase spotify_ucs.SearchTracksOnSpotifyUseCaseInterface,
 	createPlaylistOnSpotifyUseCase spotify_ucs.CreatePlaylistUseCaseInterface,
 	addTracksToSpotifyPlaylistUseCase spotify_ucs.AddTracksToPlaylistUseCaseInterface,
+	updatePlaylistOnSpotifyUseCase spotify_ucs.UpdatePlaylistUseCaseInterface,
+	syncPlaylistOnSpotifyUseCase spotify_ucs.SyncPlaylistUseCaseInterface,
+	uploadPlaylistCoverUseCase spotify_ucs.UploadPlaylistCoverUseCaseInterface,
+	getLinkedPlaylistUseCase spotify_ucs.GetLinkedPlaylistUseCaseInterface,
+	linkPlaylistUseCase spotify_ucs.LinkPlaylistUseCaseInterface,
+	recordRunUseCase history_ucs.RecordRunUseCaseInterface,
 	spotifyUserAuthenticationUseCase spotify_ucs.SpotifyUserAuthenticationUseCaseInterface,
 	genCodes oauth2util.GenerateOutput,
 	state string,
@@ -54,8 +90,15 @@ func NewRootCmdGateway(
 		SpotifyClient:                     spotifyClient,
 		GetSetlistByIDUseCase:             getSetlistByIDUseCase,
 		FetchSongsOnSpotifyUseCase:        fetchSongsOnSpotifyUseCase,
+		SearchTracksOnSpotifyUseCase:      searchTracksOnSpotifyUseCase,
 		CreatePlaylistOnSpotifyUseCase:    createPlaylistOnSpotifyUseCase,
 		AddTracksToSpotifyPlaylistUseCase: addTracksToSpotifyPlaylistUseCase,
+		UpdatePlaylistOnSpotifyUseCase:    updatePlaylistOnSpotifyUseCase,
+		SyncPlaylistOnSpotifyUseCase:      syncPlaylistOnSpotifyUseCase,
+		UploadPlaylistCoverUseCase:        uploadPlaylistCoverUseCase,
+		GetLinkedPlaylistUseCase:          getLinkedPlaylistUseCase,
+		LinkPlaylistUseCase:               linkPlaylistUseCase,
+		RecordRunUseCase:                  recordRunUseCase,
 		SpotifyUserAuthenticationUseCase:  spotifyUserAuthenticationUseCase,
 		GeneratedPKCECodes:                genCodes,
 		State:                             state,
@@ -90,26 +133,31 @@ func (gw *RootCmdGateway) HandleSpotifyAuthentication(ctx context.Context) error
 	return gw.SpotifyUserAuthenticationUseCase.Execute(ctx, gw.GeneratedPKCECodes, gw.State)
 }
 
+// RequireSpotifyScopes must be called before HandleSpotifyAuthentication, so a saved token without them is replaced.
+func (gw *RootCmdGateway) RequireSpotifyScopes(scopes []string) {
+	gw.SpotifyClient.RequireScopes(scopes...)
+}
+
 func (gw *RootCmdGateway) FetchSongsOnSpotify(
 	ctx context.Context,
-	songTitles []string,
-	artist string,
+	input spotify_entities.FindAllSongsInput,
 ) (*spotify_entities.FindAllSongsOutput, error) {
-	return gw.FetchSongsOnSpotifyUseCase.Execute(ctx, songTitles, artist)
+	return gw.FetchSongsOnSpotifyUseCase.Execute(ctx, input)
+}
+
+func (gw *RootCmdGateway) SearchTracksOnSpotify(
+	ctx context.Context,
+	query string,
+) ([]spotify_entities.Song, error) {
+	return gw.SearchTracksOnSpotifyUseCase.Execute(ctx, query)
 }
 
 func (gw *RootCmdGateway) CreatePlaylistOnSpotify(
 	ctx context.Context,
-	playlistName string,
+	input spotify_entities.CreatePlaylistInput,
 	songs []spotify_entities.Song,
-) (*string, error) {
-	createPlaylistOut, err := gw.CreatePlaylistOnSpotifyUseCase.Execute(
-		ctx,
-		spotify_entities.CreatePlaylistInput{
-			Title:       playlistName,
-			Description: nil,
-		},
-	)
+) (*spotify_entities.CreatePlaylistOutput, error) {
+	createPlaylistOut, err := gw.CreatePlaylistOnSpotifyUseCase.Execute(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +167,7 @@ func (gw *RootCmdGateway) CreatePlaylistOnSpotify(
 		"playlistURL": createPlaylistOut.URL,
 	})
 
+	// The playlist exists even if adding its tracks failed, so it is returned along with the error.
 	if err := gw.AddTracksToSpotifyPlaylistUseCase.Execute(
 		ctx,
 		spotify_entities.AddTracksToPlaylistInput{
@@ -126,8 +175,49 @@ func (gw *RootCmdGateway) CreatePlaylistOnSpotify(
 			Tracks:     songs,
 		},
 	); err != nil {
-		return nil, err
+		return createPlaylistOut, err
 	}
 
-	return &createPlaylistOut.URL, nil
+	return createPlaylistOut, nil
+}
+
+func (gw *RootCmdGateway) UpdatePlaylistOnSpotify(
+	ctx context.Context,
+	playlistID string,
+	songs []spotify_entities.Song,
+) (*spotify_entities.CreatePlaylistOutput, error) {
+	return gw.UpdatePlaylistOnSpotifyUseCase.Execute(ctx, spotify_entities.UpdatePlaylistInput{
+		PlaylistID: playlistID,
+		Tracks:     songs,
+	})
+}
+
+func (gw *RootCmdGateway) SyncPlaylistOnSpotify(
+	ctx context.Context,
+	playlistID string,
+	songs []spotify_entities.Song,
+) (*spotify_entities.PlaylistDiff, error) {
+	return gw.SyncPlaylistOnSpotifyUseCase.Execute(ctx, spotify_entities.SyncPlaylistInput{
+		PlaylistID: playlistID,
+		Tracks:     songs,
+	})
+}
+
+func (gw *RootCmdGateway) UploadPlaylistCover(ctx context.Context, playlistID string, image []byte) error {
+	return gw.UploadPlaylistCoverUseCase.Execute(ctx, spotify_entities.UploadPlaylistCoverInput{
+		PlaylistID: playlistID,
+		Image:      image,
+	})
+}
+
+func (gw *RootCmdGateway) GetLinkedPlaylist(setlistID string) (*spotify_entities.PlaylistLink, error) {
+	return gw.GetLinkedPlaylistUseCase.Execute(setlistID)
+}
+
+func (gw *RootCmdGateway) LinkPlaylist(setlistID string, playlist spotify_entities.CreatePlaylistOutput) error {
+	return gw.LinkPlaylistUseCase.Execute(setlistID, playlist)
+}
+
+func (gw *RootCmdGateway) RecordRun(entry history.Entry) error {
+	return gw.RecordRunUseCase.Execute(entry)
 }
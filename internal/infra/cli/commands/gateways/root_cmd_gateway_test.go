@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	spotifyclient "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
 	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
 	spotifyentities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
 	oauth2util "github.com/mathcale/setlist-to-playlist/internal/pkg/oauth2"
@@ -22,8 +23,15 @@ type RootCmdGatewayTestSuite struct {
 	SpotifyClientMock                     *mocks.SpotifyClientMock
 	GetSetlistByIDUseCaseMock             *mocks.SetlistFMGetSetlistByIDUseCaseMock
 	FetchSongsOnSpotifyUseCaseMock        *mocks.FetchSongsOnSpotifyUseCaseMock
+	SearchTracksOnSpotifyUseCaseMock      *mocks.SearchTracksOnSpotifyUseCaseMock
 	CreatePlaylistOnSpotifyUseCaseMock    *mocks.CreatePlaylistOnSpotifyUseCaseMock
 	AddTracksToSpotifyPlaylistUseCaseMock *mocks.AddTracksToSpotifyPlaylistUseCaseMock
+	UpdatePlaylistOnSpotifyUseCaseMock    *mocks.UpdatePlaylistUseCaseMock
+	SyncPlaylistOnSpotifyUseCaseMock      *mocks.SyncPlaylistUseCaseMock
+	UploadPlaylistCoverUseCaseMock        *mocks.UploadPlaylistCoverUseCaseMock
+	GetLinkedPlaylistUseCaseMock          *mocks.GetLinkedPlaylistUseCaseMock
+	LinkPlaylistUseCaseMock               *mocks.LinkPlaylistUseCaseMock
+	RecordRunUseCaseMock                  *mocks.RecordRunUseCaseMock
 	SpotifyUserAuthenticationUseCaseMock  *mocks.SpotifyUserAuthenticationUseCaseMock
 	GeneratedPKCECodes                    oauth2util.GenerateOutput
 	State                                 string
@@ -38,8 +46,15 @@ func (s *RootCmdGatewayTestSuite) SetupTest() {
 	s.SpotifyClientMock = new(mocks.SpotifyClientMock)
 	s.GetSetlistByIDUseCaseMock = new(mocks.SetlistFMGetSetlistByIDUseCaseMock)
 	s.FetchSongsOnSpotifyUseCaseMock = new(mocks.FetchSongsOnSpotifyUseCaseMock)
+	s.SearchTracksOnSpotifyUseCaseMock = new(mocks.SearchTracksOnSpotifyUseCaseMock)
 	s.CreatePlaylistOnSpotifyUseCaseMock = new(mocks.CreatePlaylistOnSpotifyUseCaseMock)
 	s.AddTracksToSpotifyPlaylistUseCaseMock = new(mocks.AddTracksToSpotifyPlaylistUseCaseMock)
+	s.UpdatePlaylistOnSpotifyUseCaseMock = new(mocks.UpdatePlaylistUseCaseMock)
+	s.SyncPlaylistOnSpotifyUseCaseMock = new(mocks.SyncPlaylistUseCaseMock)
+	s.UploadPlaylistCoverUseCaseMock = new(mocks.UploadPlaylistCoverUseCaseMock)
+	s.GetLinkedPlaylistUseCaseMock = new(mocks.GetLinkedPlaylistUseCaseMock)
+	s.LinkPlaylistUseCaseMock = new(mocks.LinkPlaylistUseCaseMock)
+	s.RecordRunUseCaseMock = new(mocks.RecordRunUseCaseMock)
 	s.SpotifyUserAuthenticationUseCaseMock = new(mocks.SpotifyUserAuthenticationUseCaseMock)
 	s.GeneratedPKCECodes = oauth2util.GenerateOutput{
 		CodeChallenge: "any-code-challenge",
@@ -54,8 +69,15 @@ func (s *RootCmdGatewayTestSuite) SetupTest() {
 		s.SpotifyClientMock,
 		s.GetSetlistByIDUseCaseMock,
 		s.FetchSongsOnSpotifyUseCaseMock,
+		s.SearchTracksOnSpotifyUseCaseMock,
 		s.CreatePlaylistOnSpotifyUseCaseMock,
 		s.AddTracksToSpotifyPlaylistUseCaseMock,
+		s.UpdatePlaylistOnSpotifyUseCaseMock,
+		s.SyncPlaylistOnSpotifyUseCaseMock,
+		s.UploadPlaylistCoverUseCaseMock,
+		s.GetLinkedPlaylistUseCaseMock,
+		s.LinkPlaylistUseCaseMock,
+		s.RecordRunUseCaseMock,
 		s.SpotifyUserAuthenticationUseCaseMock,
 		s.GeneratedPKCECodes,
 		s.State,
@@ -74,10 +96,24 @@ func (s *RootCmdGatewayTestSuite) cleanMocks() {
 	s.GetSetlistByIDUseCaseMock.Calls = nil
 	s.FetchSongsOnSpotifyUseCaseMock.ExpectedCalls = nil
 	s.FetchSongsOnSpotifyUseCaseMock.Calls = nil
+	s.SearchTracksOnSpotifyUseCaseMock.ExpectedCalls = nil
+	s.SearchTracksOnSpotifyUseCaseMock.Calls = nil
 	s.CreatePlaylistOnSpotifyUseCaseMock.ExpectedCalls = nil
 	s.CreatePlaylistOnSpotifyUseCaseMock.Calls = nil
 	s.AddTracksToSpotifyPlaylistUseCaseMock.ExpectedCalls = nil
 	s.AddTracksToSpotifyPlaylistUseCaseMock.Calls = nil
+	s.UpdatePlaylistOnSpotifyUseCaseMock.ExpectedCalls = nil
+	s.UpdatePlaylistOnSpotifyUseCaseMock.Calls = nil
+	s.SyncPlaylistOnSpotifyUseCaseMock.ExpectedCalls = nil
+	s.SyncPlaylistOnSpotifyUseCaseMock.Calls = nil
+	s.UploadPlaylistCoverUseCaseMock.ExpectedCalls = nil
+	s.UploadPlaylistCoverUseCaseMock.Calls = nil
+	s.GetLinkedPlaylistUseCaseMock.ExpectedCalls = nil
+	s.GetLinkedPlaylistUseCaseMock.Calls = nil
+	s.LinkPlaylistUseCaseMock.ExpectedCalls = nil
+	s.LinkPlaylistUseCaseMock.Calls = nil
+	s.RecordRunUseCaseMock.ExpectedCalls = nil
+	s.RecordRunUseCaseMock.Calls = nil
 	s.SpotifyUserAuthenticationUseCaseMock.ExpectedCalls = nil
 	s.SpotifyUserAuthenticationUseCaseMock.Calls = nil
 }
@@ -198,13 +234,16 @@ func (s *RootCmdGatewayTestSuite) TestFetchSongsOnSpotify() {
 			},
 		}
 
-		songs := []string{"any-song-1", "any-song-2", "any-song-3"}
+		input := spotifyentities.FindAllSongsInput{
+			Songs:  []spotifyentities.SongQuery{{Title: "any-song-1"}, {Title: "any-song-2"}, {Title: "any-song-3"}},
+			Artist: "any-artist",
+		}
 
 		s.FetchSongsOnSpotifyUseCaseMock.
-			On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			On("Execute", mock.Anything, input).
 			Return(expected, nil)
 
-		result, err := s.Gateway.FetchSongsOnSpotify(context.Background(), songs, "any-artist")
+		result, err := s.Gateway.FetchSongsOnSpotify(context.Background(), input)
 
 		s.NoError(err)
 		s.Equal(expected, result)
@@ -213,18 +252,66 @@ func (s *RootCmdGatewayTestSuite) TestFetchSongsOnSpotify() {
 	s.Run("Should return an error when failing to fetch songs from Spotify", func() {
 		defer s.cleanMocks()
 
-		songs := []string{"any-song-1", "any-song-2", "any-song-3"}
+		input := spotifyentities.FindAllSongsInput{
+			Songs:  []spotifyentities.SongQuery{{Title: "any-song-1"}, {Title: "any-song-2"}, {Title: "any-song-3"}},
+			Artist: "any-artist",
+		}
 
 		s.FetchSongsOnSpotifyUseCaseMock.
-			On("Execute", mock.Anything, mock.Anything, mock.Anything).
+			On("Execute", mock.Anything, input).
+			Return(nil, errors.New("any-error"))
+
+		_, err := s.Gateway.FetchSongsOnSpotify(context.Background(), input)
+
+		s.Error(err)
+	})
+}
+
+func (s *RootCmdGatewayTestSuite) TestSearchTracksOnSpotify() {
+	s.Run("Should search tracks on Spotify", func() {
+		defer s.cleanMocks()
+
+		expected := []spotifyentities.Song{
+			{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+		}
+
+		s.SearchTracksOnSpotifyUseCaseMock.
+			On("Execute", mock.Anything, "any-query").
+			Return(expected, nil)
+
+		result, err := s.Gateway.SearchTracksOnSpotify(context.Background(), "any-query")
+
+		s.NoError(err)
+		s.Equal(expected, result)
+	})
+
+	s.Run("Should return an error when failing to search tracks on Spotify", func() {
+		defer s.cleanMocks()
+
+		s.SearchTracksOnSpotifyUseCaseMock.
+			On("Execute", mock.Anything, "any-query").
 			Return(nil, errors.New("any-error"))
 
-		_, err := s.Gateway.FetchSongsOnSpotify(context.Background(), songs, "any-artist")
+		_, err := s.Gateway.SearchTracksOnSpotify(context.Background(), "any-query")
 
 		s.Error(err)
 	})
 }
 
+func (s *RootCmdGatewayTestSuite) TestRequireSpotifyScopes() {
+	s.Run("Should ask the Spotify client for the scopes", func() {
+		defer s.cleanMocks()
+
+		scopes := []string{spotifyentities.ScopePlaylistModifyPrivate}
+
+		s.SpotifyClientMock.On("RequireScopes", scopes).Return()
+
+		s.Gateway.RequireSpotifyScopes(scopes)
+
+		s.SpotifyClientMock.AssertExpectations(s.T())
+	})
+}
+
 func (s *RootCmdGatewayTestSuite) TestCreatePlaylistOnSpotify() {
 	s.Run("Should create a playlist on Spotify", func() {
 		defer s.cleanMocks()
@@ -242,19 +329,24 @@ func (s *RootCmdGatewayTestSuite) TestCreatePlaylistOnSpotify() {
 			{ID: "any-song-id-3", Title: "any-song-3", Album: "any-album-1"},
 		}
 
+		input := spotifyentities.CreatePlaylistInput{
+			Title:      "any-playlist-name",
+			Visibility: spotifyentities.NewPlaylistVisibility(true, false),
+		}
+
 		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return(nil)
 		s.CreatePlaylistOnSpotifyUseCaseMock.
-			On("Execute", mock.Anything, mock.Anything).
+			On("Execute", mock.Anything, input).
 			Return(expected, nil)
 
 		s.AddTracksToSpotifyPlaylistUseCaseMock.
 			On("Execute", mock.Anything, mock.Anything).
 			Return(nil)
 
-		result, err := s.Gateway.CreatePlaylistOnSpotify(ctx, "any-playlist-name", songs)
+		result, err := s.Gateway.CreatePlaylistOnSpotify(ctx, input, songs)
 
 		s.NoError(err)
-		s.Equal(expected.URL, *result)
+		s.Equal(expected, result)
 	})
 
 	s.Run("Should return an error when failing to create a playlist on Spotify", func() {
@@ -272,7 +364,11 @@ func (s *RootCmdGatewayTestSuite) TestCreatePlaylistOnSpotify() {
 			On("Execute", mock.Anything, mock.Anything).
 			Return(nil, errors.New("any-error"))
 
-		_, err := s.Gateway.CreatePlaylistOnSpotify(ctx, "any-playlist-name", songs)
+		_, err := s.Gateway.CreatePlaylistOnSpotify(
+			ctx,
+			spotifyentities.CreatePlaylistInput{Title: "any-playlist-name"},
+			songs,
+		)
 
 		s.Error(err)
 		s.ErrorContains(err, "any-error")
@@ -303,9 +399,115 @@ func (s *RootCmdGatewayTestSuite) TestCreatePlaylistOnSpotify() {
 			On("Execute", mock.Anything, mock.Anything).
 			Return(errors.New("any-error"))
 
-		_, err := s.Gateway.CreatePlaylistOnSpotify(ctx, "any-playlist-name", songs)
+		result, err := s.Gateway.CreatePlaylistOnSpotify(
+			ctx,
+			spotifyentities.CreatePlaylistInput{Title: "any-playlist-name"},
+			songs,
+		)
 
 		s.Error(err)
 		s.ErrorContains(err, "any-error")
+		s.Equal(expected, result)
+	})
+}
+
+func (s *RootCmdGatewayTestSuite) TestUpdatePlaylistOnSpotify() {
+	s.Run("Should replace the tracks of a playlist", func() {
+		defer s.cleanMocks()
+
+		expected := &spotifyentities.CreatePlaylistOutput{
+			ID:  "any-playlist-id",
+			URL: "https://open.spotify.com/playlist/any-playlist-id",
+		}
+
+		songs := []spotifyentities.Song{
+			{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+		}
+
+		s.UpdatePlaylistOnSpotifyUseCaseMock.
+			On("Execute", mock.Anything, spotifyentities.UpdatePlaylistInput{PlaylistID: "any-playlist-id", Tracks: songs}).
+			Return(expected, nil)
+
+		result, err := s.Gateway.UpdatePlaylistOnSpotify(context.Background(), "any-playlist-id", songs)
+
+		s.NoError(err)
+		s.Equal(expected, result)
+	})
+}
+
+func (s *RootCmdGatewayTestSuite) TestPlaylistLinks() {
+	s.Run("Should get the playlist linked to a setlist", func() {
+		defer s.cleanMocks()
+
+		expected := &spotifyentities.PlaylistLink{SetlistID: "any-set-id", PlaylistID: "any-playlist-id"}
+
+		s.GetLinkedPlaylistUseCaseMock.On("Execute", "any-set-id").Return(expected, nil)
+
+		result, err := s.Gateway.GetLinkedPlaylist("any-set-id")
+
+		s.NoError(err)
+		s.Equal(expected, result)
+	})
+
+	s.Run("Should link a playlist to a setlist", func() {
+		defer s.cleanMocks()
+
+		playlist := spotifyentities.CreatePlaylistOutput{ID: "any-playlist-id"}
+
+		s.LinkPlaylistUseCaseMock.On("Execute", "any-set-id", playlist).Return(nil)
+
+		err := s.Gateway.LinkPlaylist("any-set-id", playlist)
+
+		s.NoError(err)
+	})
+}
+
+func (s *RootCmdGatewayTestSuite) TestRecordRun() {
+	s.Run("Should record the run in the history", func() {
+		defer s.cleanMocks()
+
+		entry := history.Entry{SetlistID: "any-set-id", PlaylistID: "any-playlist-id"}
+
+		s.RecordRunUseCaseMock.On("Execute", entry).Return(nil)
+
+		err := s.Gateway.RecordRun(entry)
+
+		s.NoError(err)
+		s.RecordRunUseCaseMock.AssertExpectations(s.T())
+	})
+}
+
+func (s *RootCmdGatewayTestSuite) TestSyncPlaylistOnSpotify() {
+	s.Run("Should sync the playlist tracks", func() {
+		defer s.cleanMocks()
+
+		songs := []spotifyentities.Song{{ID: "any-song-id"}}
+		expected := &spotifyentities.PlaylistDiff{Added: songs}
+
+		s.SyncPlaylistOnSpotifyUseCaseMock.
+			On("Execute", mock.Anything, spotifyentities.SyncPlaylistInput{PlaylistID: "any-playlist-id", Tracks: songs}).
+			Return(expected, nil)
+
+		diff, err := s.Gateway.SyncPlaylistOnSpotify(context.Background(), "any-playlist-id", songs)
+
+		s.NoError(err)
+		s.Equal(expected, diff)
+	})
+}
+
+func (s *RootCmdGatewayTestSuite) TestUploadPlaylistCover() {
+	s.Run("Should upload the playlist cover", func() {
+		defer s.cleanMocks()
+
+		image := []byte("any-jpeg")
+
+		s.UploadPlaylistCoverUseCaseMock.
+			On("Execute", mock.Anything, spotifyentities.UploadPlaylistCoverInput{PlaylistID: "any-playlist-id", Image: image}).
+			Return(nil)
+
+		err := s.Gateway.UploadPlaylistCover(context.Background(), "any-playlist-id", image)
+
+		s.NoError(err)
+		s.UploadPlaylistCoverUseCaseMock.AssertExpectations(s.T())
 	})
 }
@@ -0,0 +1,58 @@
+package gateways
+
+import (
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	setlistfm_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/setlistfm"
+)
+
+type SetlistFMCmdGatewayInterface interface {
+	FindArtist(name string) (*setlistfm.Artist, error)
+	GetTourSetlists(input setlistfm.GetTourSetlistsInput) (setlistfm.Shows, error)
+	SearchSetlists(input setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error)
+	GetLatestSetlist(input setlistfm.GetLatestSetlistInput) (*setlistfm.Set, error)
+	GetFestivalSetlists(input setlistfm.GetFestivalSetlistsInput) (*setlistfm.Festival, error)
+}
+
+type SetlistFMCmdGateway struct {
+	FindArtistUseCase          setlistfm_ucs.FindArtistUseCaseInterface
+	GetTourSetlistsUseCase     setlistfm_ucs.GetTourSetlistsUseCaseInterface
+	SearchSetlistsUseCase      setlistfm_ucs.SearchSetlistsUseCaseInterface
+	GetLatestSetlistUseCase    setlistfm_ucs.GetLatestSetlistUseCaseInterface
+	GetFestivalSetlistsUseCase setlistfm_ucs.GetFestivalSetlistsUseCaseInterface
+}
+
+func NewSetlistFMCmdGateway(
+	findArtistUseCase setlistfm_ucs.FindArtistUseCaseInterface,
+	getTourSetlistsUseCase setlistfm_ucs.GetTourSetlistsUseCaseInterface,
+	searchSetlistsUseCase setlistfm_ucs.SearchSetlistsUseCaseInterface,
+	getLatestSetlistUseCase setlistfm_ucs.GetLatestSetlistUseCaseInterface,
+	getFestivalSetlistsUseCase setlistfm_ucs.GetFestivalSetlistsUseCaseInterface,
+) SetlistFMCmdGatewayInterface {
+	return &SetlistFMCmdGateway{
+		FindArtistUseCase:          findArtistUseCase,
+		GetTourSetlistsUseCase:     getTourSetlistsUseCase,
+		SearchSetlistsUseCase:      searchSetlistsUseCase,
+		GetLatestSetlistUseCase:    getLatestSetlistUseCase,
+		GetFestivalSetlistsUseCase: getFestivalSetlistsUseCase,
+	}
+}
+
+func (gw *SetlistFMCmdGateway) FindArtist(name string) (*setlistfm.Artist, error) {
+	return gw.FindArtistUseCase.Execute(name)
+}
+
+func (gw *SetlistFMCmdGateway) GetTourSetlists(input setlistfm.GetTourSetlistsInput) (setlistfm.Shows, error) {
+	return gw.GetTourSetlistsUseCase.Execute(input)
+}
+
+func (gw *SetlistFMCmdGateway) SearchSetlists(input setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error) {
+	return gw.SearchSetlistsUseCase.Execute(input)
+}
+
+func (gw *SetlistFMCmdGateway) GetLatestSetlist(input setlistfm.GetLatestSetlistInput) (*setlistfm.Set, error) {
+	return gw.GetLatestSetlistUseCase.Execute(input)
+}
+
+func (gw *SetlistFMCmdGateway) GetFestivalSetlists(input setlistfm.GetFestivalSetlistsInput) (*setlistfm.Festival, error) {
+	return gw.GetFestivalSetlistsUseCase.Execute(input)
+}
@@ -0,0 +1,100 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SetlistFMCmdGatewayTestSuite struct {
+	suite.Suite
+	FindArtistUseCaseMock          *mocks.FindArtistUseCaseMock
+	GetTourSetlistsUseCaseMock     *mocks.GetTourSetlistsUseCaseMock
+	SearchSetlistsUseCaseMock      *mocks.SearchSetlistsUseCaseMock
+	GetLatestSetlistUseCaseMock    *mocks.GetLatestSetlistUseCaseMock
+	GetFestivalSetlistsUseCaseMock *mocks.GetFestivalSetlistsUseCaseMock
+
+	Gateway SetlistFMCmdGatewayInterface
+}
+
+func (s *SetlistFMCmdGatewayTestSuite) SetupTest() {
+	s.FindArtistUseCaseMock = new(mocks.FindArtistUseCaseMock)
+	s.GetTourSetlistsUseCaseMock = new(mocks.GetTourSetlistsUseCaseMock)
+	s.SearchSetlistsUseCaseMock = new(mocks.SearchSetlistsUseCaseMock)
+	s.GetLatestSetlistUseCaseMock = new(mocks.GetLatestSetlistUseCaseMock)
+	s.GetFestivalSetlistsUseCaseMock = new(mocks.GetFestivalSetlistsUseCaseMock)
+
+	s.Gateway = NewSetlistFMCmdGateway(
+		s.FindArtistUseCaseMock,
+		s.GetTourSetlistsUseCaseMock,
+		s.SearchSetlistsUseCaseMock,
+		s.GetLatestSetlistUseCaseMock,
+		s.GetFestivalSetlistsUseCaseMock,
+	)
+}
+
+func TestSetlistFMCmdGateway(t *testing.T) {
+	suite.Run(t, new(SetlistFMCmdGatewayTestSuite))
+}
+
+func (s *SetlistFMCmdGatewayTestSuite) TestFindArtist() {
+	expected := &setlistfm.Artist{MBID: "any-mbid", Name: "any-artist"}
+
+	s.FindArtistUseCaseMock.On("Execute", "any-artist").Return(expected, nil)
+
+	artist, err := s.Gateway.FindArtist("any-artist")
+
+	s.NoError(err)
+	s.Equal(expected, artist)
+}
+
+func (s *SetlistFMCmdGatewayTestSuite) TestGetTourSetlists() {
+	in := setlistfm.GetTourSetlistsInput{ArtistMBID: "any-mbid", Tour: "any-tour"}
+	expected := setlistfm.Shows{{ID: "any-set-id"}}
+
+	s.GetTourSetlistsUseCaseMock.On("Execute", in).Return(expected, nil)
+
+	shows, err := s.Gateway.GetTourSetlists(in)
+
+	s.NoError(err)
+	s.Equal(expected, shows)
+}
+
+func (s *SetlistFMCmdGatewayTestSuite) TestSearchSetlists() {
+	in := setlistfm.SearchSetlistsInput{ArtistName: "any-artist", Page: 2}
+	expected := &setlistfm.SetlistPage{Page: 2}
+
+	s.SearchSetlistsUseCaseMock.On("Execute", in).Return(expected, nil)
+
+	page, err := s.Gateway.SearchSetlists(in)
+
+	s.NoError(err)
+	s.Equal(expected, page)
+}
+
+func (s *SetlistFMCmdGatewayTestSuite) TestGetLatestSetlist() {
+	in := setlistfm.GetLatestSetlistInput{ArtistMBID: "any-mbid", MaxPages: 3}
+	expected := &setlistfm.Set{ID: "any-set-id"}
+
+	s.GetLatestSetlistUseCaseMock.On("Execute", in).Return(expected, nil)
+
+	set, err := s.Gateway.GetLatestSetlist(in)
+
+	s.NoError(err)
+	s.Equal(expected, set)
+}
+
+func (s *SetlistFMCmdGatewayTestSuite) TestGetFestivalSetlists() {
+	in := setlistfm.GetFestivalSetlistsInput{URL: "any-festival-url"}
+	expected := &setlistfm.Festival{ID: "any-festival-id"}
+
+	s.GetFestivalSetlistsUseCaseMock.On("Execute", in).Return(expected, nil)
+
+	festival, err := s.Gateway.GetFestivalSetlists(in)
+
+	s.NoError(err)
+	s.Equal(expected, festival)
+}
@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+const historyDateLayout = time.DateOnly
+
+type HistoryCmd struct {
+	Logger  logger.LoggerInterface
+	Gateway gateways.HistoryCmdGatewayInterface
+}
+
+func NewHistoryCmd(l logger.LoggerInterface, gw gateways.HistoryCmdGatewayInterface) RootCmdInterface {
+	return &HistoryCmd{
+		Logger:  l,
+		Gateway: gw,
+	}
+}
+
+func (hc *HistoryCmd) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Shows the playlists generated so far",
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "Lists every run, newest first",
+		RunE:  hc.list,
+	}
+
+	list.Flags().Int("limit", 0, "only list this many runs")
+	list.Flags().Bool("latest", false, "only list the newest run of every setlist")
+
+	show := &cobra.Command{
+		Use:   "show <setlist-id>",
+		Short: "Shows every run of a setlist",
+		Args:  cobra.ExactArgs(1),
+		RunE:  hc.show,
+	}
+
+	filter := &cobra.Command{
+		Use:   "filter",
+		Short: "Lists the runs of an artist or of shows in a date range",
+		RunE:  hc.filter,
+	}
+
+	filter.Flags().String("artist", "", "only list runs of artists whose name contains this")
+	filter.Flags().String("from", "", "only list shows on or after this date (YYYY-MM-DD)")
+	filter.Flags().String("to", "", "only list shows on or before this date (YYYY-MM-DD)")
+	filter.Flags().Int("limit", 0, "only list this many runs")
+
+	cmd.AddCommand(list, show, filter)
+
+	return cmd
+}
+
+func (hc *HistoryCmd) list(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	latest, _ := cmd.Flags().GetBool("latest")
+
+	return hc.printEntries(cmd, history.Filter{Limit: limit, LatestOnly: latest})
+}
+
+func (hc *HistoryCmd) filter(cmd *cobra.Command, args []string) error {
+	artist, _ := cmd.Flags().GetString("artist")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	f := history.Filter{Artist: artist, Limit: limit}
+
+	var err error
+
+	if from != "" {
+		if f.From, err = time.Parse(historyDateLayout, from); err != nil {
+			err = fmt.Errorf("invalid --from date %q, expected YYYY-MM-DD", from)
+			hc.Logger.Error("Invalid filter", err, nil)
+			return err
+		}
+	}
+
+	if to != "" {
+		if f.To, err = time.Parse(historyDateLayout, to); err != nil {
+			err = fmt.Errorf("invalid --to date %q, expected YYYY-MM-DD", to)
+			hc.Logger.Error("Invalid filter", err, nil)
+			return err
+		}
+	}
+
+	return hc.printEntries(cmd, f)
+}
+
+func (hc *HistoryCmd) printEntries(cmd *cobra.Command, f history.Filter) error {
+	entries, err := hc.Gateway.ListHistory(f)
+	if err != nil {
+		hc.Logger.Error("Failed to read the history", err, nil)
+		return err
+	}
+
+	if len(entries) == 0 {
+		hc.Logger.Info("No runs found", nil)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WHEN\tSETLIST\tARTIST\tSHOW DATE\tACTION\tTRACKS\tPLAYLIST")
+
+	for _, e := range entries {
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%d/%d\t%s\n",
+			e.CreatedAt.Local().Format(time.DateTime),
+			e.SetlistID,
+			e.Artist,
+			formatEventDate(e),
+			e.Action,
+			e.Matched,
+			e.Matched+e.Unmatched,
+			e.PlaylistURL,
+		)
+	}
+
+	return w.Flush()
+}
+
+func (hc *HistoryCmd) show(cmd *cobra.Command, args []string) error {
+	entries, err := hc.Gateway.GetSetlistHistory(args[0])
+	if err != nil {
+		hc.Logger.Error("Failed to read the history", err, nil)
+		return err
+	}
+
+	if len(entries) == 0 {
+		err := fmt.Errorf("setlist %s was never converted", args[0])
+		hc.Logger.Error("Nothing to show", err, nil)
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	latest := entries[0]
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Setlist:\t%s\n", latest.SetlistID)
+	fmt.Fprintf(w, "Title:\t%s\n", latest.Title)
+	fmt.Fprintf(w, "Artist:\t%s\n", latest.Artist)
+	fmt.Fprintf(w, "Show date:\t%s\n", formatEventDate(latest))
+	fmt.Fprintf(w, "Playlist:\t%s\n", latest.PlaylistURL)
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nRuns:\n")
+
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for _, e := range entries {
+		fmt.Fprintf(
+			w,
+			"%s\t%s\tversion %s\t%d matched, %d unmatched\t%s\n",
+			e.CreatedAt.Local().Format(time.DateTime),
+			e.Action,
+			e.VersionID,
+			e.Matched,
+			e.Unmatched,
+			e.PlaylistURL,
+		)
+	}
+
+	return w.Flush()
+}
+
+func formatEventDate(e history.Entry) string {
+	date, ok := e.EventTime()
+	if !ok {
+		return e.EventDate
+	}
+
+	return date.Format(historyDateLayout)
+}
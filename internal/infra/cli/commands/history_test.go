@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type HistoryCmdTestSuite struct {
+	suite.Suite
+	LoggerMock            *mocks.LoggerMock
+	HistoryCmdGatewayMock *mocks.HistoryCmdGatewayMock
+
+	Cmd     RootCmdInterface
+	Entries []history.Entry
+}
+
+func (s *HistoryCmdTestSuite) SetupTest() {
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.HistoryCmdGatewayMock = new(mocks.HistoryCmdGatewayMock)
+
+	s.Cmd = NewHistoryCmd(s.LoggerMock, s.HistoryCmdGatewayMock)
+	s.Entries = []history.Entry{
+		{
+			SetlistID:   "53aa1325",
+			VersionID:   "any-version",
+			Artist:      "blink-182",
+			EventDate:   "07-04-2024",
+			Title:       "blink-182 @ Autódromo de Interlagos",
+			PlaylistURL: "https://open.spotify.com/playlist/any-playlist-id",
+			Action:      history.ActionCreated,
+			Matched:     20,
+			Unmatched:   2,
+			CreatedAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func (s *HistoryCmdTestSuite) cleanMocks() {
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+	s.HistoryCmdGatewayMock.ExpectedCalls = nil
+	s.HistoryCmdGatewayMock.Calls = nil
+}
+
+func TestHistoryCmd(t *testing.T) {
+	suite.Run(t, new(HistoryCmdTestSuite))
+}
+
+func (s *HistoryCmdTestSuite) TestList() {
+	s.Run("Should print the runs", func() {
+		defer s.cleanMocks()
+
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{Limit: 5}).Return(s.Entries, nil)
+
+		out := new(bytes.Buffer)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"list", "--limit", "5"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.Contains(out.String(), "53aa1325")
+		s.Contains(out.String(), "2024-04-07")
+		s.Contains(out.String(), "20/22")
+	})
+
+	s.Run("Should say when there is nothing to list", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{}).Return([]history.Entry{}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{"list"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Info", "No runs found", mock.Anything)
+	})
+}
+
+func (s *HistoryCmdTestSuite) TestFilter() {
+	s.Run("Should filter by artist and date range", func() {
+		defer s.cleanMocks()
+
+		expected := history.Filter{
+			Artist: "blink",
+			From:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		}
+
+		s.HistoryCmdGatewayMock.On("ListHistory", expected).Return(s.Entries, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetArgs([]string{"filter", "--artist", "blink", "--from", "2024-01-01", "--to", "2024-12-31"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.HistoryCmdGatewayMock.AssertExpectations(s.T())
+	})
+
+	s.Run("Should return an error when a date is invalid", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{"filter", "--from", "07-04-2024"})
+
+		err := cmd.Execute()
+
+		s.ErrorContains(err, "invalid --from date")
+		s.HistoryCmdGatewayMock.AssertNotCalled(s.T(), "ListHistory", mock.Anything)
+	})
+}
+
+func (s *HistoryCmdTestSuite) TestShow() {
+	s.Run("Should print the runs of a setlist", func() {
+		defer s.cleanMocks()
+
+		s.HistoryCmdGatewayMock.On("GetSetlistHistory", "53aa1325").Return(s.Entries, nil)
+
+		out := new(bytes.Buffer)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"show", "53aa1325"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.Contains(out.String(), "blink-182 @ Autódromo de Interlagos")
+		s.Contains(out.String(), "20 matched, 2 unmatched")
+	})
+
+	s.Run("Should return an error when the setlist was never converted", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("GetSetlistHistory", "unknown").Return([]history.Entry{}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{"show", "unknown"})
+
+		err := cmd.Execute()
+
+		s.ErrorContains(err, "setlist unknown was never converted")
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("GetSetlistHistory", "53aa1325").Return(nil, errors.New("any-error"))
+
+		cmd := s.Cmd.Build()
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{"show", "53aa1325"})
+
+		err := cmd.Execute()
+
+		s.ErrorContains(err, "any-error")
+	})
+}
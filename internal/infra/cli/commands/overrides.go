@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type OverridesCmd struct {
+	Logger  logger.LoggerInterface
+	Gateway gateways.OverridesCmdGatewayInterface
+}
+
+func NewOverridesCmd(l logger.LoggerInterface, gw gateways.OverridesCmdGatewayInterface) RootCmdInterface {
+	return &OverridesCmd{
+		Logger:  l,
+		Gateway: gw,
+	}
+}
+
+func (oc *OverridesCmd) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "overrides",
+		Short: "Manages the songs you matched by hand",
+	}
+
+	add := &cobra.Command{
+		Use:   "add",
+		Short: "Always use a Spotify track (or skip) for a song of an artist",
+		RunE:  oc.add,
+	}
+
+	add.Flags().String("artist", "", "artist name, as on Setlist.fm")
+	add.Flags().String("song", "", "song title, as on Setlist.fm")
+	add.Flags().String("track", "", `Spotify track ID, URI or URL, or "skip" to leave the song out`)
+	add.MarkFlagRequired("artist")
+	add.MarkFlagRequired("song")
+	add.MarkFlagRequired("track")
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the saved overrides",
+		RunE:  oc.list,
+	}
+
+	list.Flags().String("artist", "", "only list the overrides of this artist")
+
+	remove := &cobra.Command{
+		Use:   "remove",
+		Short: "Removes a saved override",
+		RunE:  oc.remove,
+	}
+
+	remove.Flags().String("artist", "", "artist name, as on Setlist.fm")
+	remove.Flags().String("song", "", "song title, as on Setlist.fm")
+	remove.MarkFlagRequired("artist")
+	remove.MarkFlagRequired("song")
+
+	cmd.AddCommand(add, list, remove)
+
+	return cmd
+}
+
+func (oc *OverridesCmd) add(cmd *cobra.Command, args []string) error {
+	artist, _ := cmd.Flags().GetString("artist")
+	song, _ := cmd.Flags().GetString("song")
+	track, _ := cmd.Flags().GetString("track")
+
+	override, err := oc.Gateway.AddOverride(artist, song, track)
+	if err != nil {
+		oc.Logger.Error("Failed to save override", err, nil)
+		return err
+	}
+
+	oc.Logger.Info(fmt.Sprintf("Override saved: %q by %s → %s", override.Song, override.Artist, override.Track), nil)
+	return nil
+}
+
+func (oc *OverridesCmd) list(cmd *cobra.Command, args []string) error {
+	artist, _ := cmd.Flags().GetString("artist")
+
+	found, err := oc.Gateway.ListOverrides(artist)
+	if err != nil {
+		oc.Logger.Error("Failed to list overrides", err, nil)
+		return err
+	}
+
+	if len(found) == 0 {
+		oc.Logger.Info("No overrides saved yet", nil)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ARTIST\tSONG\tTRACK")
+
+	for _, o := range found {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", o.Artist, o.Song, o.Track)
+	}
+
+	return w.Flush()
+}
+
+func (oc *OverridesCmd) remove(cmd *cobra.Command, args []string) error {
+	artist, _ := cmd.Flags().GetString("artist")
+	song, _ := cmd.Flags().GetString("song")
+
+	if err := oc.Gateway.RemoveOverride(artist, song); err != nil {
+		oc.Logger.Error("Failed to remove override", err, nil)
+		return err
+	}
+
+	oc.Logger.Info(fmt.Sprintf("Override removed: %q by %s", song, artist), nil)
+	return nil
+}
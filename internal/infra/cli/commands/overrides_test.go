@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type OverridesCmdTestSuite struct {
+	suite.Suite
+	LoggerMock              *mocks.LoggerMock
+	OverridesCmdGatewayMock *mocks.OverridesCmdGatewayMock
+
+	Cmd RootCmdInterface
+}
+
+func (s *OverridesCmdTestSuite) SetupTest() {
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.OverridesCmdGatewayMock = new(mocks.OverridesCmdGatewayMock)
+
+	s.Cmd = NewOverridesCmd(s.LoggerMock, s.OverridesCmdGatewayMock)
+}
+
+func (s *OverridesCmdTestSuite) cleanMocks() {
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+	s.OverridesCmdGatewayMock.ExpectedCalls = nil
+	s.OverridesCmdGatewayMock.Calls = nil
+}
+
+func TestOverridesCmd(t *testing.T) {
+	suite.Run(t, new(OverridesCmdTestSuite))
+}
+
+func (s *OverridesCmdTestSuite) TestBuild() {
+	s.Run("Should have the add, list and remove subcommands", func() {
+		cmd := s.Cmd.Build()
+
+		s.Equal("overrides", cmd.Name())
+
+		for _, name := range []string{"add", "list", "remove"} {
+			sub, _, err := cmd.Find([]string{name})
+
+			s.NoError(err)
+			s.Equal(name, sub.Name())
+		}
+	})
+}
+
+func (s *OverridesCmdTestSuite) TestAdd() {
+	s.Run("Should save an override", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.OverridesCmdGatewayMock.
+			On("AddOverride", "blink-182", "Dammit", "skip").
+			Return(&overrides.Override{Artist: "blink-182", Song: "Dammit", Track: "skip"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{"add", "--artist", "blink-182", "--song", "Dammit", "--track", "skip"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Info", `Override saved: "Dammit" by blink-182 → skip`, mock.Anything)
+	})
+
+	s.Run("Should return an error when saving fails", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.OverridesCmdGatewayMock.
+			On("AddOverride", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("any-error"))
+
+		cmd := s.Cmd.Build()
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{"add", "--artist", "blink-182", "--song", "Dammit", "--track", "nope"})
+
+		err := cmd.Execute()
+
+		s.ErrorContains(err, "any-error")
+	})
+}
+
+func (s *OverridesCmdTestSuite) TestList() {
+	s.Run("Should print the overrides", func() {
+		defer s.cleanMocks()
+
+		s.OverridesCmdGatewayMock.On("ListOverrides", "").Return([]overrides.Override{
+			{Artist: "blink-182", Song: "Dammit", Track: "4uLU6hMCjMI75M1A2tKUQC"},
+		}, nil)
+
+		out := new(bytes.Buffer)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"list"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.Contains(out.String(), "ARTIST")
+		s.Contains(out.String(), "4uLU6hMCjMI75M1A2tKUQC")
+	})
+
+	s.Run("Should say when there are no overrides", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.OverridesCmdGatewayMock.On("ListOverrides", "blink-182").Return([]overrides.Override{}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{"list", "--artist", "blink-182"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Info", "No overrides saved yet", mock.Anything)
+	})
+}
+
+func (s *OverridesCmdTestSuite) TestRemove() {
+	s.Run("Should remove an override", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.OverridesCmdGatewayMock.On("RemoveOverride", "blink-182", "Dammit").Return(nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{"remove", "--artist", "blink-182", "--song", "Dammit"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+	})
+}
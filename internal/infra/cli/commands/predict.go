@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	spotify_entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/prompts"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type PredictCmd struct {
+	Logger    logger.LoggerInterface
+	Setlists  gateways.SetlistFMCmdGatewayInterface
+	Playlists *RootCmd
+}
+
+func NewPredictCmd(
+	l logger.LoggerInterface,
+	gw gateways.RootCmdGatewayInterface,
+	setlistsGw gateways.SetlistFMCmdGatewayInterface,
+	cfg *config.Config,
+	reviewer prompts.MatchReviewerInterface,
+) RootCmdInterface {
+	return &PredictCmd{
+		Logger:    l,
+		Setlists:  setlistsGw,
+		Playlists: &RootCmd{Logger: l, Gateway: gw, Setlists: setlistsGw, Config: cfg, Reviewer: reviewer},
+	}
+}
+
+func (pc *PredictCmd) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "predict",
+		Short: "Creates a playlist with the songs an artist is most likely to play on a tour",
+		RunE:  pc.run,
+	}
+
+	cmd.Flags().String("artist", "", "name of the artist, as on Setlist.fm, or its MusicBrainz ID")
+	cmd.Flags().String("tour", "", "name of the tour, as on Setlist.fm")
+	cmd.MarkFlagRequired("artist")
+	cmd.MarkFlagRequired("tour")
+	cmd.Flags().Float64(
+		"min-frequency",
+		0.5,
+		"share of the shows, from 0 to 1, a song must have been played at to make the playlist",
+	)
+	cmd.Flags().Int("max-pages", 20, "how many pages of the artist setlists to look through for the tour (0 for all)")
+
+	pc.Playlists.addPlaylistFlags(cmd)
+
+	return cmd
+}
+
+func (pc *PredictCmd) run(cmd *cobra.Command, args []string) error {
+	artistName, _ := cmd.Flags().GetString("artist")
+	tour, _ := cmd.Flags().GetString("tour")
+	minFrequency, _ := cmd.Flags().GetFloat64("min-frequency")
+	maxPages, _ := cmd.Flags().GetInt("max-pages")
+
+	predictOpts := setlistfm.PredictOptions{MinFrequency: minFrequency}
+
+	if err := predictOpts.Validate(); err != nil {
+		pc.Logger.Error("Invalid minimum frequency", err, nil)
+		return err
+	}
+
+	opts, err := pc.Playlists.playlistOptions(cmd, spotify_entities.PredictedTitleTemplate)
+	if err != nil {
+		return err
+	}
+
+	predictOpts.IncludeTapes = opts.IncludeTapes
+
+	pc.Logger.Info(fmt.Sprintf("Looking %s up on Setlist.fm...", artistName), nil)
+
+	artist, err := pc.Setlists.FindArtist(artistName)
+	if err != nil {
+		pc.Logger.Error("Failed to find the artist", err, nil)
+		return err
+	}
+
+	pc.Logger.Info(fmt.Sprintf("Fetching the setlists of %s on the %s tour...", artist.Name, tour), nil)
+
+	shows, err := pc.Setlists.GetTourSetlists(setlistfm.GetTourSetlistsInput{
+		ArtistMBID: artist.MBID,
+		Tour:       tour,
+		MaxPages:   maxPages,
+	})
+	if err != nil {
+		pc.Logger.Error("Failed to fetch the tour setlists", err, nil)
+		return err
+	}
+
+	if len(shows) == 0 {
+		err := fmt.Errorf("no setlists of the %q tour were found for %s", tour, artist.Name)
+		pc.Logger.Error("Nothing to predict", err, nil)
+		return err
+	}
+
+	prediction, err := shows.Predict(predictOpts)
+	if err != nil {
+		pc.Logger.Error("Nothing to predict", err, nil)
+		return err
+	}
+
+	if len(prediction.Songs) == 0 {
+		err := errors.New("no song was played often enough, try a lower --min-frequency")
+		pc.Logger.Error("Nothing to predict", err, nil)
+		return err
+	}
+
+	if err := pc.printPrediction(cmd, prediction); err != nil {
+		return err
+	}
+
+	return pc.Playlists.convert(cmd, opts, setlistfm.Shows{prediction.Set()})
+}
+
+func (pc *PredictCmd) printPrediction(cmd *cobra.Command, prediction *setlistfm.Prediction) error {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Predicted from %d shows:\n\n", prediction.Shows)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for i, p := range prediction.Songs {
+		fmt.Fprintf(w, "%d.\t%s\t%d/%d shows\t%.0f%%\n", i+1, p.Song.Name, p.Shows, prediction.Shows, p.Frequency*100)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	return nil
+}
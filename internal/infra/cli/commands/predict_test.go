@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type PredictCmdTestSuite struct {
+	suite.Suite
+	LoggerMock              *mocks.LoggerMock
+	RootCmdGatewayMock      *mocks.RootCmdGatewayMock
+	SetlistFMCmdGatewayMock *mocks.SetlistFMCmdGatewayMock
+	MatchReviewerMock       *mocks.MatchReviewerMock
+
+	Cmd    RootCmdInterface
+	Artist *setlistfm.Artist
+	Shows  setlistfm.Shows
+}
+
+func (s *PredictCmdTestSuite) SetupTest() {
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.RootCmdGatewayMock = new(mocks.RootCmdGatewayMock)
+	s.SetlistFMCmdGatewayMock = new(mocks.SetlistFMCmdGatewayMock)
+	s.MatchReviewerMock = new(mocks.MatchReviewerMock)
+
+	s.Cmd = NewPredictCmd(
+		s.LoggerMock,
+		s.RootCmdGatewayMock,
+		s.SetlistFMCmdGatewayMock,
+		&config.Config{},
+		s.MatchReviewerMock,
+	)
+
+	s.Artist = &setlistfm.Artist{MBID: "any-mbid", Name: "any-artist"}
+
+	show := func(songs ...string) *setlistfm.Set {
+		set := &setlistfm.Set{Artist: *s.Artist, Tour: setlistfm.Tour{Name: "Any Tour"}}
+
+		for _, name := range songs {
+			set.Sets.Set = append(set.Sets.Set, setlistfm.Songs{Song: []setlistfm.Song{{Name: name}}})
+		}
+
+		return set
+	}
+
+	s.Shows = setlistfm.Shows{
+		show("any-opener", "any-hit", "any-rarity"),
+		show("any-opener", "any-hit"),
+		show("any-hit", "any-closer"),
+	}
+}
+
+func (s *PredictCmdTestSuite) cleanMocks() {
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+	s.RootCmdGatewayMock.ExpectedCalls = nil
+	s.RootCmdGatewayMock.Calls = nil
+	s.SetlistFMCmdGatewayMock.ExpectedCalls = nil
+	s.SetlistFMCmdGatewayMock.Calls = nil
+}
+
+func TestPredictCmd(t *testing.T) {
+	suite.Run(t, new(PredictCmdTestSuite))
+}
+
+func (s *PredictCmdTestSuite) TestRun() {
+	tourInput := setlistfm.GetTourSetlistsInput{ArtistMBID: "any-mbid", Tour: "any tour", MaxPages: 20}
+
+	s.Run("Should create a playlist with the songs played at most shows", func() {
+		defer s.cleanMocks()
+
+		predicted := &setlistfm.Set{
+			ID:     "predicted:any-mbid:any tour",
+			Artist: *s.Artist,
+			Tour:   setlistfm.Tour{Name: "Any Tour"},
+			Sets: setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{
+				{Name: "any-opener"},
+				{Name: "any-hit"},
+			}}}},
+		}
+		songs := &spotify.FindAllSongsOutput{Songs: []spotify.Song{{ID: "any-song-id-1"}, {ID: "any-song-id-2"}}}
+		input := spotify.CreatePlaylistInput{Title: "any-artist - Any Tour (predicted setlist)"}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("FindArtist", "any-artist").Return(s.Artist, nil)
+		s.SetlistFMCmdGatewayMock.On("GetTourSetlists", tourInput).Return(s.Shows, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(predicted)).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", predicted.ID).Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", predicted.ID, mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.Flags().Set("artist", "any-artist")
+		cmd.Flags().Set("tour", "any tour")
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs)
+	})
+
+	s.Run("Should return an error when the tour has no setlists", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("FindArtist", "any-artist").Return(s.Artist, nil)
+		s.SetlistFMCmdGatewayMock.On("GetTourSetlists", tourInput).Return(setlistfm.Shows{}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("artist", "any-artist")
+		cmd.Flags().Set("tour", "any tour")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, `no setlists of the "any tour" tour were found for any-artist`)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "StartWebServer")
+	})
+
+	s.Run("Should return an error when no song was played often enough", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("FindArtist", "any-artist").Return(s.Artist, nil)
+		s.SetlistFMCmdGatewayMock.
+			On("GetTourSetlists", mock.Anything).
+			Return(setlistfm.Shows{s.Shows[0], {Sets: setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-closer"}}}}}}}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("artist", "any-artist")
+		cmd.Flags().Set("tour", "any tour")
+		cmd.Flags().Set("min-frequency", "1")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "try a lower --min-frequency")
+	})
+
+	s.Run("Should return an error when the artist is not found", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("FindArtist", "any-artist").Return(nil, errors.New("any-error"))
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("artist", "any-artist")
+		cmd.Flags().Set("tour", "any tour")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "any-error")
+		s.SetlistFMCmdGatewayMock.AssertNotCalled(s.T(), "GetTourSetlists", mock.Anything)
+	})
+
+	s.Run("Should return an error for an invalid minimum frequency", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("artist", "any-artist")
+		cmd.Flags().Set("tour", "any tour")
+		cmd.Flags().Set("min-frequency", "0")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "between 0 and 1")
+		s.SetlistFMCmdGatewayMock.AssertNotCalled(s.T(), "FindArtist", mock.Anything)
+	})
+}
@@ -1,12 +1,24 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	spotify_entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
 	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/prompts"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/cover"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
 )
 
 type RootCmdInterface interface {
@@ -14,66 +26,969 @@ type RootCmdInterface interface {
 }
 
 type RootCmd struct {
-	Logger  logger.LoggerInterface
-	Gateway gateways.RootCmdGatewayInterface
+	Logger   logger.LoggerInterface
+	Gateway  gateways.RootCmdGatewayInterface
+	Setlists gateways.SetlistFMCmdGatewayInterface
+	Config   *config.Config
+	Reviewer prompts.MatchReviewerInterface
 }
 
+// latestSetlistMaxPages caps how far back --artist looks for a show with songs.
+const latestSetlistMaxPages = 5
+
 func NewRootCmd(
 	l logger.LoggerInterface,
 	gw gateways.RootCmdGatewayInterface,
+	setlistsGw gateways.SetlistFMCmdGatewayInterface,
+	cfg *config.Config,
+	reviewer prompts.MatchReviewerInterface,
 ) RootCmdInterface {
 	return &RootCmd{
-		Logger:  l,
-		Gateway: gw,
+		Logger:   l,
+		Gateway:  gw,
+		Setlists: setlistsGw,
+		Config:   cfg,
+		Reviewer: reviewer,
 	}
 }
 
 func (s *RootCmd) Build() *cobra.Command {
 	cmd := &cobra.Command{
+		Use:   "setlist-to-playlist",
 		Short: "Creates a playlist based on a Setlist.fm entry",
 		RunE:  s.run,
 	}
 
-	cmd.Flags().String("url", "", "setlist.fm set URL to create a playlist from")
-	cmd.MarkFlagRequired("url")
+	cmd.Flags().StringArray("url", nil, "setlist.fm set URL (or ID) to create a playlist from, repeat it to combine several shows")
+	cmd.Flags().String("url-file", "", "file with one setlist.fm set URL per line to combine into one playlist")
+	cmd.Flags().String("artist", "", "name or MusicBrainz ID of an artist to create a playlist from their latest show")
+	cmd.Flags().String("file", "", "local JSON, YAML or text setlist to create a playlist from, for shows not on setlist.fm")
+	cmd.MarkFlagsOneRequired("url", "url-file", "artist", "file")
+	cmd.MarkFlagsMutuallyExclusive("url", "artist", "file")
+	cmd.MarkFlagsMutuallyExclusive("url-file", "artist", "file")
+	cmd.Flags().String(
+		"festival-mode",
+		s.Config.Playlist.Festival,
+		"for festival URLs: combined (the whole lineup in one playlist) or per-artist (one playlist per artist)",
+	)
+	cmd.Flags().String("festival-day", "", "for festival URLs: only the shows of this day, as DD-MM-YYYY or YYYY-MM-DD")
+	cmd.Flags().StringSlice("include-artist", nil, "for festival URLs: only the shows of these artists")
+	cmd.Flags().StringSlice("exclude-artist", nil, "for festival URLs: leave the shows of these artists out")
+	cmd.Flags().String(
+		"merge",
+		s.Config.Playlist.Merge,
+		"how to combine several shows: concat (every song, in order) or dedupe (each song once)",
+	)
+
+	s.addPlaylistFlags(cmd)
 
 	return cmd
 }
 
+// addPlaylistFlags adds the flags of every command that ends up creating a playlist.
+func (s *RootCmd) addPlaylistFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "skip the match review and create the playlist right away")
+	cmd.Flags().String("playlist", "", "ID or URL of an existing playlist to replace the tracks of, instead of creating a new one")
+	cmd.Flags().Bool("new", false, "create a new playlist even if this setlist was converted before")
+	cmd.MarkFlagsMutuallyExclusive("playlist", "new")
+	cmd.Flags().Bool("dry-run", false, "show the playlist that would be created without creating it")
+	cmd.Flags().String("title", s.Config.Playlist.Title, "Go template for the playlist title, see the README for the available fields")
+	cmd.Flags().String("description", s.Config.Playlist.Description, "Go template for the playlist description")
+	cmd.Flags().String("cover", "", "JPEG or PNG image to use as the playlist cover")
+	cmd.Flags().Bool(
+		"generate-cover",
+		s.Config.Playlist.GenerateCover,
+		"generate a cover with the artist, venue and date of the show",
+	)
+	cmd.MarkFlagsMutuallyExclusive("cover", "generate-cover")
+	cmd.Flags().Bool("private", s.Config.Playlist.Private, "create the playlist as private")
+	cmd.Flags().Bool(
+		"collaborative",
+		s.Config.Playlist.Collaborative,
+		"create the playlist as collaborative (collaborative playlists are always private)",
+	)
+	cmd.Flags().Bool("include-tapes", false, "keep tape entries (intros, outros) from the setlist")
+	cmd.Flags().String(
+		"sets",
+		s.Config.Playlist.Sets,
+		"how to handle the sets of the show: combined, split (one playlist per set and encore) or describe (list them in the description)",
+	)
+	cmd.Flags().String(
+		"version-preference",
+		s.Config.Matching.VersionPreference,
+		"which track versions to pick: any, studio-only, prefer-live or prefer-original-release",
+	)
+	cmd.Flags().StringSlice(
+		"exclude",
+		s.Config.Matching.Exclude,
+		"track variants to never pick: live, remaster, demo, compilation, karaoke, instrumental, tribute",
+	)
+	cmd.Flags().String(
+		"covers",
+		s.Config.Matching.Covers,
+		"how to look up covers: performer, original, fallback (performer, then original artist) or best",
+	)
+}
+
+// playlistOptions are the flags added by addPlaylistFlags, parsed and validated.
+type playlistOptions struct {
+	Policy       matching.Policy
+	IncludeTapes bool
+	SkipReview   bool
+	DryRun       bool
+	Visibility   spotify_entities.PlaylistVisibility
+	Template     *spotify_entities.PlaylistTemplate
+	Sets         spotify_entities.SetsMode
+	Merge        spotify_entities.MergeMode
+	// Festival names the festival the shows were played at, for the title templates.
+	Festival string
+}
+
 func (rc *RootCmd) run(cmd *cobra.Command, args []string) error {
-	setlistfmURL, _ := cmd.Flags().GetString("url")
+	artistName, _ := cmd.Flags().GetString("artist")
+
+	if artistName != "" {
+		return rc.runLatestShow(cmd, artistName)
+	}
+
+	setlistFile, _ := cmd.Flags().GetString("file")
+
+	if setlistFile != "" {
+		return rc.runFile(cmd, setlistFile)
+	}
+
+	urls, err := setlistURLs(cmd)
+	if err != nil {
+		rc.Logger.Error("Invalid setlist URLs", err, nil)
+		return err
+	}
+
+	for _, u := range urls {
+		if !setlistfm.NewGetSetlistByIDInput(u).IsFestival() {
+			continue
+		}
+
+		if len(urls) > 1 {
+			err := errors.New("a festival URL can't be combined with other setlists")
+			rc.Logger.Error("Invalid setlist URLs", err, nil)
+			return err
+		}
+
+		return rc.runFestival(cmd, u)
+	}
+
+	return rc.runSetlists(cmd, urls)
+}
+
+// runSetlists turns the setlists at the given URLs into one playlist.
+func (rc *RootCmd) runSetlists(cmd *cobra.Command, urls []string) error {
+	mergeFlag, _ := cmd.Flags().GetString("merge")
+
+	opts, err := rc.playlistOptions(cmd, "")
+	if err != nil {
+		return err
+	}
+
+	opts.Merge, err = spotify_entities.ParseMergeMode(mergeFlag)
+	if err != nil {
+		rc.Logger.Error("Invalid merge option", err, nil)
+		return err
+	}
+
+	if len(urls) > 1 && opts.Sets != spotify_entities.SetsCombined {
+		err := errors.New("--sets can only be used with a single setlist")
+		rc.Logger.Error("Invalid sets option", err, nil)
+		return err
+	}
 
 	rc.Logger.Info("Fetching setlist...", nil)
 
-	set, err := rc.Gateway.GetTracksFromSetlist(setlistfmURL)
+	shows := make(setlistfm.Shows, 0, len(urls))
+
+	for _, u := range urls {
+		show, err := rc.Gateway.GetTracksFromSetlist(u)
+		if err != nil {
+			rc.Logger.Error("Failed to get tracks from setlist", err, nil)
+			return err
+		}
+
+		shows = append(shows, show)
+	}
+
+	return rc.convert(cmd, opts, shows)
+}
+
+// runLatestShow turns the most recent show of the artist into a playlist.
+func (rc *RootCmd) runLatestShow(cmd *cobra.Command, artistName string) error {
+	opts, err := rc.playlistOptions(cmd, "")
+	if err != nil {
+		return err
+	}
+
+	show, err := rc.latestShow(artistName)
+	if err != nil {
+		return err
+	}
+
+	return rc.convert(cmd, opts, setlistfm.Shows{show})
+}
+
+// runFile turns a setlist written in a local file into a playlist.
+func (rc *RootCmd) runFile(cmd *cobra.Command, path string) error {
+	opts, err := rc.playlistOptions(cmd, "")
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		rc.Logger.Error("Failed to read the setlist file", err, nil)
+		return err
+	}
+
+	show, err := setlistfm.ParseSetlistFile(path, data)
 	if err != nil {
-		rc.Logger.Error("Failed to get tracks from setlist", err, nil)
+		rc.Logger.Error("Invalid setlist file", err, nil)
 		return err
 	}
 
+	return rc.convert(cmd, opts, setlistfm.Shows{show})
+}
+
+// runFestival turns the lineup of a festival into one playlist, or one per artist.
+func (rc *RootCmd) runFestival(cmd *cobra.Command, festivalURL string) error {
+	mergeFlag, _ := cmd.Flags().GetString("merge")
+	modeFlag, _ := cmd.Flags().GetString("festival-mode")
+	day, _ := cmd.Flags().GetString("festival-day")
+	include, _ := cmd.Flags().GetStringSlice("include-artist")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude-artist")
+
+	mode, err := spotify_entities.ParseFestivalMode(modeFlag)
+	if err != nil {
+		rc.Logger.Error("Invalid festival mode", err, nil)
+		return err
+	}
+
+	defaultTitle := spotify_entities.FestivalTitleTemplate
+	if mode == spotify_entities.FestivalPerArtist {
+		defaultTitle = spotify_entities.FestivalArtistTitleTemplate
+	}
+
+	if playlistRef, _ := cmd.Flags().GetString("playlist"); mode == spotify_entities.FestivalPerArtist && playlistRef != "" {
+		err := errors.New("--playlist can't be used with --festival-mode per-artist, every artist gets its own playlist")
+		rc.Logger.Error("Invalid festival mode", err, nil)
+		return err
+	}
+
+	opts, err := rc.playlistOptions(cmd, defaultTitle)
+	if err != nil {
+		return err
+	}
+
+	opts.Merge, err = spotify_entities.ParseMergeMode(mergeFlag)
+	if err != nil {
+		rc.Logger.Error("Invalid merge option", err, nil)
+		return err
+	}
+
+	if opts.Sets != spotify_entities.SetsCombined {
+		err := errors.New("--sets can only be used with a single setlist")
+		rc.Logger.Error("Invalid sets option", err, nil)
+		return err
+	}
+
+	input := setlistfm.GetFestivalSetlistsInput{
+		URL:     festivalURL,
+		Date:    day,
+		Artists: setlistfm.ArtistFilter{Include: include, Exclude: exclude},
+	}
+
+	if err := input.Validate(); err != nil {
+		rc.Logger.Error("Invalid festival", err, nil)
+		return err
+	}
+
+	rc.Logger.Info("Fetching the festival setlists...", nil)
+
+	festival, err := rc.Setlists.GetFestivalSetlists(input)
+	if err != nil {
+		rc.Logger.Error("Failed to fetch the festival setlists", err, nil)
+		return err
+	}
+
+	rc.Logger.Info(fmt.Sprintf("Found %d setlists of %s", len(festival.Shows), festival.Name), nil)
+
+	opts.Festival = festival.Name
+
+	if mode == spotify_entities.FestivalPerArtist {
+		return rc.convertEach(cmd, opts, festival.Shows.ByArtist())
+	}
+
+	return rc.convert(cmd, opts, festival.Shows)
+}
+
+// latestShow resolves the artist and returns their most recent setlist that has songs.
+func (rc *RootCmd) latestShow(artistName string) (*setlistfm.Set, error) {
+	rc.Logger.Info(fmt.Sprintf("Looking %s up on Setlist.fm...", artistName), nil)
+
+	artist, err := rc.Setlists.FindArtist(artistName)
+	if err != nil {
+		rc.Logger.Error("Failed to find the artist", err, nil)
+		return nil, err
+	}
+
+	rc.Logger.Info(fmt.Sprintf("Fetching the latest setlist of %s...", artist.Name), nil)
+
+	show, err := rc.Setlists.GetLatestSetlist(setlistfm.GetLatestSetlistInput{
+		ArtistMBID: artist.MBID,
+		MaxPages:   latestSetlistMaxPages,
+	})
+	if err != nil {
+		rc.Logger.Error("Failed to fetch the latest setlist", err, nil)
+		return nil, err
+	}
+
+	rc.Logger.Info("Latest setlist found", map[string]interface{}{
+		"date":  show.EventDate,
+		"venue": show.Venue.Name,
+	})
+
+	return show, nil
+}
+
+// playlistOptions parses the playlist flags, logging what is wrong with them. defaultTitle
+// replaces DefaultTitleTemplate when no title template was given.
+func (rc *RootCmd) playlistOptions(cmd *cobra.Command, defaultTitle string) (*playlistOptions, error) {
+	versionPreference, _ := cmd.Flags().GetString("version-preference")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	covers, _ := cmd.Flags().GetString("covers")
+	includeTapes, _ := cmd.Flags().GetBool("include-tapes")
+	skipReview, _ := cmd.Flags().GetBool("yes")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	private, _ := cmd.Flags().GetBool("private")
+	collaborative, _ := cmd.Flags().GetBool("collaborative")
+	titleTemplate, _ := cmd.Flags().GetString("title")
+	descriptionTemplate, _ := cmd.Flags().GetString("description")
+	setsFlag, _ := cmd.Flags().GetString("sets")
+	playlistRef, _ := cmd.Flags().GetString("playlist")
+
+	policy, err := matching.NewPolicy(versionPreference, exclude, covers)
+	if err != nil {
+		rc.Logger.Error("Invalid matching options", err, nil)
+		return nil, err
+	}
+
+	setsMode, err := spotify_entities.ParseSetsMode(setsFlag)
+	if err != nil {
+		rc.Logger.Error("Invalid sets option", err, nil)
+		return nil, err
+	}
+
+	if setsMode == spotify_entities.SetsSplit && playlistRef != "" {
+		err := errors.New("--playlist can't be used with --sets split, every set gets its own playlist")
+		rc.Logger.Error("Invalid sets option", err, nil)
+		return nil, err
+	}
+
+	if strings.TrimSpace(titleTemplate) == "" {
+		titleTemplate = defaultTitle
+	}
+
+	playlistTemplate, err := spotify_entities.NewPlaylistTemplate(titleTemplate, descriptionTemplate)
+	if err != nil {
+		rc.Logger.Error("Invalid playlist template", err, nil)
+		return nil, err
+	}
+
+	return &playlistOptions{
+		Policy:       policy,
+		IncludeTapes: includeTapes,
+		SkipReview:   skipReview,
+		DryRun:       dryRun,
+		Visibility:   spotify_entities.NewPlaylistVisibility(private, collaborative),
+		Template:     playlistTemplate,
+		Sets:         setsMode,
+		Merge:        spotify_entities.MergeConcat,
+	}, nil
+}
+
+// convert turns the shows into one playlist (or one per set, see --sets): it matches their
+// songs on Spotify, lets them be reviewed and creates or updates the playlist.
+func (rc *RootCmd) convert(cmd *cobra.Command, opts *playlistOptions, shows setlistfm.Shows) error {
+	return rc.convertEach(cmd, opts, []setlistfm.Shows{shows})
+}
+
+// convertEach turns every group of shows into its own playlist, authenticating on Spotify once.
+func (rc *RootCmd) convertEach(cmd *cobra.Command, opts *playlistOptions, groups []setlistfm.Shows) error {
+	inputs := make([]spotify_entities.CreatePlaylistInput, len(groups))
+	covers := make([][]byte, len(groups))
+
+	for i, shows := range groups {
+		data := spotify_entities.NewShowsTemplateData(shows)
+		data.Festival = opts.Festival
+
+		input, err := opts.Template.RenderData(data)
+		if err != nil {
+			rc.Logger.Error("Failed to name the playlist", err, nil)
+			return err
+		}
+
+		input.Visibility = opts.Visibility
+		inputs[i] = input
+
+		covers[i], err = rc.loadCover(cmd, data)
+		if err != nil {
+			rc.Logger.Error("Invalid cover image", err, nil)
+			return err
+		}
+	}
+
 	rc.Gateway.StartWebServer()
 
+	var scopes []string
+
+	if !opts.Visibility.IsPublic() {
+		scopes = append(scopes, opts.Visibility.RequiredScopes()...)
+	}
+
+	for _, scope := range rc.updateScopes(cmd, opts, groups) {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if len(covers) > 0 && covers[0] != nil {
+		scopes = append(scopes, spotify_entities.ScopeUGCImageUpload)
+	}
+
+	if len(scopes) > 0 {
+		rc.Gateway.RequireSpotifyScopes(scopes)
+	}
+
 	if err := rc.Gateway.HandleSpotifyAuthentication(cmd.Context()); err != nil {
 		rc.Logger.Error("Failed to authenticate on Spotify", err, nil)
 		return err
 	}
 
+	for i, shows := range groups {
+		if err := rc.createPlaylist(cmd, opts, shows, inputs[i], covers[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateScopes returns the scopes needed to update the playlists the groups of shows were already
+// turned into, as they keep their visibility whatever the flags say. A playlist given with
+// --playlist wasn't necessarily made here, so it may be private.
+func (rc *RootCmd) updateScopes(cmd *cobra.Command, opts *playlistOptions, groups []setlistfm.Shows) []string {
+	playlistRef, _ := cmd.Flags().GetString("playlist")
+	newPlaylist, _ := cmd.Flags().GetBool("new")
+
+	if opts.DryRun || newPlaylist {
+		return nil
+	}
+
+	if playlistRef != "" {
+		return spotify_entities.UpdateScopes("")
+	}
+
+	var scopes []string
+
+	for _, shows := range groups {
+		set := shows.Merge()
+		parts := set.Parts(setlistfm.NormalizeOptions{IncludeTapes: opts.IncludeTapes})
+
+		for _, id := range linkIDs(opts.Sets, set, parts) {
+			// A failed lookup is reported when the playlist is saved.
+			link, err := rc.Gateway.GetLinkedPlaylist(id)
+			if err != nil || link == nil {
+				continue
+			}
+
+			for _, scope := range spotify_entities.UpdateScopes(link.Visibility) {
+				if !slices.Contains(scopes, scope) {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+	}
+
+	return scopes
+}
+
+// createPlaylist matches the songs of the shows, lets them be reviewed and publishes them.
+func (rc *RootCmd) createPlaylist(
+	cmd *cobra.Command,
+	opts *playlistOptions,
+	shows setlistfm.Shows,
+	playlistInput spotify_entities.CreatePlaylistInput,
+	coverImage []byte,
+) error {
+	set := shows.Merge()
+
 	rc.Logger.Info("Fetching songs on Spotify...", nil)
 
-	songs, err := rc.Gateway.FetchSongsOnSpotify(cmd.Context(), set.Songs(), set.ArtistName())
+	songs, err := rc.fetchSongs(cmd, shows, opts.Policy, opts.IncludeTapes)
 	if err != nil {
 		rc.Logger.Error("Failed to fetch songs from Spotify", err, nil)
 		return err
 	}
 
-	rc.Logger.Info("Creating playlist...", nil)
+	// Repeats within a single show are kept, dropping them would shift the songs of its sets.
+	dedupe := opts.Merge == spotify_entities.MergeDedupe && len(shows) > 1
+
+	if dedupe {
+		songs = songs.Dedupe()
+	}
+
+	rc.reportUnmatchedSongs(songs)
+
+	parts := set.Parts(setlistfm.NormalizeOptions{IncludeTapes: opts.IncludeTapes})
+
+	if opts.DryRun {
+		for _, t := range playlistTargets(opts.Sets, set, parts, playlistInput, songs) {
+			if err := rc.printPreview(cmd, t.Input, coverImage, t.Songs); err != nil {
+				return err
+			}
+		}
+
+		rc.Logger.Info("Dry run, no playlist was created", nil)
+		return nil
+	}
+
+	if !opts.SkipReview {
+		songs, err = rc.Reviewer.Review(cmd.Context(), songs, rc.Gateway.SearchTracksOnSpotify)
+		if err != nil {
+			rc.Logger.Error("Match review was not completed", err, nil)
+			return err
+		}
+
+		if dedupe {
+			songs = songs.Dedupe()
+		}
+	}
+
+	if len(songs.Songs) == 0 {
+		err := errors.New("none of the setlist songs were found on Spotify")
+		rc.Logger.Error("Nothing to add to the playlist", err, nil)
+		return err
+	}
+
+	for _, t := range playlistTargets(opts.Sets, set, parts, playlistInput, songs) {
+		if len(t.Songs.Songs) == 0 {
+			rc.Logger.Warn(fmt.Sprintf("None of the songs of %q were found on Spotify, skipping it", t.Part), nil)
+			continue
+		}
+
+		if err := rc.publish(cmd, opts, set, t, coverImage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// playlistTarget is a playlist to make out of the setlist: the whole show, or one of its sets.
+type playlistTarget struct {
+	LinkID string
+	Part   string
+	Input  spotify_entities.CreatePlaylistInput
+	Songs  *spotify_entities.FindAllSongsOutput
+}
 
-	playlistURL, err := rc.Gateway.CreatePlaylistOnSpotify(cmd.Context(), set.Title(), songs.Songs)
+// linkIDs are the IDs the playlists made out of the setlist are linked to, one per target.
+func linkIDs(mode spotify_entities.SetsMode, set *setlistfm.Set, parts []setlistfm.SetPart) []string {
+	if mode != spotify_entities.SetsSplit || len(parts) == 0 {
+		return []string{set.ID}
+	}
+
+	ids := make([]string, len(parts))
+	for i, p := range parts {
+		ids[i] = partLinkID(set, p.Label)
+	}
+
+	return ids
+}
+
+func partLinkID(set *setlistfm.Set, label string) string {
+	return fmt.Sprintf("%s#%s", set.ID, label)
+}
+
+func playlistTargets(
+	mode spotify_entities.SetsMode,
+	set *setlistfm.Set,
+	parts []setlistfm.SetPart,
+	input spotify_entities.CreatePlaylistInput,
+	songs *spotify_entities.FindAllSongsOutput,
+) []playlistTarget {
+	if mode == spotify_entities.SetsCombined || len(parts) == 0 {
+		return []playlistTarget{{LinkID: set.ID, Input: input, Songs: songs}}
+	}
+
+	sizes := make([]int, len(parts))
+	for i, p := range parts {
+		sizes[i] = len(p.Songs)
+	}
+
+	perPart := songs.Split(sizes)
+
+	if mode == spotify_entities.SetsDescribe {
+		labels := make([]string, len(parts))
+		counts := make([]int, len(parts))
+
+		for i, p := range parts {
+			labels[i] = p.Label
+			counts[i] = len(perPart[i].Songs)
+		}
+
+		description := fmt.Sprintf("%s — %s", input.GetDescription(), spotify_entities.DescribeSets(labels, counts))
+		input.Description = &description
+
+		return []playlistTarget{{LinkID: set.ID, Input: input, Songs: songs}}
+	}
+
+	targets := make([]playlistTarget, len(parts))
+
+	for i, p := range parts {
+		partInput := input
+		partInput.Title = fmt.Sprintf("%s (%s)", input.Title, p.Label)
+
+		targets[i] = playlistTarget{
+			LinkID: partLinkID(set, p.Label),
+			Part:   p.Label,
+			Input:  partInput,
+			Songs:  perPart[i],
+		}
+	}
+
+	return targets
+}
+
+func (rc *RootCmd) publish(
+	cmd *cobra.Command,
+	opts *playlistOptions,
+	set *setlistfm.Set,
+	target playlistTarget,
+	coverImage []byte,
+) error {
+	playlist, updated, err := rc.savePlaylist(cmd, target)
 	if err != nil {
-		rc.Logger.Error("Failed to create playlist on Spotify", err, nil)
 		return err
 	}
 
-	rc.Logger.Info(fmt.Sprintf("Playlist created successfully, check it out: %s", *playlistURL), nil)
+	if coverImage != nil {
+		if err := rc.Gateway.UploadPlaylistCover(cmd.Context(), playlist.ID, coverImage); err != nil {
+			rc.Logger.Warn(fmt.Sprintf("Could not set the playlist cover: %s", err), nil)
+		}
+	}
+
+	if err := rc.Gateway.LinkPlaylist(target.LinkID, *playlist); err != nil {
+		rc.Logger.Warn(fmt.Sprintf("Could not remember the playlist for this setlist: %s", err), nil)
+	}
+
+	rc.recordRun(opts, set, target, playlist, updated)
+
+	if updated {
+		rc.Logger.Info(fmt.Sprintf("Playlist updated successfully, check it out: %s", playlist.URL), nil)
+		return nil
+	}
+
+	rc.Logger.Info(fmt.Sprintf("Playlist created successfully, check it out: %s", playlist.URL), nil)
 	return nil
 }
+
+func (rc *RootCmd) savePlaylist(
+	cmd *cobra.Command,
+	target playlistTarget,
+) (*spotify_entities.CreatePlaylistOutput, bool, error) {
+	songs := target.Songs.Songs
+
+	playlistRef, _ := cmd.Flags().GetString("playlist")
+	newPlaylist, _ := cmd.Flags().GetBool("new")
+
+	var playlistID string
+
+	if playlistRef != "" {
+		id, err := spotify_entities.ParsePlaylistID(playlistRef)
+		if err != nil {
+			rc.Logger.Error("Invalid playlist", err, nil)
+			return nil, false, err
+		}
+
+		playlistID = id
+	} else if !newPlaylist {
+		link, err := rc.Gateway.GetLinkedPlaylist(target.LinkID)
+		if err != nil {
+			rc.Logger.Warn(fmt.Sprintf("Could not check for a playlist already made from this setlist: %s", err), nil)
+		}
+
+		if link != nil {
+			rc.Logger.Info(fmt.Sprintf("This setlist was already converted into %s, updating it (pass --new to create another one)", link.URL), nil)
+			playlistID = link.PlaylistID
+		}
+	}
+
+	if playlistID != "" {
+		rc.Logger.Info("Updating playlist...", nil)
+
+		playlist, err := rc.Gateway.UpdatePlaylistOnSpotify(cmd.Context(), playlistID, songs)
+		if err != nil {
+			rc.reportPartialAdd(target.LinkID, nil, err)
+			rc.Logger.Error("Failed to update playlist on Spotify", err, nil)
+			return nil, false, err
+		}
+
+		return playlist, true, nil
+	}
+
+	rc.Logger.Info("Creating playlist...", nil)
+
+	playlist, err := rc.Gateway.CreatePlaylistOnSpotify(cmd.Context(), target.Input, songs)
+	if err != nil {
+		rc.reportPartialAdd(target.LinkID, playlist, err)
+		rc.Logger.Error("Failed to create playlist on Spotify", err, nil)
+		return nil, false, err
+	}
+
+	return playlist, false, nil
+}
+
+// reportPartialAdd tells how far adding the tracks got. A playlist created with only some of
+// its tracks is linked to the setlist, so running the command again completes it.
+func (rc *RootCmd) reportPartialAdd(
+	linkID string,
+	playlist *spotify_entities.CreatePlaylistOutput,
+	err error,
+) {
+	var partial *spotify_entities.PartialAddError
+	if !errors.As(err, &partial) {
+		return
+	}
+
+	rc.Logger.Warn(
+		fmt.Sprintf("Only %d of %d tracks were added, run the command again to finish the playlist", partial.Added, partial.Total),
+		nil,
+	)
+
+	if playlist == nil {
+		return
+	}
+
+	if err := rc.Gateway.LinkPlaylist(linkID, *playlist); err != nil {
+		rc.Logger.Warn(fmt.Sprintf("Could not remember the playlist for this setlist: %s", err), nil)
+		return
+	}
+
+	rc.Logger.Warn(fmt.Sprintf("The incomplete playlist is at %s", playlist.URL), nil)
+}
+
+// setlistURLs returns the URLs given with --url followed by the ones listed in --url-file.
+// Blank lines and lines starting with # are skipped.
+func setlistURLs(cmd *cobra.Command) ([]string, error) {
+	urls, _ := cmd.Flags().GetStringArray("url")
+	urlFile, _ := cmd.Flags().GetString("url-file")
+
+	if urlFile != "" {
+		data, err := os.ReadFile(urlFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			urls = append(urls, line)
+		}
+	}
+
+	if len(urls) == 0 {
+		return nil, errors.New("no setlist URL was given, pass --url, --url-file, --artist or --file")
+	}
+
+	return urls, nil
+}
+
+// fetchSongs searches every show on its own, so each is matched against its own artist, and
+// puts the results one after the other.
+func (rc *RootCmd) fetchSongs(
+	cmd *cobra.Command,
+	shows setlistfm.Shows,
+	policy matching.Policy,
+	includeTapes bool,
+) (*spotify_entities.FindAllSongsOutput, error) {
+	if len(shows) == 1 {
+		return rc.Gateway.FetchSongsOnSpotify(cmd.Context(), findAllSongsInput(shows[0], policy, includeTapes))
+	}
+
+	outputs := make([]*spotify_entities.FindAllSongsOutput, 0, len(shows))
+
+	for _, show := range shows {
+		out, err := rc.Gateway.FetchSongsOnSpotify(cmd.Context(), findAllSongsInput(show, policy, includeTapes))
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, out)
+	}
+
+	var artist string
+	if shows.SameArtist() {
+		artist = shows[0].ArtistName()
+	}
+
+	return spotify_entities.ConcatSongs(artist, outputs), nil
+}
+
+// loadCover returns the JPEG to upload as the playlist cover, or nil when none was asked for.
+func (rc *RootCmd) loadCover(cmd *cobra.Command, data spotify_entities.PlaylistTemplateData) ([]byte, error) {
+	coverPath, _ := cmd.Flags().GetString("cover")
+	generate, _ := cmd.Flags().GetBool("generate-cover")
+
+	if coverPath != "" {
+		data, err := os.ReadFile(coverPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return cover.Prepare(data)
+	}
+
+	if !generate {
+		return nil, nil
+	}
+
+	artist := data.Artist
+	if data.Festival != "" && !data.Shows.SameArtist() {
+		artist = data.Festival
+	}
+
+	return cover.Generate(cover.Details{
+		Artist: artist,
+		Venue:  data.Venue,
+		City:   strings.Trim(fmt.Sprintf("%s, %s", data.City, data.Country), ", "),
+		Date:   data.DateRange,
+	})
+}
+
+func findAllSongsInput(set *setlistfm.Set, policy matching.Policy, includeTapes bool) spotify_entities.FindAllSongsInput {
+	return spotify_entities.FindAllSongsInput{
+		Songs:  spotify_entities.NewSongQueries(set.NormalizedTracks(setlistfm.NormalizeOptions{IncludeTapes: includeTapes})),
+		Artist: set.ArtistName(),
+		Policy: policy,
+	}
+}
+
+func (rc *RootCmd) recordRun(
+	opts *playlistOptions,
+	set *setlistfm.Set,
+	target playlistTarget,
+	playlist *spotify_entities.CreatePlaylistOutput,
+	updated bool,
+) {
+	songs := target.Songs
+
+	action := history.ActionCreated
+	if updated {
+		action = history.ActionUpdated
+	}
+
+	if err := rc.Gateway.RecordRun(history.Entry{
+		SetlistID:   set.ID,
+		SetlistURL:  set.URL,
+		Part:        target.Part,
+		VersionID:   set.VersionID,
+		Artist:      set.ArtistName(),
+		EventDate:   set.EventDate,
+		Title:       target.Input.Title,
+		PlaylistID:  playlist.ID,
+		PlaylistURL: playlist.URL,
+		Action:      action,
+		Matched:     len(songs.Songs),
+		Unmatched:   len(songs.Unmatched()),
+		Options:     history.NewOptions(opts.Policy, opts.IncludeTapes, string(opts.Sets), playlist.Visibility.String()),
+		Picks:       picks(songs.Results),
+	}); err != nil {
+		rc.Logger.Warn(fmt.Sprintf("Could not record this run in the history: %s", err), nil)
+	}
+}
+
+// picks records the track every song was turned into, or that it was skipped, so sync keeps
+// them. Songs that weren't found are left out to be searched again.
+func picks(results []spotify_entities.SongResult) []history.Pick {
+	var picked []history.Pick
+
+	for _, r := range results {
+		switch {
+		case r.Status == spotify_entities.MatchStatusMatched && r.Song != nil:
+			picked = append(picked, history.Pick{
+				Song:    r.Query,
+				TrackID: r.Song.ID,
+				Title:   r.Song.Title,
+				Album:   r.Song.Album,
+				Artists: r.Song.Artists,
+			})
+		case r.Status == spotify_entities.MatchStatusSkipped:
+			picked = append(picked, history.Pick{Song: r.Query})
+		}
+	}
+
+	return picked
+}
+
+func (rc *RootCmd) reportUnmatchedSongs(songs *spotify_entities.FindAllSongsOutput) {
+	unmatched := songs.Unmatched()
+	if len(unmatched) == 0 {
+		return
+	}
+
+	rc.Logger.Warn(
+		fmt.Sprintf("%d of %d songs were left out of the playlist:", len(unmatched), len(songs.Results)),
+		nil,
+	)
+
+	for _, r := range unmatched {
+		rc.Logger.Warn(fmt.Sprintf("  - %q: %s", r.Query, r.Status.Reason()), nil)
+	}
+}
+
+func (rc *RootCmd) printPreview(
+	cmd *cobra.Command,
+	input spotify_entities.CreatePlaylistInput,
+	coverImage []byte,
+	songs *spotify_entities.FindAllSongsOutput,
+) error {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Title:       %s\n", input.Title)
+	fmt.Fprintf(out, "Description: %s\n", input.GetDescription())
+	fmt.Fprintf(out, "Visibility:  %s\n", input.Visibility.String())
+
+	if coverImage != nil {
+		fmt.Fprintf(out, "Cover:       %d KB JPEG\n", (len(coverImage)+1023)/1024)
+	}
+	fmt.Fprintf(out, "Tracks:      %d of %d songs\n\n", len(songs.Songs), len(songs.Results))
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for i, r := range songs.Results {
+		if r.Status != spotify_entities.MatchStatusMatched || r.Song == nil {
+			fmt.Fprintf(w, "%d.\t%s\t-\t(%s)\n", i+1, r.Query, r.Status.Reason())
+			continue
+		}
+
+		confidence := fmt.Sprintf("%.0f%%", r.Score*100)
+		if r.Overridden {
+			confidence = "override"
+		}
+
+		fmt.Fprintf(w, "%d.\t%s\t%s\t%s\n", i+1, r.Query, r.Song.String(), confidence)
+	}
+
+	return w.Flush()
+}
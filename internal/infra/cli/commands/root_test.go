@@ -1,22 +1,33 @@
 package commands
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
 	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
 	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/prompts"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
 	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
 )
 
 type RootCmdTestSuite struct {
 	suite.Suite
-	LoggerMock         *mocks.LoggerMock
-	RootCmdGatewayMock *mocks.RootCmdGatewayMock
+	LoggerMock              *mocks.LoggerMock
+	RootCmdGatewayMock      *mocks.RootCmdGatewayMock
+	SetlistFMCmdGatewayMock *mocks.SetlistFMCmdGatewayMock
+	MatchReviewerMock       *mocks.MatchReviewerMock
 
 	Cmd RootCmdInterface
 }
@@ -24,10 +35,15 @@ type RootCmdTestSuite struct {
 func (s *RootCmdTestSuite) SetupTest() {
 	s.LoggerMock = new(mocks.LoggerMock)
 	s.RootCmdGatewayMock = new(mocks.RootCmdGatewayMock)
+	s.SetlistFMCmdGatewayMock = new(mocks.SetlistFMCmdGatewayMock)
+	s.MatchReviewerMock = new(mocks.MatchReviewerMock)
 
 	s.Cmd = NewRootCmd(
 		s.LoggerMock,
 		s.RootCmdGatewayMock,
+		s.SetlistFMCmdGatewayMock,
+		&config.Config{},
+		s.MatchReviewerMock,
 	)
 }
 
@@ -36,6 +52,20 @@ func (s *RootCmdTestSuite) cleanMocks() {
 	s.LoggerMock.Calls = nil
 	s.RootCmdGatewayMock.ExpectedCalls = nil
 	s.RootCmdGatewayMock.Calls = nil
+	s.SetlistFMCmdGatewayMock.ExpectedCalls = nil
+	s.SetlistFMCmdGatewayMock.Calls = nil
+	s.MatchReviewerMock.ExpectedCalls = nil
+	s.MatchReviewerMock.Calls = nil
+}
+
+const anySetlistURL = "https://www.setlist.fm/setlist/any-artist/2024/any-venue-any-set-id.html"
+
+func fetchSongsInput(set *setlistfm.Set) spotify.FindAllSongsInput {
+	return spotify.FindAllSongsInput{
+		Songs:  spotify.NewSongQueries(set.NormalizedTracks(setlistfm.NormalizeOptions{})),
+		Artist: set.ArtistName(),
+		Policy: matching.Policy{Preference: matching.PreferAny, Covers: matching.CoversFallback},
+	}
 }
 
 func TestRootCmd(t *testing.T) {
@@ -67,6 +97,45 @@ func (s *RootCmdTestSuite) TestBuild() {
 		flags := cmd.Flags()
 
 		s.NotNil(flags.Lookup("url"))
+		s.NotNil(flags.Lookup("url-file"))
+		s.NotNil(flags.Lookup("artist"))
+		s.NotNil(flags.Lookup("file"))
+		s.NotNil(flags.Lookup("merge"))
+		s.NotNil(flags.Lookup("version-preference"))
+		s.NotNil(flags.Lookup("exclude"))
+		s.NotNil(flags.Lookup("covers"))
+		s.NotNil(flags.Lookup("include-tapes"))
+		s.NotNil(flags.Lookup("yes"))
+		s.NotNil(flags.Lookup("dry-run"))
+		s.NotNil(flags.Lookup("playlist"))
+		s.NotNil(flags.Lookup("new"))
+		s.NotNil(flags.Lookup("private"))
+		s.NotNil(flags.Lookup("collaborative"))
+		s.NotNil(flags.Lookup("title"))
+		s.NotNil(flags.Lookup("description"))
+		s.NotNil(flags.Lookup("cover"))
+		s.NotNil(flags.Lookup("generate-cover"))
+	})
+
+	s.Run("Should use the matching config as flag defaults", func() {
+		cmd := NewRootCmd(s.LoggerMock, s.RootCmdGatewayMock, s.SetlistFMCmdGatewayMock, &config.Config{
+			Matching: config.Matching{
+				VersionPreference: "studio-only",
+				Exclude:           []string{"karaoke"},
+			},
+		}, s.MatchReviewerMock).Build()
+
+		s.Equal("studio-only", cmd.Flags().Lookup("version-preference").DefValue)
+		s.Equal("[karaoke]", cmd.Flags().Lookup("exclude").DefValue)
+	})
+
+	s.Run("Should use the playlist config as flag defaults", func() {
+		cmd := NewRootCmd(s.LoggerMock, s.RootCmdGatewayMock, s.SetlistFMCmdGatewayMock, &config.Config{
+			Playlist: config.Playlist{Private: true},
+		}, s.MatchReviewerMock).Build()
+
+		s.Equal("true", cmd.Flags().Lookup("private").DefValue)
+		s.Equal("false", cmd.Flags().Lookup("collaborative").DefValue)
 	})
 }
 
@@ -113,13 +182,18 @@ func (s *RootCmdTestSuite) TestRun() {
 		s.RootCmdGatewayMock.On("StartWebServer").Return()
 		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
 		s.RootCmdGatewayMock.
-			On("FetchSongsOnSpotify", mock.Anything, set.Songs(), set.ArtistName()).
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
 			Return(songs, nil)
+		s.MatchReviewerMock.On("Review", mock.Anything, songs, mock.Anything).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
 		s.RootCmdGatewayMock.
-			On("CreatePlaylistOnSpotify", mock.Anything, set.Title(), songs.Songs).
-			Return(&playlistURL, nil)
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id", URL: playlistURL}, nil)
 
 		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
 		err := cmd.RunE(cmd, []string{
 			"--url", url,
 		})
@@ -128,6 +202,36 @@ func (s *RootCmdTestSuite) TestRun() {
 
 		s.NoError(err)
 		s.Equal(s.LoggerMock.Calls[len(s.LoggerMock.Calls)-1].Arguments[0].(string), expectedMsg)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", history.Entry{
+			SetlistID:   "any-set-id",
+			Title:       set.Title(),
+			PlaylistID:  "any-playlist-id",
+			PlaylistURL: playlistURL,
+			Action:      history.ActionCreated,
+			Matched:     3,
+			Options: &history.Options{
+				VersionPreference: "any",
+				Covers:            "fallback",
+				Sets:              "combined",
+				Visibility:        "public",
+			},
+		})
+	})
+
+	s.Run("Should return an error when the version preference is invalid", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("version-preference", "prefer-bootlegs")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.Error(err)
+		s.ErrorContains(err, "unknown version preference")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
 	})
 
 	s.Run("Should return an error when failing to extract Setlist.fm ID from URL", func() {
@@ -138,6 +242,7 @@ func (s *RootCmdTestSuite) TestRun() {
 		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(nil, errors.New("any-validation-error"))
 
 		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
 		err := cmd.RunE(cmd, []string{
 			"--url", url,
 		})
@@ -156,6 +261,7 @@ func (s *RootCmdTestSuite) TestRun() {
 			Return(nil, errors.New("any-error"))
 
 		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
 		err := cmd.RunE(cmd, []string{
 			"--url", url,
 		})
@@ -171,11 +277,13 @@ func (s *RootCmdTestSuite) TestRun() {
 		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
 		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(&setlistfm.Set{}, nil)
 		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", mock.Anything).Return(nil, nil)
 		s.RootCmdGatewayMock.
 			On("HandleSpotifyAuthentication", mock.Anything).
 			Return(errors.New("any-error"))
 
 		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
 		err := cmd.RunE(cmd, []string{
 			"--url", url,
 		})
@@ -212,12 +320,14 @@ func (s *RootCmdTestSuite) TestRun() {
 		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
 		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
 		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", mock.Anything).Return(nil, nil)
 		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
 		s.RootCmdGatewayMock.
-			On("FetchSongsOnSpotify", mock.Anything, set.Songs(), set.ArtistName()).
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
 			Return(nil, errors.New("any-error"))
 
 		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
 		err := cmd.RunE(cmd, []string{
 			"--url", url,
 		})
@@ -226,6 +336,327 @@ func (s *RootCmdTestSuite) TestRun() {
 		s.ErrorContains(err, "any-error")
 	})
 
+	s.Run("Should report the songs left out of the playlist", func() {
+		defer s.cleanMocks()
+
+		set := &setlistfm.Set{
+			ID: "any-set-id",
+			Sets: setlistfm.Sets{
+				Set: []setlistfm.Songs{
+					{
+						Song: []setlistfm.Song{
+							{Name: "any-song-1"},
+							{Name: "any-song-2"},
+							{Name: "any-song-3"},
+						},
+					},
+				},
+			},
+		}
+
+		songs := &spotify.FindAllSongsOutput{
+			Artist: "any-artist",
+			Songs: []spotify.Song{
+				{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+			},
+			Results: []spotify.SongResult{
+				{Query: "any-song-1", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-1"}},
+				{Query: "any-song-2", Status: spotify.MatchStatusNotFound},
+				{Query: "any-song-3", Status: spotify.MatchStatusAmbiguous},
+			},
+		}
+
+		playlistURL := "https://open.spotify.com/playlist/any-playlist-id"
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.MatchReviewerMock.On("Review", mock.Anything, songs, mock.Anything).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id", URL: playlistURL}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		err := cmd.RunE(cmd, []string{
+			"--url", url,
+		})
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Warn", "2 of 3 songs were left out of the playlist:", mock.Anything)
+		s.LoggerMock.AssertCalled(s.T(), "Warn", `  - "any-song-2": no track found on Spotify`, mock.Anything)
+		s.LoggerMock.AssertCalled(s.T(), "Warn", `  - "any-song-3": no confident match among Spotify results`, mock.Anything)
+	})
+
+	s.Run("Should create the playlist with the reviewed songs", func() {
+		defer s.cleanMocks()
+
+		set := &setlistfm.Set{
+			ID: "any-set-id",
+			Sets: setlistfm.Sets{
+				Set: []setlistfm.Songs{
+					{
+						Song: []setlistfm.Song{
+							{Name: "any-song-1"},
+							{Name: "any-song-2"},
+						},
+					},
+				},
+			},
+		}
+
+		songs := &spotify.FindAllSongsOutput{
+			Artist: "any-artist",
+			Songs: []spotify.Song{
+				{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+			},
+			Results: []spotify.SongResult{
+				{Query: "any-song-1", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-1"}},
+				{Query: "any-song-2", Status: spotify.MatchStatusNotFound},
+			},
+		}
+
+		reviewed := &spotify.FindAllSongsOutput{
+			Artist: "any-artist",
+			Songs: []spotify.Song{
+				{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+				{ID: "any-song-id-2", Title: "any-song-2", Album: "any-album-2"},
+			},
+		}
+
+		playlistURL := "https://open.spotify.com/playlist/any-playlist-id"
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.MatchReviewerMock.On("Review", mock.Anything, songs, mock.Anything).Return(reviewed, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, reviewed.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id", URL: playlistURL}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		err := cmd.RunE(cmd, []string{
+			"--url", url,
+		})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, reviewed.Songs)
+	})
+
+	s.Run("Should skip the review when --yes is set", func() {
+		defer s.cleanMocks()
+
+		set := &setlistfm.Set{
+			ID: "any-set-id",
+			Sets: setlistfm.Sets{
+				Set: []setlistfm.Songs{
+					{
+						Song: []setlistfm.Song{
+							{Name: "any-song-1"},
+						},
+					},
+				},
+			},
+		}
+
+		songs := &spotify.FindAllSongsOutput{
+			Artist: "any-artist",
+			Songs: []spotify.Song{
+				{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+			},
+		}
+
+		playlistURL := "https://open.spotify.com/playlist/any-playlist-id"
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id", URL: playlistURL}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.MatchReviewerMock.AssertNotCalled(s.T(), "Review", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should not create the playlist when the review is cancelled", func() {
+		defer s.cleanMocks()
+
+		set := &setlistfm.Set{
+			ID: "any-set-id",
+			Sets: setlistfm.Sets{
+				Set: []setlistfm.Songs{
+					{
+						Song: []setlistfm.Song{
+							{Name: "any-song-1"},
+						},
+					},
+				},
+			},
+		}
+
+		songs := &spotify.FindAllSongsOutput{
+			Artist: "any-artist",
+			Songs: []spotify.Song{
+				{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+			},
+		}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", mock.Anything).Return(nil, nil)
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.MatchReviewerMock.
+			On("Review", mock.Anything, songs, mock.Anything).
+			Return(nil, prompts.ErrReviewCancelled)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		err := cmd.RunE(cmd, []string{
+			"--url", url,
+		})
+
+		s.ErrorIs(err, prompts.ErrReviewCancelled)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should only print the playlist on a dry run", func() {
+		defer s.cleanMocks()
+
+		set := &setlistfm.Set{
+			ID:     "any-set-id",
+			Artist: setlistfm.Artist{Name: "blink-182"},
+			Sets: setlistfm.Sets{
+				Set: []setlistfm.Songs{
+					{
+						Song: []setlistfm.Song{
+							{Name: "Dammit"},
+							{Name: "Family Reunion"},
+						},
+					},
+				},
+			},
+		}
+
+		songs := spotify.NewFindAllSongsOutput("blink-182", []spotify.SongResult{
+			{
+				Query:  "Dammit",
+				Status: spotify.MatchStatusMatched,
+				Song:   &spotify.Song{ID: "any-id", Title: "Dammit", Album: "Dude Ranch", Artists: []string{"blink-182"}},
+				Score:  0.93,
+			},
+			{Query: "Family Reunion", Status: spotify.MatchStatusNotFound},
+		})
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+
+		out := new(bytes.Buffer)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.SetOut(out)
+		cmd.Flags().Set("dry-run", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.Contains(out.String(), "Title:       "+set.Title())
+		s.Contains(out.String(), "Description: "+spotify.DefaultPlaylistDescription)
+		s.Contains(out.String(), "1 of 2 songs")
+		s.Regexp(`1\.\s+Dammit\s+Dammit - blink-182 \(Dude Ranch\)\s+93%`, out.String())
+		s.Regexp(`2\.\s+Family Reunion\s+-\s+\(no track found on Spotify\)`, out.String())
+		s.MatchReviewerMock.AssertNotCalled(s.T(), "Review", mock.Anything, mock.Anything, mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should return an error when none of the songs were found on Spotify", func() {
+		defer s.cleanMocks()
+
+		set := &setlistfm.Set{
+			ID: "any-set-id",
+			Sets: setlistfm.Sets{
+				Set: []setlistfm.Songs{
+					{
+						Song: []setlistfm.Song{
+							{Name: "any-song-1"},
+						},
+					},
+				},
+			},
+		}
+
+		songs := &spotify.FindAllSongsOutput{
+			Artist: "any-artist",
+			Results: []spotify.SongResult{
+				{Query: "any-song-1", Status: spotify.MatchStatusNotFound},
+			},
+		}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", mock.Anything).Return(nil, nil)
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.MatchReviewerMock.On("Review", mock.Anything, songs, mock.Anything).Return(songs, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		err := cmd.RunE(cmd, []string{
+			"--url", url,
+		})
+
+		s.Error(err)
+		s.ErrorContains(err, "none of the setlist songs were found on Spotify")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	s.Run("Should return an error when failing to create a playlist on Spotify", func() {
 		defer s.cleanMocks()
 
@@ -265,13 +696,18 @@ func (s *RootCmdTestSuite) TestRun() {
 		s.RootCmdGatewayMock.On("StartWebServer").Return()
 		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
 		s.RootCmdGatewayMock.
-			On("FetchSongsOnSpotify", mock.Anything, set.Songs(), set.ArtistName()).
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
 			Return(songs, nil)
+		s.MatchReviewerMock.On("Review", mock.Anything, songs, mock.Anything).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
 		s.RootCmdGatewayMock.
-			On("CreatePlaylistOnSpotify", mock.Anything, set.Title(), songs.Songs).
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, songs.Songs).
 			Return(nil, errors.New("any-error"))
 
 		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
 		err := cmd.RunE(cmd, []string{
 			"--url", url,
 		})
@@ -280,3 +716,1145 @@ func (s *RootCmdTestSuite) TestRun() {
 		s.ErrorContains(err, "any-error")
 	})
 }
+
+func (s *RootCmdTestSuite) TestRunWithExistingPlaylist() {
+	set := &setlistfm.Set{
+		ID: "any-set-id",
+		Sets: setlistfm.Sets{
+			Set: []setlistfm.Songs{
+				{
+					Song: []setlistfm.Song{
+						{Name: "any-song-1"},
+					},
+				},
+			},
+		},
+	}
+
+	songs := &spotify.FindAllSongsOutput{
+		Artist: "any-artist",
+		Songs: []spotify.Song{
+			{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+		},
+	}
+
+	playlist := &spotify.CreatePlaylistOutput{
+		ID:  "37i9dQZF1DXcBWIGoYBM5M",
+		URL: "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+	}
+
+	setupMocks := func() {
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", *playlist).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+	}
+
+	s.Run("Should update the playlist given with --playlist", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.
+			On("UpdatePlaylistOnSpotify", mock.Anything, playlist.ID, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("playlist", playlist.URL)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetLinkedPlaylist", mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RequireSpotifyScopes", []string{spotify.ScopePlaylistModifyPrivate})
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", "any-set-id", *playlist)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", mock.MatchedBy(func(e history.Entry) bool {
+			return e.Action == history.ActionUpdated && e.PlaylistID == playlist.ID
+		}))
+		s.LoggerMock.AssertCalled(s.T(), "Info", "Playlist updated successfully, check it out: "+playlist.URL, mock.Anything)
+	})
+
+	s.Run("Should update the playlist previously made from the same setlist", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.
+			On("GetLinkedPlaylist", "any-set-id").
+			Return(&spotify.PlaylistLink{
+				SetlistID:  "any-set-id",
+				PlaylistID: playlist.ID,
+				URL:        playlist.URL,
+				Visibility: "public",
+			}, nil)
+		s.RootCmdGatewayMock.
+			On("UpdatePlaylistOnSpotify", mock.Anything, playlist.ID, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "RequireSpotifyScopes", mock.Anything)
+	})
+
+	s.Run("Should ask for the private scope to update a private playlist without --private", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.
+			On("GetLinkedPlaylist", "any-set-id").
+			Return(&spotify.PlaylistLink{
+				SetlistID:  "any-set-id",
+				PlaylistID: playlist.ID,
+				URL:        playlist.URL,
+				Visibility: "private",
+			}, nil)
+		s.RootCmdGatewayMock.
+			On("UpdatePlaylistOnSpotify", mock.Anything, playlist.ID, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RequireSpotifyScopes", []string{spotify.ScopePlaylistModifyPrivate})
+	})
+
+	s.Run("Should ask for the private scope to update a playlist linked before its visibility was kept", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.
+			On("GetLinkedPlaylist", "any-set-id").
+			Return(&spotify.PlaylistLink{SetlistID: "any-set-id", PlaylistID: playlist.ID, URL: playlist.URL}, nil)
+		s.RootCmdGatewayMock.
+			On("UpdatePlaylistOnSpotify", mock.Anything, playlist.ID, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RequireSpotifyScopes", []string{spotify.ScopePlaylistModifyPrivate})
+	})
+
+	s.Run("Should create another playlist with --new", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("new", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetLinkedPlaylist", mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "UpdatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should link a partially filled playlist so the next run finishes it", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, songs.Songs).
+			Return(playlist, &spotify.PartialAddError{Added: 100, Total: 150, Err: errors.New("any-error")})
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "added 100 of 150 tracks")
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", "any-set-id", *playlist)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "RecordRun", mock.Anything)
+		s.LoggerMock.AssertCalled(
+			s.T(),
+			"Warn",
+			"Only 100 of 150 tracks were added, run the command again to finish the playlist",
+			mock.Anything,
+		)
+	})
+
+	s.Run("Should return an error when --playlist is not a playlist", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("playlist", "https://open.spotify.com/track/37i9dQZF1DXcBWIGoYBM5M")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "is not a Spotify playlist URL")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "UpdatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithPlaylistVisibility() {
+	set := &setlistfm.Set{
+		ID: "any-set-id",
+		Sets: setlistfm.Sets{
+			Set: []setlistfm.Songs{
+				{
+					Song: []setlistfm.Song{
+						{Name: "any-song-1"},
+					},
+				},
+			},
+		},
+	}
+
+	songs := &spotify.FindAllSongsOutput{
+		Artist: "any-artist",
+		Songs: []spotify.Song{
+			{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+		},
+	}
+
+	playlist := &spotify.CreatePlaylistOutput{
+		ID:  "any-playlist-id",
+		URL: "https://open.spotify.com/playlist/any-playlist-id",
+	}
+
+	setupMocks := func() {
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", *playlist).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+	}
+
+	s.Run("Should not ask for more scopes for a public playlist", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: set.Title()}, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "RequireSpotifyScopes", mock.Anything)
+	})
+
+	s.Run("Should create a private playlist with --private", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+
+		input := spotify.CreatePlaylistInput{
+			Title:      set.Title(),
+			Visibility: spotify.PlaylistVisibility{Private: true},
+		}
+
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("private", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(
+			s.T(),
+			"RequireSpotifyScopes",
+			[]string{spotify.ScopePlaylistModifyPrivate},
+		)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs)
+	})
+
+	s.Run("Should create a private collaborative playlist with --collaborative", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+
+		input := spotify.CreatePlaylistInput{
+			Title:      set.Title(),
+			Visibility: spotify.PlaylistVisibility{Private: true, Collaborative: true},
+		}
+
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs).
+			Return(playlist, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("collaborative", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs)
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithPlaylistTemplates() {
+	set := &setlistfm.Set{
+		ID:        "any-set-id",
+		EventDate: "09-03-2023",
+		Artist:    setlistfm.Artist{Name: "any-artist"},
+		Venue:     setlistfm.Venue{Name: "any-venue"},
+		Sets: setlistfm.Sets{
+			Set: []setlistfm.Songs{
+				{
+					Song: []setlistfm.Song{
+						{Name: "any-song-1"},
+					},
+				},
+			},
+		},
+	}
+
+	songs := &spotify.FindAllSongsOutput{
+		Artist: "any-artist",
+		Songs: []spotify.Song{
+			{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+		},
+	}
+
+	s.Run("Should name the playlist with the given templates", func() {
+		defer s.cleanMocks()
+
+		description := "Played at any-venue on 2023-03-09"
+		input := spotify.CreatePlaylistInput{
+			Title:       "any-artist (2023)",
+			Description: &description,
+		}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("title", "{{.Artist}} ({{year .Date}})")
+		cmd.Flags().Set("description", `Played at {{.Venue}} on {{.Date | date "2006-01-02"}}`)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs)
+	})
+
+	s.Run("Should return an error when a template is invalid", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("title", "{{.Artist")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "invalid title template")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithCover() {
+	set := &setlistfm.Set{
+		ID:        "any-set-id",
+		EventDate: "09-03-2023",
+		Artist:    setlistfm.Artist{Name: "any-artist"},
+		Venue:     setlistfm.Venue{Name: "any-venue"},
+		Sets: setlistfm.Sets{
+			Set: []setlistfm.Songs{
+				{
+					Song: []setlistfm.Song{
+						{Name: "any-song-1"},
+					},
+				},
+			},
+		},
+	}
+
+	songs := &spotify.FindAllSongsOutput{
+		Artist: "any-artist",
+		Songs: []spotify.Song{
+			{ID: "any-song-id-1", Title: "any-song-1", Album: "any-album-1"},
+		},
+	}
+
+	playlist := &spotify.CreatePlaylistOutput{ID: "any-playlist-id", URL: "any-playlist-url"}
+
+	setupMocks := func() {
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).
+			Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("CreatePlaylistOnSpotify", mock.Anything, mock.Anything, songs.Songs).Return(playlist, nil)
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", *playlist).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+	}
+
+	isJPEG := mock.MatchedBy(func(image []byte) bool {
+		return len(image) > 2 && image[0] == 0xFF && image[1] == 0xD8
+	})
+
+	s.Run("Should upload a generated cover", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.On("UploadPlaylistCover", mock.Anything, playlist.ID, isJPEG).Return(nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("generate-cover", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RequireSpotifyScopes", []string{spotify.ScopeUGCImageUpload})
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "UploadPlaylistCover", mock.Anything, playlist.ID, isJPEG)
+	})
+
+	s.Run("Should upload the cover given with --cover", func() {
+		defer s.cleanMocks()
+
+		var buf bytes.Buffer
+		png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 300)))
+
+		coverPath := filepath.Join(s.T().TempDir(), "cover.png")
+		s.NoError(os.WriteFile(coverPath, buf.Bytes(), 0600))
+
+		setupMocks()
+		s.RootCmdGatewayMock.On("UploadPlaylistCover", mock.Anything, playlist.ID, isJPEG).Return(nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("cover", coverPath)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "UploadPlaylistCover", mock.Anything, playlist.ID, isJPEG)
+	})
+
+	s.Run("Should keep the playlist when the cover upload fails", func() {
+		defer s.cleanMocks()
+
+		setupMocks()
+		s.RootCmdGatewayMock.
+			On("UploadPlaylistCover", mock.Anything, playlist.ID, mock.Anything).
+			Return(errors.New("any-error"))
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("generate-cover", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Warn", "Could not set the playlist cover: any-error", mock.Anything)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", "any-set-id", *playlist)
+	})
+
+	s.Run("Should return an error when the cover is not an image", func() {
+		defer s.cleanMocks()
+
+		coverPath := filepath.Join(s.T().TempDir(), "cover.txt")
+		s.NoError(os.WriteFile(coverPath, []byte("not an image"), 0600))
+
+		setupMocks()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("cover", coverPath)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "unsupported cover image")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "HandleSpotifyAuthentication", mock.Anything)
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithSets() {
+	set := &setlistfm.Set{
+		ID:     "any-set-id",
+		Artist: setlistfm.Artist{Name: "any-artist"},
+		Venue:  setlistfm.Venue{Name: "any-venue"},
+		Sets: setlistfm.Sets{
+			Set: []setlistfm.Songs{
+				{Song: []setlistfm.Song{{Name: "any-song-1"}, {Name: "any-song-2"}}},
+				{Encore: 1, Song: []setlistfm.Song{{Name: "any-song-3"}}},
+			},
+		},
+	}
+
+	mainSongs := []spotify.Song{{ID: "any-song-id-1"}, {ID: "any-song-id-2"}}
+	encoreSong := spotify.Song{ID: "any-song-id-3"}
+	songs := spotify.NewFindAllSongsOutput("any-artist", []spotify.SongResult{
+		{Status: spotify.MatchStatusMatched, Song: &mainSongs[0]},
+		{Status: spotify.MatchStatusMatched, Song: &mainSongs[1]},
+		{Status: spotify.MatchStatusMatched, Song: &encoreSong},
+	})
+
+	s.Run("Should create one playlist per set", func() {
+		defer s.cleanMocks()
+
+		mainInput := spotify.CreatePlaylistInput{Title: set.Title() + " (Main Set)"}
+		encoreInput := spotify.CreatePlaylistInput{Title: set.Title() + " (Encore)"}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", mock.Anything).Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", mock.Anything, mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, mainInput, mainSongs).
+			Return(&spotify.CreatePlaylistOutput{ID: "main-playlist-id"}, nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, encoreInput, []spotify.Song{encoreSong}).
+			Return(&spotify.CreatePlaylistOutput{ID: "encore-playlist-id"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("sets", "split")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "GetLinkedPlaylist", "any-set-id#Main Set")
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "GetLinkedPlaylist", "any-set-id#Encore")
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", "any-set-id#Encore", spotify.CreatePlaylistOutput{ID: "encore-playlist-id"})
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", mock.MatchedBy(func(e history.Entry) bool {
+			return e.Part == "Encore" && e.PlaylistID == "encore-playlist-id" && e.Matched == 1 && e.Title == encoreInput.Title
+		}))
+	})
+
+	s.Run("Should list the sets in the playlist description", func() {
+		defer s.cleanMocks()
+
+		description := spotify.DefaultPlaylistDescription + " — Main Set: 1-2 · Encore: 3"
+		input := spotify.CreatePlaylistInput{Title: set.Title(), Description: &description}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(set)).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("sets", "describe")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, input, songs.Songs)
+	})
+
+	s.Run("Should keep a song repeated within the show in its own set when deduping", func() {
+		defer s.cleanMocks()
+
+		reprise := &setlistfm.Set{
+			ID:     "any-set-id",
+			Artist: setlistfm.Artist{Name: "any-artist"},
+			Venue:  setlistfm.Venue{Name: "any-venue"},
+			Sets: setlistfm.Sets{
+				Set: []setlistfm.Songs{
+					{Song: []setlistfm.Song{{Name: "any-song-1"}, {Name: "any-song-1 (reprise)"}}},
+					{Encore: 1, Song: []setlistfm.Song{{Name: "any-song-2"}, {Name: "any-song-3"}}},
+				},
+			},
+		}
+
+		first := spotify.Song{ID: "any-song-id-1"}
+		encoreSongs := []spotify.Song{{ID: "any-song-id-2"}, {ID: "any-song-id-3"}}
+		repriseSongs := spotify.NewFindAllSongsOutput("any-artist", []spotify.SongResult{
+			{Status: spotify.MatchStatusMatched, Song: &first},
+			{Status: spotify.MatchStatusMatched, Song: &first},
+			{Status: spotify.MatchStatusMatched, Song: &encoreSongs[0]},
+			{Status: spotify.MatchStatusMatched, Song: &encoreSongs[1]},
+		})
+
+		mainInput := spotify.CreatePlaylistInput{Title: reprise.Title() + " (Main Set)"}
+		encoreInput := spotify.CreatePlaylistInput{Title: reprise.Title() + " (Encore)"}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", mock.Anything).Return(reprise, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(reprise)).Return(repriseSongs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", mock.Anything).Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", mock.Anything, mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, mainInput, []spotify.Song{first, first}).
+			Return(&spotify.CreatePlaylistOutput{ID: "main-playlist-id"}, nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, encoreInput, encoreSongs).
+			Return(&spotify.CreatePlaylistOutput{ID: "encore-playlist-id"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("sets", "split")
+		cmd.Flags().Set("merge", "dedupe")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, mainInput, []spotify.Song{first, first})
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, encoreInput, encoreSongs)
+	})
+
+	s.Run("Should not allow an existing playlist when splitting the sets", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("sets", "split")
+		cmd.Flags().Set("playlist", "any-playlist-id")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "--playlist can't be used with --sets split")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+	})
+
+	s.Run("Should return an error for an unknown sets option", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", anySetlistURL)
+		cmd.Flags().Set("sets", "medley")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "unknown sets mode")
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithMultipleSetlists() {
+	venue := setlistfm.Venue{Name: "any-venue"}
+	night1 := &setlistfm.Set{
+		ID:        "any-set-id-1",
+		EventDate: "09-03-2023",
+		Artist:    setlistfm.Artist{Name: "any-artist"},
+		Venue:     venue,
+		Sets:      setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-song-1"}, {Name: "any-song-2"}}}}},
+	}
+	night2 := &setlistfm.Set{
+		ID:        "any-set-id-2",
+		EventDate: "10-03-2023",
+		Artist:    setlistfm.Artist{Name: "any-artist"},
+		Venue:     venue,
+		Sets:      setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-song-2"}, {Name: "any-song-3"}}}}},
+	}
+
+	song1 := spotify.Song{ID: "any-song-id-1"}
+	song2 := spotify.Song{ID: "any-song-id-2"}
+	song3 := spotify.Song{ID: "any-song-id-3"}
+
+	songs1 := &spotify.FindAllSongsOutput{Artist: "any-artist", Songs: []spotify.Song{song1, song2}}
+	songs2 := &spotify.FindAllSongsOutput{Artist: "any-artist", Songs: []spotify.Song{song2, song3}}
+
+	title := "any-artist @ any-venue, - (March 9-10, 2023)"
+
+	mockRun := func() {
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", "any-url-1").Return(night1, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", "any-url-2").Return(night2, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(night1)).Return(songs1, nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(night2)).Return(songs2, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id-1+any-set-id-2").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id-1+any-set-id-2", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+	}
+
+	s.Run("Should put the songs of every show in one playlist", func() {
+		defer s.cleanMocks()
+
+		mockRun()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("url", "any-url-1")
+		cmd.Flags().Set("url", "any-url-2")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(
+			s.T(),
+			"CreatePlaylistOnSpotify",
+			mock.Anything,
+			spotify.CreatePlaylistInput{Title: title},
+			[]spotify.Song{song1, song2, song2, song3},
+		)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", mock.MatchedBy(func(e history.Entry) bool {
+			return e.SetlistID == "any-set-id-1+any-set-id-2" && e.Matched == 4
+		}))
+	})
+
+	s.Run("Should add songs played on several nights once", func() {
+		defer s.cleanMocks()
+
+		mockRun()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("url", "any-url-1")
+		cmd.Flags().Set("url", "any-url-2")
+		cmd.Flags().Set("merge", "dedupe")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(
+			s.T(),
+			"CreatePlaylistOnSpotify",
+			mock.Anything,
+			spotify.CreatePlaylistInput{Title: title},
+			[]spotify.Song{song1, song2, song3},
+		)
+	})
+
+	s.Run("Should read the setlist URLs from a file", func() {
+		defer s.cleanMocks()
+
+		mockRun()
+
+		path := filepath.Join(s.T().TempDir(), "shows.txt")
+		os.WriteFile(path, []byte("# residency\nany-url-1\n\n  any-url-2  \n"), 0o644)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("url-file", path)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "GetTracksFromSetlist", "any-url-1")
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "GetTracksFromSetlist", "any-url-2")
+		s.RootCmdGatewayMock.AssertNumberOfCalls(s.T(), "GetTracksFromSetlist", 2)
+	})
+
+	s.Run("Should not split the sets of several shows", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", "any-url-1")
+		cmd.Flags().Set("url", "any-url-2")
+		cmd.Flags().Set("sets", "split")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "--sets can only be used with a single setlist")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+	})
+
+	s.Run("Should return an error when no setlist is given", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "no setlist URL was given")
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithArtist() {
+	artist := &setlistfm.Artist{MBID: "any-mbid", Name: "any-artist"}
+	latest := &setlistfm.Set{
+		ID:     "any-set-id",
+		Artist: *artist,
+		Sets:   setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-song-1"}}}}},
+	}
+	latestInput := setlistfm.GetLatestSetlistInput{ArtistMBID: "any-mbid", MaxPages: latestSetlistMaxPages}
+
+	s.Run("Should convert the latest show of the artist", func() {
+		defer s.cleanMocks()
+
+		songs := &spotify.FindAllSongsOutput{Artist: "any-artist", Songs: []spotify.Song{{ID: "any-song-id-1"}}}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("FindArtist", "any-artist").Return(artist, nil)
+		s.SetlistFMCmdGatewayMock.On("GetLatestSetlist", latestInput).Return(latest, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(latest)).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: latest.Title()}, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("artist", "any-artist")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", "any-set-id", mock.Anything)
+	})
+
+	s.Run("Should return an error when the artist name is ambiguous", func() {
+		defer s.cleanMocks()
+
+		ambiguous := &setlistfm.AmbiguousArtistError{
+			Name:       "any-artist",
+			Candidates: []setlistfm.Artist{{MBID: "any-mbid-1", Name: "any-artist"}, {MBID: "any-mbid-2", Name: "any-artist"}},
+		}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("FindArtist", "any-artist").Return(nil, ambiguous)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("artist", "any-artist")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorIs(err, ambiguous)
+		s.SetlistFMCmdGatewayMock.AssertNotCalled(s.T(), "GetLatestSetlist", mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "StartWebServer")
+	})
+
+	s.Run("Should return an error when no show has songs", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("FindArtist", "any-artist").Return(artist, nil)
+		s.SetlistFMCmdGatewayMock.
+			On("GetLatestSetlist", latestInput).
+			Return(nil, errors.New("none of the latest setlists of the artist has songs yet"))
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("artist", "any-artist")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "has songs yet")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "StartWebServer")
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithFile() {
+	dir := s.T().TempDir()
+	path := filepath.Join(dir, "rehearsal.txt")
+	data := []byte("Artist: any-artist\nDate: 2024-05-18\nVenue: any-venue\n\n1. any-song-1\n2. any-song-2\n")
+	s.Require().NoError(os.WriteFile(path, data, 0o600))
+
+	show, err := setlistfm.ParseSetlistFile(path, data)
+	s.Require().NoError(err)
+
+	s.Run("Should convert the setlist written in the file", func() {
+		defer s.cleanMocks()
+
+		songs := &spotify.FindAllSongsOutput{Artist: "any-artist", Songs: []spotify.Song{{ID: "any-song-id-1"}}}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(show)).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", show.ID).Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", show.ID, mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: show.Title()}, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("file", path)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", show.ID, mock.Anything)
+	})
+
+	s.Run("Should return an error when the file has no artist", func() {
+		defer s.cleanMocks()
+
+		noArtist := filepath.Join(dir, "no-artist.txt")
+		s.Require().NoError(os.WriteFile(noArtist, []byte("any-song-1\n"), 0o600))
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("file", noArtist)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "no-artist.txt has no artist")
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "StartWebServer")
+	})
+
+	s.Run("Should return an error when the file doesn't exist", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("file", filepath.Join(dir, "missing.yaml"))
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorIs(err, os.ErrNotExist)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "StartWebServer")
+	})
+}
+
+func (s *RootCmdTestSuite) TestRunWithFestival() {
+	festivalURL := "https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html"
+	venue := setlistfm.Venue{Name: "Donington Park"}
+	qotsa := &setlistfm.Set{
+		ID:        "any-set-id-1",
+		EventDate: "14-06-2024",
+		Artist:    setlistfm.Artist{Name: "Queens of the Stone Age"},
+		Venue:     venue,
+		Sets:      setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-song-1"}}}}},
+	}
+	a7x := &setlistfm.Set{
+		ID:        "any-set-id-2",
+		EventDate: "14-06-2024",
+		Artist:    setlistfm.Artist{Name: "Avenged Sevenfold"},
+		Venue:     venue,
+		Sets:      setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-song-2"}}}}},
+	}
+	festival := &setlistfm.Festival{
+		ID:    "73d44e99",
+		Name:  "Download Festival 2024",
+		URL:   festivalURL,
+		Shows: setlistfm.Shows{qotsa, a7x},
+	}
+
+	songs1 := &spotify.FindAllSongsOutput{Artist: "Queens of the Stone Age", Songs: []spotify.Song{{ID: "any-song-id-1"}}}
+	songs2 := &spotify.FindAllSongsOutput{Artist: "Avenged Sevenfold", Songs: []spotify.Song{{ID: "any-song-id-2"}}}
+
+	mockRun := func() {
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(qotsa)).Return(songs1, nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(a7x)).Return(songs2, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", mock.Anything).Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", mock.Anything, mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+	}
+
+	s.Run("Should put the whole lineup in one playlist", func() {
+		defer s.cleanMocks()
+
+		mockRun()
+		s.SetlistFMCmdGatewayMock.
+			On("GetFestivalSetlists", setlistfm.GetFestivalSetlistsInput{
+				URL:     festivalURL,
+				Artists: setlistfm.ArtistFilter{Include: []string{}, Exclude: []string{}},
+			}).
+			Return(festival, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("url", festivalURL)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+		s.RootCmdGatewayMock.AssertNumberOfCalls(s.T(), "CreatePlaylistOnSpotify", 1)
+		s.RootCmdGatewayMock.AssertCalled(
+			s.T(),
+			"CreatePlaylistOnSpotify",
+			mock.Anything,
+			spotify.CreatePlaylistInput{Title: "Download Festival 2024 (June 14, 2024)"},
+			[]spotify.Song{{ID: "any-song-id-1"}, {ID: "any-song-id-2"}},
+		)
+	})
+
+	s.Run("Should create one playlist per artist", func() {
+		defer s.cleanMocks()
+
+		mockRun()
+		s.SetlistFMCmdGatewayMock.
+			On("GetFestivalSetlists", setlistfm.GetFestivalSetlistsInput{
+				URL:     festivalURL,
+				Date:    "14-06-2024",
+				Artists: setlistfm.ArtistFilter{Include: []string{}, Exclude: []string{"Metallica"}},
+			}).
+			Return(festival, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("yes", "true")
+		cmd.Flags().Set("url", festivalURL)
+		cmd.Flags().Set("festival-mode", "per-artist")
+		cmd.Flags().Set("festival-day", "14-06-2024")
+		cmd.Flags().Set("exclude-artist", "Metallica")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNumberOfCalls(s.T(), "StartWebServer", 1)
+		s.RootCmdGatewayMock.AssertNumberOfCalls(s.T(), "CreatePlaylistOnSpotify", 2)
+		s.RootCmdGatewayMock.AssertCalled(
+			s.T(),
+			"CreatePlaylistOnSpotify",
+			mock.Anything,
+			spotify.CreatePlaylistInput{Title: "Queens of the Stone Age @ Download Festival 2024"},
+			songs1.Songs,
+		)
+		s.RootCmdGatewayMock.AssertCalled(
+			s.T(),
+			"CreatePlaylistOnSpotify",
+			mock.Anything,
+			spotify.CreatePlaylistInput{Title: "Avenged Sevenfold @ Download Festival 2024"},
+			songs2.Songs,
+		)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", "any-set-id-1", mock.Anything)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "LinkPlaylist", "any-set-id-2", mock.Anything)
+	})
+
+	s.Run("Should return an error when a festival is combined with other setlists", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", festivalURL)
+		cmd.Flags().Set("url", anySetlistURL)
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "a festival URL can't be combined with other setlists")
+		s.SetlistFMCmdGatewayMock.AssertNotCalled(s.T(), "GetFestivalSetlists", mock.Anything)
+	})
+
+	s.Run("Should return an error for an unknown festival mode", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", festivalURL)
+		cmd.Flags().Set("festival-mode", "per-day")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "unknown festival mode")
+		s.SetlistFMCmdGatewayMock.AssertNotCalled(s.T(), "GetFestivalSetlists", mock.Anything)
+	})
+
+	s.Run("Should return an error when --playlist is given with one playlist per artist", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("url", festivalURL)
+		cmd.Flags().Set("festival-mode", "per-artist")
+		cmd.Flags().Set("playlist", "https://open.spotify.com/playlist/any-playlist-id")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "--playlist can't be used with --festival-mode per-artist")
+		s.SetlistFMCmdGatewayMock.AssertNotCalled(s.T(), "GetFestivalSetlists", mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "UpdatePlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/prompts"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type SearchCmd struct {
+	Logger    logger.LoggerInterface
+	Setlists  gateways.SetlistFMCmdGatewayInterface
+	Picker    prompts.ShowPickerInterface
+	Playlists *RootCmd
+}
+
+func NewSearchCmd(
+	l logger.LoggerInterface,
+	gw gateways.RootCmdGatewayInterface,
+	setlistsGw gateways.SetlistFMCmdGatewayInterface,
+	cfg *config.Config,
+	reviewer prompts.MatchReviewerInterface,
+	picker prompts.ShowPickerInterface,
+) RootCmdInterface {
+	return &SearchCmd{
+		Logger:    l,
+		Setlists:  setlistsGw,
+		Picker:    picker,
+		Playlists: &RootCmd{Logger: l, Gateway: gw, Setlists: setlistsGw, Config: cfg, Reviewer: reviewer},
+	}
+}
+
+func (sc *SearchCmd) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Searches Setlist.fm for shows and creates a playlist from the one you pick",
+		RunE:  sc.run,
+	}
+
+	cmd.Flags().String("artist", "", "name of the artist")
+	cmd.Flags().String("mbid", "", "MusicBrainz ID of the artist")
+	cmd.Flags().String("city", "", "name of the city")
+	cmd.Flags().String("venue", "", "name of the venue")
+	cmd.Flags().String("tour", "", "name of the tour")
+	cmd.Flags().String("date", "", "day of the show, as DD-MM-YYYY or YYYY-MM-DD")
+	cmd.Flags().String("year", "", "year of the show")
+	cmd.Flags().Int("page", 1, "page of results to start from")
+
+	sc.Playlists.addPlaylistFlags(cmd)
+
+	return cmd
+}
+
+func (sc *SearchCmd) run(cmd *cobra.Command, args []string) error {
+	artist, _ := cmd.Flags().GetString("artist")
+	mbid, _ := cmd.Flags().GetString("mbid")
+	city, _ := cmd.Flags().GetString("city")
+	venue, _ := cmd.Flags().GetString("venue")
+	tour, _ := cmd.Flags().GetString("tour")
+	date, _ := cmd.Flags().GetString("date")
+	year, _ := cmd.Flags().GetString("year")
+	page, _ := cmd.Flags().GetInt("page")
+
+	input := setlistfm.SearchSetlistsInput{
+		ArtistName: artist,
+		ArtistMBID: mbid,
+		CityName:   city,
+		VenueName:  venue,
+		TourName:   tour,
+		Date:       date,
+		Year:       year,
+		Page:       max(page, 1),
+	}
+
+	if err := input.Validate(); err != nil {
+		sc.Logger.Error("Invalid search", err, nil)
+		return err
+	}
+
+	opts, err := sc.Playlists.playlistOptions(cmd, "")
+	if err != nil {
+		return err
+	}
+
+	for {
+		sc.Logger.Info("Searching Setlist.fm...", nil)
+
+		results, err := sc.Setlists.SearchSetlists(input)
+		if err != nil {
+			sc.Logger.Error("Failed to search setlists", err, nil)
+			return err
+		}
+
+		if len(results.Setlist) == 0 {
+			err := errors.New("no setlists match the search")
+			sc.Logger.Error("Nothing found", err, nil)
+			return err
+		}
+
+		if err := sc.printShows(cmd, results); err != nil {
+			return err
+		}
+
+		// Shows that didn't happen yet (or weren't filled in) have nothing to convert.
+		var playable []setlistfm.Set
+		for _, s := range results.Setlist {
+			if len(s.Songs()) > 0 {
+				playable = append(playable, s)
+			}
+		}
+
+		if len(playable) == 0 && results.IsLast() {
+			err := errors.New("none of the matching shows has a setlist yet")
+			sc.Logger.Error("Nothing to convert", err, nil)
+			return err
+		}
+
+		choice, err := sc.Picker.Pick(playable, !results.IsLast())
+		if errors.Is(err, prompts.ErrPickCancelled) {
+			sc.Logger.Info("No setlist was picked, nothing was created", nil)
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if choice == prompts.PickNextPage {
+			input.Page++
+			continue
+		}
+
+		picked := playable[choice]
+
+		return sc.Playlists.convert(cmd, opts, setlistfm.Shows{&picked})
+	}
+}
+
+func (sc *SearchCmd) printShows(cmd *cobra.Command, results *setlistfm.SetlistPage) error {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "%d setlists found, page %d:\n\n", results.Total, results.Page)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for _, s := range results.Setlist {
+		place := strings.Trim(fmt.Sprintf("%s, %s", s.Venue.City.Name, s.Venue.City.Country.Name), ", ")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d songs\n", s.EventDate, s.Artist.Name, s.Venue.Name, place, len(s.Songs()))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	return nil
+}
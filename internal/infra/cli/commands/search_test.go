@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/prompts"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SearchCmdTestSuite struct {
+	suite.Suite
+	LoggerMock              *mocks.LoggerMock
+	RootCmdGatewayMock      *mocks.RootCmdGatewayMock
+	SetlistFMCmdGatewayMock *mocks.SetlistFMCmdGatewayMock
+	MatchReviewerMock       *mocks.MatchReviewerMock
+	ShowPickerMock          *mocks.ShowPickerMock
+
+	Cmd  RootCmdInterface
+	Show setlistfm.Set
+}
+
+func (s *SearchCmdTestSuite) SetupTest() {
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.RootCmdGatewayMock = new(mocks.RootCmdGatewayMock)
+	s.SetlistFMCmdGatewayMock = new(mocks.SetlistFMCmdGatewayMock)
+	s.MatchReviewerMock = new(mocks.MatchReviewerMock)
+	s.ShowPickerMock = new(mocks.ShowPickerMock)
+
+	s.Cmd = NewSearchCmd(
+		s.LoggerMock,
+		s.RootCmdGatewayMock,
+		s.SetlistFMCmdGatewayMock,
+		&config.Config{},
+		s.MatchReviewerMock,
+		s.ShowPickerMock,
+	)
+
+	s.Show = setlistfm.Set{
+		ID:     "any-set-id",
+		Artist: setlistfm.Artist{Name: "any-artist"},
+		Venue:  setlistfm.Venue{Name: "any-venue"},
+		Sets:   setlistfm.Sets{Set: []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-song-1"}}}}},
+	}
+}
+
+func (s *SearchCmdTestSuite) cleanMocks() {
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+	s.RootCmdGatewayMock.ExpectedCalls = nil
+	s.RootCmdGatewayMock.Calls = nil
+	s.SetlistFMCmdGatewayMock.ExpectedCalls = nil
+	s.SetlistFMCmdGatewayMock.Calls = nil
+	s.ShowPickerMock.ExpectedCalls = nil
+	s.ShowPickerMock.Calls = nil
+}
+
+func TestSearchCmd(t *testing.T) {
+	suite.Run(t, new(SearchCmdTestSuite))
+}
+
+func (s *SearchCmdTestSuite) TestRun() {
+	s.Run("Should convert the picked show", func() {
+		defer s.cleanMocks()
+
+		upcoming := setlistfm.Set{ID: "upcoming-set-id", Artist: setlistfm.Artist{Name: "any-artist"}}
+		page := &setlistfm.SetlistPage{Setlist: []setlistfm.Set{upcoming, s.Show}, Total: 2, Page: 1, ItemsPerPage: 20}
+		songs := &spotify.FindAllSongsOutput{Songs: []spotify.Song{{ID: "any-song-id-1"}}}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.
+			On("SearchSetlists", setlistfm.SearchSetlistsInput{ArtistName: "any-artist", Year: "2024", Page: 1}).
+			Return(page, nil)
+		s.ShowPickerMock.On("Pick", []setlistfm.Set{s.Show}, false).Return(0, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(&s.Show)).Return(songs, nil)
+		s.RootCmdGatewayMock.On("GetLinkedPlaylist", "any-set-id").Return(nil, nil)
+		s.RootCmdGatewayMock.On("LinkPlaylist", "any-set-id", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.
+			On("CreatePlaylistOnSpotify", mock.Anything, spotify.CreatePlaylistInput{Title: s.Show.Title()}, songs.Songs).
+			Return(&spotify.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+
+		out := new(bytes.Buffer)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(out)
+		cmd.Flags().Set("artist", "any-artist")
+		cmd.Flags().Set("year", "2024")
+		cmd.Flags().Set("yes", "true")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.Contains(out.String(), "2 setlists found")
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "CreatePlaylistOnSpotify", mock.Anything, mock.Anything, songs.Songs)
+	})
+
+	s.Run("Should load the next page when asked to", func() {
+		defer s.cleanMocks()
+
+		first := &setlistfm.SetlistPage{Setlist: []setlistfm.Set{s.Show}, Total: 40, Page: 1, ItemsPerPage: 20}
+		second := &setlistfm.SetlistPage{Setlist: []setlistfm.Set{s.Show}, Total: 40, Page: 2, ItemsPerPage: 20}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("SearchSetlists", setlistfm.SearchSetlistsInput{VenueName: "any-venue", Page: 1}).Return(first, nil)
+		s.SetlistFMCmdGatewayMock.On("SearchSetlists", setlistfm.SearchSetlistsInput{VenueName: "any-venue", Page: 2}).Return(second, nil)
+		s.ShowPickerMock.On("Pick", mock.Anything, true).Return(prompts.PickNextPage, nil)
+		s.ShowPickerMock.On("Pick", mock.Anything, false).Return(0, prompts.ErrPickCancelled)
+
+		cmd := s.Cmd.Build()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.Flags().Set("venue", "any-venue")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.NoError(err)
+		s.SetlistFMCmdGatewayMock.AssertNumberOfCalls(s.T(), "SearchSetlists", 2)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "StartWebServer")
+	})
+
+	s.Run("Should return an error when nothing matches", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.SetlistFMCmdGatewayMock.On("SearchSetlists", mock.Anything).Return(&setlistfm.SetlistPage{Page: 1}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.Flags().Set("city", "any-city")
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "no setlists match the search")
+		s.ShowPickerMock.AssertNotCalled(s.T(), "Pick", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should return an error without search criteria", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		cmd := s.Cmd.Build()
+
+		err := cmd.RunE(cmd, []string{})
+
+		s.ErrorContains(err, "give at least one of")
+		s.SetlistFMCmdGatewayMock.AssertNotCalled(s.T(), "SearchSetlists", mock.Anything)
+	})
+}
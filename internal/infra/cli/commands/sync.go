@@ -0,0 +1,345 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	spotify_entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+type SyncCmd struct {
+	Logger  logger.LoggerInterface
+	Gateway gateways.RootCmdGatewayInterface
+	History gateways.HistoryCmdGatewayInterface
+	Config  *config.Config
+}
+
+type syncTarget struct {
+	Entry history.Entry
+	Set   *setlistfm.Set
+}
+
+func NewSyncCmd(
+	l logger.LoggerInterface,
+	gw gateways.RootCmdGatewayInterface,
+	historyGw gateways.HistoryCmdGatewayInterface,
+	cfg *config.Config,
+) RootCmdInterface {
+	return &SyncCmd{
+		Logger:  l,
+		Gateway: gw,
+		History: historyGw,
+		Config:  cfg,
+	}
+}
+
+func (sc *SyncCmd) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Updates the playlists whose setlist was edited on Setlist.fm since they were generated",
+		RunE:  sc.run,
+	}
+
+	cmd.Flags().String("setlist", "", "only sync the playlist of this setlist ID")
+
+	return cmd
+}
+
+func (sc *SyncCmd) run(cmd *cobra.Command, args []string) error {
+	only, _ := cmd.Flags().GetString("setlist")
+
+	policy, err := matching.NewPolicy(
+		sc.Config.Matching.VersionPreference,
+		sc.Config.Matching.Exclude,
+		sc.Config.Matching.Covers,
+	)
+	if err != nil {
+		sc.Logger.Error("Invalid matching options", err, nil)
+		return err
+	}
+
+	entries, err := sc.History.ListHistory(history.Filter{LatestOnly: true})
+	if err != nil {
+		sc.Logger.Error("Failed to read the history", err, nil)
+		return err
+	}
+
+	var (
+		targets []syncTarget
+		checked int
+		skipped int
+		failed  int
+	)
+
+	for _, e := range entries {
+		if only != "" && e.SetlistID != only {
+			continue
+		}
+
+		// Combined shows, setlist files and predictions don't match a setlist on Setlist.fm.
+		if e.SetlistURL == "" {
+			sc.Logger.Warn(fmt.Sprintf("Skipping %s, it wasn't made from a single Setlist.fm setlist", e.Title), nil)
+			skipped++
+			continue
+		}
+
+		checked++
+
+		set, err := sc.Gateway.GetTracksFromSetlist(e.SetlistURL)
+		if err != nil {
+			sc.Logger.Error(fmt.Sprintf("Failed to fetch setlist %s", e.SetlistID), err, nil)
+			failed++
+			continue
+		}
+
+		if set.VersionID == e.VersionID {
+			sc.Logger.Info(fmt.Sprintf("%s is up to date", set.Title()), nil)
+			continue
+		}
+
+		targets = append(targets, syncTarget{Entry: e, Set: set})
+	}
+
+	if skipped > 0 {
+		sc.Logger.Info(fmt.Sprintf("%d playlists skipped, they can't be synced", skipped), nil)
+	}
+
+	if checked == 0 {
+		sc.Logger.Info("No playlists to sync", nil)
+		return nil
+	}
+
+	if len(targets) > 0 {
+		sc.Gateway.StartWebServer()
+
+		if scopes := syncScopes(targets); len(scopes) > 0 {
+			sc.Gateway.RequireSpotifyScopes(scopes)
+		}
+
+		if err := sc.Gateway.HandleSpotifyAuthentication(cmd.Context()); err != nil {
+			sc.Logger.Error("Failed to authenticate on Spotify", err, nil)
+			return err
+		}
+	}
+
+	for _, t := range targets {
+		if err := sc.sync(cmd, t, policy); err != nil {
+			sc.Logger.Error(fmt.Sprintf("Failed to sync %s", t.Set.Title()), err, nil)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d playlists could not be synced", failed, checked)
+	}
+
+	return nil
+}
+
+// syncScopes returns the scopes needed to change the tracks of the playlists, which depend on
+// their visibility.
+func syncScopes(targets []syncTarget) []string {
+	var scopes []string
+
+	for _, t := range targets {
+		visibility := ""
+		if t.Entry.Options != nil {
+			visibility = t.Entry.Options.Visibility
+		}
+
+		for _, scope := range spotify_entities.UpdateScopes(visibility) {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	return scopes
+}
+
+// sync matches the songs with the options the playlist was made with, falling back to the
+// configured ones for playlists recorded without them.
+func (sc *SyncCmd) sync(cmd *cobra.Command, t syncTarget, policy matching.Policy) error {
+	sc.Logger.Info(fmt.Sprintf("%s was edited on Setlist.fm, syncing %s...", t.Set.Title(), t.Entry.PlaylistURL), nil)
+
+	includeTapes := false
+
+	if o := t.Entry.Options; o != nil {
+		var err error
+
+		if policy, err = o.Policy(); err != nil {
+			return err
+		}
+
+		includeTapes = o.IncludeTapes
+	}
+
+	songs, err := sc.matchSongs(cmd, t, findAllSongsInput(t.Set, policy, includeTapes))
+	if err != nil {
+		return err
+	}
+
+	if t.Entry.Part != "" {
+		if songs, err = partSongs(t.Set, t.Entry.Part, includeTapes, songs); err != nil {
+			return err
+		}
+	}
+
+	if len(songs.Songs) == 0 {
+		return fmt.Errorf("none of the setlist songs were found on Spotify")
+	}
+
+	diff, err := sc.Gateway.SyncPlaylistOnSpotify(cmd.Context(), t.Entry.PlaylistID, songs.Songs)
+	if err != nil {
+		return err
+	}
+
+	sc.reportDiff(diff)
+
+	if err := sc.Gateway.RecordRun(history.Entry{
+		SetlistID:   t.Set.ID,
+		SetlistURL:  t.Set.URL,
+		Part:        t.Entry.Part,
+		VersionID:   t.Set.VersionID,
+		Artist:      t.Set.ArtistName(),
+		EventDate:   t.Set.EventDate,
+		Title:       t.Entry.Title,
+		PlaylistID:  t.Entry.PlaylistID,
+		PlaylistURL: t.Entry.PlaylistURL,
+		Action:      history.ActionSynced,
+		Matched:     len(songs.Songs),
+		Unmatched:   len(songs.Unmatched()),
+		Options:     t.Entry.Options,
+		Picks:       picks(songs.Results),
+	}); err != nil {
+		sc.Logger.Warn(fmt.Sprintf("Could not record this run in the history: %s", err), nil)
+	}
+
+	return nil
+}
+
+// matchSongs keeps the tracks picked for the songs that are still in the setlist, so choices
+// made in the match review aren't lost, and only searches Spotify for new or renamed songs.
+func (sc *SyncCmd) matchSongs(
+	cmd *cobra.Command,
+	t syncTarget,
+	input spotify_entities.FindAllSongsInput,
+) (*spotify_entities.FindAllSongsOutput, error) {
+	picked := make(map[string][]history.Pick)
+	for _, p := range t.Entry.Picks {
+		key := matching.NormalizeTitle(p.Song)
+		picked[key] = append(picked[key], p)
+	}
+
+	results := make([]spotify_entities.SongResult, len(input.Songs))
+
+	var (
+		missIndexes []int
+		missSongs   []spotify_entities.SongQuery
+	)
+
+	for i, q := range input.Songs {
+		key := matching.NormalizeTitle(q.Title)
+
+		if len(picked[key]) == 0 {
+			missIndexes = append(missIndexes, i)
+			missSongs = append(missSongs, q)
+			continue
+		}
+
+		results[i] = pickResult(q, picked[key][0])
+		picked[key] = picked[key][1:]
+	}
+
+	if len(missSongs) < len(input.Songs) {
+		sc.Logger.Info(
+			fmt.Sprintf("Keeping the tracks picked for %d songs, searching %d new or changed ones", len(input.Songs)-len(missSongs), len(missSongs)),
+			nil,
+		)
+	}
+
+	if len(missSongs) > 0 {
+		missInput := input
+		missInput.Songs = missSongs
+
+		found, err := sc.Gateway.FetchSongsOnSpotify(cmd.Context(), missInput)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, res := range found.Results {
+			results[missIndexes[j]] = res
+		}
+	}
+
+	return spotify_entities.NewFindAllSongsOutput(input.Artist, results), nil
+}
+
+func pickResult(q spotify_entities.SongQuery, p history.Pick) spotify_entities.SongResult {
+	if p.TrackID == "" {
+		return spotify_entities.SongResult{Query: q.Title, Status: spotify_entities.MatchStatusSkipped}
+	}
+
+	return spotify_entities.SongResult{
+		Query:  q.Title,
+		Status: spotify_entities.MatchStatusMatched,
+		Song:   &spotify_entities.Song{ID: p.TrackID, Title: p.Title, Album: p.Album, Artists: p.Artists},
+	}
+}
+
+// partSongs keeps the songs of one set of the show, for playlists made with --sets split.
+func partSongs(
+	set *setlistfm.Set,
+	label string,
+	includeTapes bool,
+	songs *spotify_entities.FindAllSongsOutput,
+) (*spotify_entities.FindAllSongsOutput, error) {
+	parts := set.Parts(setlistfm.NormalizeOptions{IncludeTapes: includeTapes})
+	sizes := make([]int, len(parts))
+
+	for i, p := range parts {
+		sizes[i] = len(p.Songs)
+	}
+
+	for i, p := range songs.Split(sizes) {
+		if parts[i].Label == label {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the setlist no longer has a set named %q", label)
+}
+
+func (sc *SyncCmd) reportDiff(diff *spotify_entities.PlaylistDiff) {
+	if diff.IsEmpty() {
+		sc.Logger.Info("The playlist already had the right tracks", nil)
+		return
+	}
+
+	reordered := diff.Reordered()
+
+	sc.Logger.Info(
+		fmt.Sprintf("%d added, %d removed, %d reordered", len(diff.Added), len(diff.Removed), len(reordered)),
+		nil,
+	)
+
+	for _, s := range diff.Added {
+		sc.Logger.Info(fmt.Sprintf("  + %s", s.String()), nil)
+	}
+
+	for _, r := range diff.Removed {
+		sc.Logger.Info(fmt.Sprintf("  - %s", r.Song.String()), nil)
+	}
+
+	for _, s := range reordered {
+		sc.Logger.Info(fmt.Sprintf("  ~ %s", s.String()), nil)
+	}
+}
@@ -0,0 +1,374 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/config"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SyncCmdTestSuite struct {
+	suite.Suite
+	LoggerMock            *mocks.LoggerMock
+	RootCmdGatewayMock    *mocks.RootCmdGatewayMock
+	HistoryCmdGatewayMock *mocks.HistoryCmdGatewayMock
+
+	Cmd   RootCmdInterface
+	Entry history.Entry
+	Set   *setlistfm.Set
+}
+
+func (s *SyncCmdTestSuite) SetupTest() {
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.RootCmdGatewayMock = new(mocks.RootCmdGatewayMock)
+	s.HistoryCmdGatewayMock = new(mocks.HistoryCmdGatewayMock)
+
+	s.Cmd = NewSyncCmd(s.LoggerMock, s.RootCmdGatewayMock, s.HistoryCmdGatewayMock, &config.Config{})
+
+	s.Entry = history.Entry{
+		SetlistID:   "any-set-id",
+		SetlistURL:  "https://www.setlist.fm/setlist/blink182/2024/any-venue-any-set-id.html",
+		VersionID:   "v1",
+		PlaylistID:  "any-playlist-id",
+		PlaylistURL: "https://open.spotify.com/playlist/any-playlist-id",
+		Title:       "any-playlist-title",
+	}
+
+	s.Set = &setlistfm.Set{
+		ID:        "any-set-id",
+		VersionID: "v2",
+		Sets: setlistfm.Sets{
+			Set: []setlistfm.Songs{
+				{Song: []setlistfm.Song{{Name: "any-song-1"}, {Name: "any-song-2"}}},
+			},
+		},
+	}
+}
+
+func (s *SyncCmdTestSuite) cleanMocks() {
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+	s.RootCmdGatewayMock.ExpectedCalls = nil
+	s.RootCmdGatewayMock.Calls = nil
+	s.HistoryCmdGatewayMock.ExpectedCalls = nil
+	s.HistoryCmdGatewayMock.Calls = nil
+}
+
+func TestSyncCmd(t *testing.T) {
+	suite.Run(t, new(SyncCmdTestSuite))
+}
+
+func (s *SyncCmdTestSuite) TestRun() {
+	s.Run("Should apply the diff when the setlist version changed", func() {
+		defer s.cleanMocks()
+
+		songs := spotify.NewFindAllSongsOutput("", []spotify.SongResult{
+			{Query: "any-song-1", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-1"}},
+			{Query: "any-song-2", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-2"}},
+		})
+		diff := &spotify.PlaylistDiff{Added: []spotify.Song{{ID: "any-song-id-2", Title: "any-song-2"}}}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{s.Entry}, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", s.Entry.SetlistURL).Return(s.Set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(s.Set)).Return(songs, nil)
+		s.RootCmdGatewayMock.On("SyncPlaylistOnSpotify", mock.Anything, "any-playlist-id", songs.Songs).Return(diff, nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Info", "1 added, 0 removed, 0 reordered", mock.Anything)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", mock.MatchedBy(func(e history.Entry) bool {
+			return e.Action == history.ActionSynced && e.VersionID == "v2" && e.PlaylistID == "any-playlist-id" &&
+				e.Title == "any-playlist-title"
+		}))
+	})
+
+	s.Run("Should not touch Spotify when the setlist did not change", func() {
+		defer s.cleanMocks()
+
+		s.Set.VersionID = "v1"
+		defer func() { s.Set.VersionID = "v2" }()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{s.Entry}, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", s.Entry.SetlistURL).Return(s.Set, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "HandleSpotifyAuthentication", mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "SyncPlaylistOnSpotify", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should only sync the given setlist", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{s.Entry}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{"--setlist", "another-set-id"})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Info", "No playlists to sync", mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+	})
+
+	s.Run("Should keep going and report the setlists that failed", func() {
+		defer s.cleanMocks()
+
+		other := s.Entry
+		other.SetlistID = "other-set-id"
+		other.SetlistURL = "https://www.setlist.fm/setlist/blink182/2024/any-venue-other-set-id.html"
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.
+			On("ListHistory", history.Filter{LatestOnly: true}).
+			Return([]history.Entry{s.Entry, other}, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", s.Entry.SetlistURL).Return(nil, errors.New("any-error"))
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", other.SetlistURL).Return(&setlistfm.Set{VersionID: "v1"}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.ErrorContains(err, "1 of 2 playlists could not be synced")
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "GetTracksFromSetlist", other.SetlistURL)
+	})
+
+	s.Run("Should only sync the songs of the set a split playlist was made for", func() {
+		defer s.cleanMocks()
+
+		entry := s.Entry
+		entry.Part = "Encore"
+
+		set := *s.Set
+		set.Sets.Set = append(set.Sets.Set, setlistfm.Songs{Encore: 1, Song: []setlistfm.Song{{Name: "any-song-3"}}})
+
+		encoreSong := spotify.Song{ID: "any-song-id-3"}
+		songs := spotify.NewFindAllSongsOutput("", []spotify.SongResult{
+			{Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-1"}},
+			{Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-2"}},
+			{Status: spotify.MatchStatusMatched, Song: &encoreSong},
+		})
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{entry}, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", entry.SetlistURL).Return(&set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, fetchSongsInput(&set)).Return(songs, nil)
+		s.RootCmdGatewayMock.
+			On("SyncPlaylistOnSpotify", mock.Anything, "any-playlist-id", []spotify.Song{encoreSong}).
+			Return(&spotify.PlaylistDiff{}, nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", mock.MatchedBy(func(e history.Entry) bool {
+			return e.Part == "Encore" && e.Matched == 1
+		}))
+	})
+
+	s.Run("Should match the songs with the options the playlist was made with", func() {
+		defer s.cleanMocks()
+
+		entry := s.Entry
+		entry.Options = &history.Options{VersionPreference: "studio-only", Exclude: []string{"karaoke"}, Covers: "original", IncludeTapes: true}
+
+		set := *s.Set
+		set.Sets.Set = []setlistfm.Songs{{Song: []setlistfm.Song{{Name: "any-intro", Tape: true}, {Name: "any-song-1"}}}}
+
+		input := spotify.FindAllSongsInput{
+			Songs: spotify.NewSongQueries(set.NormalizedTracks(setlistfm.NormalizeOptions{IncludeTapes: true})),
+			Policy: matching.Policy{
+				Preference: matching.StudioOnly,
+				Exclude:    []matching.Variant{matching.VariantKaraoke},
+				Covers:     matching.CoversOriginal,
+			},
+		}
+		songs := spotify.NewFindAllSongsOutput("", []spotify.SongResult{
+			{Query: "any-intro", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-intro-id"}},
+			{Query: "any-song-1", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-1"}},
+		})
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{entry}, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", entry.SetlistURL).Return(&set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, input).Return(songs, nil)
+		s.RootCmdGatewayMock.On("SyncPlaylistOnSpotify", mock.Anything, "any-playlist-id", songs.Songs).Return(&spotify.PlaylistDiff{}, nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "FetchSongsOnSpotify", mock.Anything, input)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", mock.MatchedBy(func(e history.Entry) bool {
+			return e.Options == entry.Options
+		}))
+	})
+
+	s.Run("Should keep the tracks picked for songs still in the setlist", func() {
+		defer s.cleanMocks()
+
+		entry := s.Entry
+		entry.Picks = []history.Pick{
+			{Song: "any-song-1", TrackID: "hand-picked-id", Title: "any-song-1 (single)"},
+			{Song: "any-removed-song", TrackID: "any-removed-id"},
+		}
+
+		newSong := spotify.Song{ID: "any-song-id-2"}
+		found := spotify.NewFindAllSongsOutput("", []spotify.SongResult{
+			{Query: "any-song-2", Status: spotify.MatchStatusMatched, Song: &newSong},
+		})
+		input := fetchSongsInput(s.Set)
+		input.Songs = input.Songs[1:]
+		tracks := []spotify.Song{{ID: "hand-picked-id", Title: "any-song-1 (single)"}, newSong}
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{entry}, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", entry.SetlistURL).Return(s.Set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, input).Return(found, nil)
+		s.RootCmdGatewayMock.On("SyncPlaylistOnSpotify", mock.Anything, "any-playlist-id", tracks).Return(&spotify.PlaylistDiff{}, nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "SyncPlaylistOnSpotify", mock.Anything, "any-playlist-id", tracks)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RecordRun", mock.MatchedBy(func(e history.Entry) bool {
+			return len(e.Picks) == 2 && e.Picks[0].TrackID == "hand-picked-id" && e.Picks[1].TrackID == "any-song-id-2"
+		}))
+	})
+
+	s.Run("Should report the playlists that can't be synced as skipped", func() {
+		defer s.cleanMocks()
+
+		merged := s.Entry
+		merged.SetlistURL = ""
+		merged.Title = "any-merged-playlist"
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{merged}, nil)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.LoggerMock.AssertCalled(s.T(), "Warn", "Skipping any-merged-playlist, it wasn't made from a single Setlist.fm setlist", mock.Anything)
+		s.LoggerMock.AssertCalled(s.T(), "Info", "1 playlists skipped, they can't be synced", mock.Anything)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "GetTracksFromSetlist", mock.Anything)
+	})
+}
+
+func (s *SyncCmdTestSuite) TestScopes() {
+	songs := spotify.NewFindAllSongsOutput("", []spotify.SongResult{
+		{Query: "any-song-1", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-1"}},
+		{Query: "any-song-2", Status: spotify.MatchStatusMatched, Song: &spotify.Song{ID: "any-song-id-2"}},
+	})
+
+	setupMocks := func(entry history.Entry) {
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return()
+		s.HistoryCmdGatewayMock.On("ListHistory", history.Filter{LatestOnly: true}).Return([]history.Entry{entry}, nil)
+		s.RootCmdGatewayMock.On("GetTracksFromSetlist", entry.SetlistURL).Return(s.Set, nil)
+		s.RootCmdGatewayMock.On("StartWebServer").Return()
+		s.RootCmdGatewayMock.On("RequireSpotifyScopes", mock.Anything).Return()
+		s.RootCmdGatewayMock.On("HandleSpotifyAuthentication", mock.Anything).Return(nil)
+		s.RootCmdGatewayMock.On("FetchSongsOnSpotify", mock.Anything, mock.Anything).Return(songs, nil)
+		s.RootCmdGatewayMock.On("SyncPlaylistOnSpotify", mock.Anything, "any-playlist-id", songs.Songs).Return(&spotify.PlaylistDiff{}, nil)
+		s.RootCmdGatewayMock.On("RecordRun", mock.Anything).Return(nil)
+	}
+
+	s.Run("Should ask for the private scope to sync a private playlist", func() {
+		defer s.cleanMocks()
+
+		entry := s.Entry
+		entry.Options = &history.Options{VersionPreference: "any", Covers: "fallback", Visibility: "private"}
+
+		setupMocks(entry)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RequireSpotifyScopes", []string{spotify.ScopePlaylistModifyPrivate})
+	})
+
+	s.Run("Should ask for the private scope when the visibility wasn't recorded", func() {
+		defer s.cleanMocks()
+
+		setupMocks(s.Entry)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertCalled(s.T(), "RequireSpotifyScopes", []string{spotify.ScopePlaylistModifyPrivate})
+	})
+
+	s.Run("Should not ask for more scopes to sync a public playlist", func() {
+		defer s.cleanMocks()
+
+		entry := s.Entry
+		entry.Options = &history.Options{VersionPreference: "any", Covers: "fallback", Visibility: "public"}
+
+		setupMocks(entry)
+
+		cmd := s.Cmd.Build()
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+
+		s.NoError(err)
+		s.RootCmdGatewayMock.AssertNotCalled(s.T(), "RequireSpotifyScopes", mock.Anything)
+	})
+}
@@ -0,0 +1,196 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/huh"
+
+	spotify_entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+)
+
+const (
+	reviewDone   = -1
+	reviewCancel = -2
+
+	editSearch = -1
+	editSkip   = -2
+	editBack   = -3
+)
+
+var (
+	ErrReviewCancelled = errors.New("match review cancelled")
+)
+
+type SearchFunc func(ctx context.Context, query string) ([]spotify_entities.Song, error)
+
+type MatchReviewerInterface interface {
+	Review(
+		ctx context.Context,
+		songs *spotify_entities.FindAllSongsOutput,
+		search SearchFunc,
+	) (*spotify_entities.FindAllSongsOutput, error)
+}
+
+type MatchReviewer struct{}
+
+func NewMatchReviewer() MatchReviewerInterface {
+	return &MatchReviewer{}
+}
+
+func (r *MatchReviewer) Review(
+	ctx context.Context,
+	songs *spotify_entities.FindAllSongsOutput,
+	search SearchFunc,
+) (*spotify_entities.FindAllSongsOutput, error) {
+	results := slices.Clone(songs.Results)
+
+	for {
+		choice := reviewDone
+
+		options := []huh.Option[int]{
+			huh.NewOption("✔ Looks good, create the playlist", reviewDone),
+		}
+
+		for i, res := range results {
+			options = append(options, huh.NewOption(DescribeResult(i, res), i))
+		}
+
+		options = append(options, huh.NewOption("✖ Cancel", reviewCancel))
+
+		if err := huh.NewSelect[int]().
+			Title("Review the songs before creating the playlist").
+			Description("Pick a song to change its match").
+			Options(options...).
+			Value(&choice).
+			Run(); err != nil {
+			return nil, err
+		}
+
+		switch choice {
+		case reviewDone:
+			return spotify_entities.NewFindAllSongsOutput(songs.Artist, results), nil
+		case reviewCancel:
+			return nil, ErrReviewCancelled
+		}
+
+		updated, err := r.edit(ctx, results[choice], songs.Artist, search)
+		if err != nil {
+			return nil, err
+		}
+
+		results[choice] = *updated
+	}
+}
+
+func (r *MatchReviewer) edit(
+	ctx context.Context,
+	res spotify_entities.SongResult,
+	artist string,
+	search SearchFunc,
+) (*spotify_entities.SongResult, error) {
+	choice := editBack
+
+	options := make([]huh.Option[int], 0, len(res.Candidates)+3)
+	for i, c := range res.Candidates {
+		options = append(options, huh.NewOption(c.String(), i))
+	}
+
+	options = append(
+		options,
+		huh.NewOption("🔍 Search manually...", editSearch),
+		huh.NewOption("⏭ Skip this song", editSkip),
+		huh.NewOption("↩ Back", editBack),
+	)
+
+	if err := huh.NewSelect[int]().
+		Title(fmt.Sprintf("Pick a track for %q", res.Query)).
+		Options(options...).
+		Value(&choice).
+		Run(); err != nil {
+		return nil, err
+	}
+
+	switch choice {
+	case editBack:
+		return &res, nil
+	case editSkip:
+		res.Status = spotify_entities.MatchStatusSkipped
+		res.Song = nil
+		return &res, nil
+	case editSearch:
+		return r.searchManually(ctx, res, artist, search)
+	}
+
+	return withSong(res, res.Candidates[choice]), nil
+}
+
+func (r *MatchReviewer) searchManually(
+	ctx context.Context,
+	res spotify_entities.SongResult,
+	artist string,
+	search SearchFunc,
+) (*spotify_entities.SongResult, error) {
+	query := fmt.Sprintf("%s %s", res.Query, artist)
+
+	if err := huh.NewInput().
+		Title("Search Spotify for").
+		Prompt(">").
+		Value(&query).
+		Run(); err != nil {
+		return nil, err
+	}
+
+	found, err := search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		if err := huh.NewNote().Title(fmt.Sprintf("Nothing found for %q", query)).Run(); err != nil {
+			return nil, err
+		}
+
+		return &res, nil
+	}
+
+	choice := editBack
+
+	options := make([]huh.Option[int], 0, len(found)+1)
+	for i, s := range found {
+		options = append(options, huh.NewOption(s.String(), i))
+	}
+
+	options = append(options, huh.NewOption("↩ Back", editBack))
+
+	if err := huh.NewSelect[int]().
+		Title(fmt.Sprintf("Pick a track for %q", res.Query)).
+		Options(options...).
+		Value(&choice).
+		Run(); err != nil {
+		return nil, err
+	}
+
+	if choice == editBack {
+		return &res, nil
+	}
+
+	return withSong(res, found[choice]), nil
+}
+
+func withSong(res spotify_entities.SongResult, song spotify_entities.Song) *spotify_entities.SongResult {
+	res.Status = spotify_entities.MatchStatusMatched
+	res.Song = &song
+
+	return &res
+}
+
+func DescribeResult(i int, res spotify_entities.SongResult) string {
+	if res.Status == spotify_entities.MatchStatusMatched && res.Song != nil {
+		return fmt.Sprintf("%2d. %s → %s", i+1, res.Query, res.Song.String())
+	}
+
+	return fmt.Sprintf("%2d. %s → (%s)", i+1, res.Query, res.Status.Reason())
+}
@@ -0,0 +1,69 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+)
+
+const (
+	// PickNextPage is returned by ShowPicker.Pick when the next page of results is asked for.
+	PickNextPage = -1
+
+	pickCancel = -2
+)
+
+var (
+	ErrPickCancelled = errors.New("no setlist was picked")
+)
+
+type ShowPickerInterface interface {
+	Pick(shows []setlistfm.Set, hasMore bool) (int, error)
+}
+
+type ShowPicker struct{}
+
+func NewShowPicker() ShowPickerInterface {
+	return &ShowPicker{}
+}
+
+// Pick asks which of the shows to convert and returns its index.
+func (p *ShowPicker) Pick(shows []setlistfm.Set, hasMore bool) (int, error) {
+	choice := pickCancel
+
+	options := make([]huh.Option[int], 0, len(shows)+2)
+	for i, s := range shows {
+		options = append(options, huh.NewOption(DescribeShow(s), i))
+	}
+
+	if hasMore {
+		options = append(options, huh.NewOption("↓ More results", PickNextPage))
+	}
+
+	options = append(options, huh.NewOption("✖ Cancel", pickCancel))
+
+	if err := huh.NewSelect[int]().
+		Title("Pick the show to create a playlist from").
+		Options(options...).
+		Value(&choice).
+		Run(); err != nil {
+		return 0, err
+	}
+
+	if choice == pickCancel {
+		return 0, ErrPickCancelled
+	}
+
+	return choice, nil
+}
+
+// DescribeShow sums a show up in one line, e.g. "09-03-2023  Paramore @ Allianz Parque, São Paulo (24 songs)".
+func DescribeShow(s setlistfm.Set) string {
+	place := strings.Trim(fmt.Sprintf("%s, %s", s.Venue.Name, s.Venue.City.Name), ", ")
+
+	return fmt.Sprintf("%s  %s @ %s (%d songs)", s.EventDate, s.Artist.Name, place, len(s.Songs()))
+}
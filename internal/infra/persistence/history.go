@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence/strategies"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type HistoryPersistenceInterface interface {
+	Read() (*history.History, error)
+	Write(data history.History) error
+}
+
+type HistoryPersistence struct {
+	Strategy strategies.PersistenceStrategyInterface
+	Logger   logger.LoggerInterface
+}
+
+func NewHistoryPersistence(
+	strategy strategies.PersistenceStrategyInterface,
+	logger logger.LoggerInterface,
+) HistoryPersistenceInterface {
+	return &HistoryPersistence{
+		Strategy: strategy,
+		Logger:   logger,
+	}
+}
+
+func (p *HistoryPersistence) Read() (*history.History, error) {
+	data, err := p.Strategy.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	h := history.History{}
+	if len(data) == 0 {
+		return &h, nil
+	}
+
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
+
+func (p *HistoryPersistence) Write(data history.History) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return p.Strategy.Write(dataBytes)
+}
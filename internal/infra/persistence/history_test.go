@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type HistoryPersistenceTestSuite struct {
+	suite.Suite
+	StrategyMock *mocks.PersistenceStrategyMock
+	LoggerMock   *mocks.LoggerMock
+
+	Persistence HistoryPersistenceInterface
+}
+
+func (s *HistoryPersistenceTestSuite) SetupTest() {
+	s.StrategyMock = new(mocks.PersistenceStrategyMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.Persistence = NewHistoryPersistence(s.StrategyMock, s.LoggerMock)
+}
+
+func (s *HistoryPersistenceTestSuite) cleanMocks() {
+	s.StrategyMock.ExpectedCalls = nil
+	s.StrategyMock.Calls = nil
+}
+
+func TestHistoryPersistence(t *testing.T) {
+	suite.Run(t, new(HistoryPersistenceTestSuite))
+}
+
+func (s *HistoryPersistenceTestSuite) TestReadAndWrite() {
+	s.Run("Should read back what was written", func() {
+		defer s.cleanMocks()
+
+		data := history.History{Entries: []history.Entry{
+			{
+				SetlistID:   "53aa1325",
+				VersionID:   "any-version",
+				Artist:      "blink-182",
+				EventDate:   "07-04-2024",
+				PlaylistID:  "any-playlist-id",
+				PlaylistURL: "https://open.spotify.com/playlist/any-playlist-id",
+				Action:      history.ActionCreated,
+				Matched:     20,
+				Unmatched:   1,
+				CreatedAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			},
+		}}
+
+		var written []byte
+
+		s.StrategyMock.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+			written = args.Get(0).([]byte)
+		}).Return(nil)
+
+		s.NoError(s.Persistence.Write(data))
+
+		s.StrategyMock.On("Read").Return(written, nil)
+
+		out, err := s.Persistence.Read()
+
+		s.NoError(err)
+		s.Equal(data, *out)
+	})
+
+	s.Run("Should read an empty file as an empty history", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Read").Return([]byte{}, nil)
+
+		out, err := s.Persistence.Read()
+
+		s.NoError(err)
+		s.Empty(out.Entries)
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Read").Return(nil, errors.New("any-error"))
+
+		out, err := s.Persistence.Read()
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
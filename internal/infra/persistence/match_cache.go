@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence/strategies"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type MatchCachePersistenceInterface interface {
+	Read() (*cache.MatchCache, error)
+	Write(data cache.MatchCache) error
+}
+
+type MatchCachePersistence struct {
+	Strategy strategies.PersistenceStrategyInterface
+	Logger   logger.LoggerInterface
+}
+
+func NewMatchCachePersistence(
+	strategy strategies.PersistenceStrategyInterface,
+	logger logger.LoggerInterface,
+) MatchCachePersistenceInterface {
+	return &MatchCachePersistence{
+		Strategy: strategy,
+		Logger:   logger,
+	}
+}
+
+func (p *MatchCachePersistence) Read() (*cache.MatchCache, error) {
+	data, err := p.Strategy.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	c := cache.MatchCache{Entries: map[string]cache.Entry{}}
+	if len(data) == 0 {
+		return &c, nil
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (p *MatchCachePersistence) Write(data cache.MatchCache) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return p.Strategy.Write(dataBytes)
+}
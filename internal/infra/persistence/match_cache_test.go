@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type MatchCachePersistenceTestSuite struct {
+	suite.Suite
+	StrategyMock *mocks.PersistenceStrategyMock
+	LoggerMock   *mocks.LoggerMock
+
+	Persistence MatchCachePersistenceInterface
+}
+
+func (s *MatchCachePersistenceTestSuite) SetupTest() {
+	s.StrategyMock = new(mocks.PersistenceStrategyMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.Persistence = NewMatchCachePersistence(s.StrategyMock, s.LoggerMock)
+}
+
+func (s *MatchCachePersistenceTestSuite) cleanMocks() {
+	s.StrategyMock.ExpectedCalls = nil
+	s.StrategyMock.Calls = nil
+}
+
+func TestMatchCachePersistence(t *testing.T) {
+	suite.Run(t, new(MatchCachePersistenceTestSuite))
+}
+
+func (s *MatchCachePersistenceTestSuite) TestReadAndWrite() {
+	s.Run("Should read back what was written", func() {
+		defer s.cleanMocks()
+
+		storedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		data := cache.MatchCache{Entries: map[string]cache.Entry{
+			"any-key": {
+				Result: spotify.SongResult{
+					Query:  "Dammit",
+					Status: spotify.MatchStatusMatched,
+					Song:   &spotify.Song{ID: "any-id", Title: "Dammit"},
+				},
+				StoredAt: storedAt,
+			},
+		}}
+
+		var written []byte
+
+		s.StrategyMock.On("Write", mock.Anything).Run(func(args mock.Arguments) {
+			written = args.Get(0).([]byte)
+		}).Return(nil)
+
+		s.NoError(s.Persistence.Write(data))
+
+		s.StrategyMock.On("Read").Return(written, nil)
+
+		out, err := s.Persistence.Read()
+
+		s.NoError(err)
+		s.Equal(data, *out)
+	})
+
+	s.Run("Should read an empty file as an empty cache", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Read").Return([]byte{}, nil)
+
+		out, err := s.Persistence.Read()
+
+		s.NoError(err)
+		s.Empty(out.Entries)
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Read").Return(nil, errors.New("any-error"))
+
+		out, err := s.Persistence.Read()
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
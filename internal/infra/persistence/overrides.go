@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence/strategies"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type OverridesPersistenceInterface interface {
+	Read() (*overrides.Overrides, error)
+	Write(data overrides.Overrides) error
+}
+
+type OverridesPersistence struct {
+	Strategy strategies.PersistenceStrategyInterface
+	Logger   logger.LoggerInterface
+}
+
+func NewOverridesPersistence(
+	strategy strategies.PersistenceStrategyInterface,
+	logger logger.LoggerInterface,
+) OverridesPersistenceInterface {
+	return &OverridesPersistence{
+		Strategy: strategy,
+		Logger:   logger,
+	}
+}
+
+func (p *OverridesPersistence) Read() (*overrides.Overrides, error) {
+	data, err := p.Strategy.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	var o overrides.Overrides
+	if err := toml.Unmarshal(data, &o); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (p *OverridesPersistence) Write(data overrides.Overrides) error {
+	dataBytes, err := toml.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return p.Strategy.Write(dataBytes)
+}
@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type OverridesPersistenceTestSuite struct {
+	suite.Suite
+	StrategyMock *mocks.PersistenceStrategyMock
+	LoggerMock   *mocks.LoggerMock
+
+	Persistence OverridesPersistenceInterface
+}
+
+func (s *OverridesPersistenceTestSuite) SetupTest() {
+	s.StrategyMock = new(mocks.PersistenceStrategyMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.Persistence = NewOverridesPersistence(s.StrategyMock, s.LoggerMock)
+}
+
+func (s *OverridesPersistenceTestSuite) cleanMocks() {
+	s.StrategyMock.ExpectedCalls = nil
+	s.StrategyMock.Calls = nil
+}
+
+func TestOverridesPersistence(t *testing.T) {
+	suite.Run(t, new(OverridesPersistenceTestSuite))
+}
+
+func (s *OverridesPersistenceTestSuite) TestRead() {
+	s.Run("Should read overrides from TOML", func() {
+		defer s.cleanMocks()
+
+		data := []byte(`
+[[override]]
+artist = "blink-182"
+song = "Dammit"
+track = "4uLU6hMCjMI75M1A2tKUQC"
+
+[[override]]
+artist = "blink-182"
+song = "Family Reunion"
+track = "skip"
+`)
+
+		s.StrategyMock.On("Read").Return(data, nil)
+
+		out, err := s.Persistence.Read()
+
+		s.NoError(err)
+		s.Equal([]overrides.Override{
+			{Artist: "blink-182", Song: "Dammit", Track: "4uLU6hMCjMI75M1A2tKUQC"},
+			{Artist: "blink-182", Song: "Family Reunion", Track: "skip"},
+		}, out.Overrides)
+	})
+
+	s.Run("Should read an empty file", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Read").Return([]byte{}, nil)
+
+		out, err := s.Persistence.Read()
+
+		s.NoError(err)
+		s.Empty(out.Overrides)
+	})
+
+	s.Run("Should return an error when the file is not valid TOML", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Read").Return([]byte("[[override"), nil)
+
+		out, err := s.Persistence.Read()
+
+		s.Error(err)
+		s.Nil(out)
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Read").Return(nil, errors.New("any-error"))
+
+		out, err := s.Persistence.Read()
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
+
+func (s *OverridesPersistenceTestSuite) TestWrite() {
+	s.Run("Should write overrides as TOML", func() {
+		defer s.cleanMocks()
+
+		s.StrategyMock.On("Write", mock.Anything).Return(nil)
+
+		err := s.Persistence.Write(overrides.Overrides{Overrides: []overrides.Override{
+			{Artist: "blink-182", Song: "Family Reunion", Track: "skip"},
+		}})
+
+		s.NoError(err)
+
+		written := s.StrategyMock.Calls[0].Arguments[0].([]byte)
+		s.Contains(string(written), "[[override]]")
+		s.Contains(string(written), "song = 'Family Reunion'")
+	})
+}
@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence/strategies"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type PlaylistLinksPersistenceInterface interface {
+	Read() (*spotify.PlaylistLinks, error)
+	Write(data spotify.PlaylistLinks) error
+}
+
+type PlaylistLinksPersistence struct {
+	Strategy strategies.PersistenceStrategyInterface
+	Logger   logger.LoggerInterface
+}
+
+func NewPlaylistLinksPersistence(
+	strategy strategies.PersistenceStrategyInterface,
+	logger logger.LoggerInterface,
+) PlaylistLinksPersistenceInterface {
+	return &PlaylistLinksPersistence{
+		Strategy: strategy,
+		Logger:   logger,
+	}
+}
+
+func (p *PlaylistLinksPersistence) Read() (*spotify.PlaylistLinks, error) {
+	data, err := p.Strategy.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	links := spotify.PlaylistLinks{Links: map[string]spotify.PlaylistLink{}}
+	if len(data) == 0 {
+		return &links, nil
+	}
+
+	if err := json.Unmarshal(data, &links); err != nil {
+		return nil, err
+	}
+
+	return &links, nil
+}
+
+func (p *PlaylistLinksPersistence) Write(data spotify.PlaylistLinks) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return p.Strategy.Write(dataBytes)
+}
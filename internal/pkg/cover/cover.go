@@ -0,0 +1,260 @@
+package cover
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"strings"
+
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
+)
+
+const (
+	// Size is the width and height of generated covers.
+	Size = 640
+	// MaxBytes keeps the image under the 256 KB Spotify accepts once it is base64 encoded.
+	MaxBytes = 192 * 1024
+
+	margin = 48
+)
+
+var (
+	qualities = []int{90, 75, 60, 45}
+	textColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// Details are the lines printed on a generated cover.
+type Details struct {
+	Artist string
+	Venue  string
+	City   string
+	Date   string
+}
+
+// Generate renders a cover for the details and encodes it as a JPEG.
+func Generate(d Details) ([]byte, error) {
+	return encode(Render(d))
+}
+
+// Render draws the artist in large type over a gradient picked from the artist name, with
+// the venue and city below it and the date at the bottom.
+func Render(d Details) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, Size, Size))
+	fillGradient(img, d.Artist)
+
+	width := Size - 2*margin
+	bottom := Size - margin
+
+	// The details are laid out from the bottom up, the artist gets whatever space is left.
+	for _, text := range []string{d.Date, d.City, d.Venue} {
+		if text == "" {
+			continue
+		}
+
+		scale, lines := fit(text, width, 6, 3, 2)
+		bottom -= blockHeight(len(lines), scale)
+		drawLines(img, lines, scale, bottom)
+		bottom -= 4 * scale
+	}
+
+	height := bottom - margin - 4*glyphHeight
+	scale := 14
+
+	for ; scale > 3; scale-- {
+		if _, lines := fit(d.Artist, width, scale, scale, 8); blockHeight(len(lines), scale) <= height {
+			break
+		}
+	}
+
+	_, lines := fit(d.Artist, width, scale, scale, height/((glyphHeight+3)*scale))
+	drawLines(img, lines, scale, margin)
+
+	return img
+}
+
+// Prepare makes a user supplied image (JPEG, PNG or GIF) fit what Spotify accepts: JPEGs
+// already small enough are kept as they are, anything else is re-encoded and shrunk if needed.
+func Prepare(data []byte) ([]byte, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported cover image: %w", err)
+	}
+
+	if format == "jpeg" && len(data) <= MaxBytes {
+		return data, nil
+	}
+
+	return encode(img)
+}
+
+func encode(img image.Image) ([]byte, error) {
+	for img.Bounds().Dx() >= 64 {
+		for _, quality := range qualities {
+			var buf bytes.Buffer
+
+			if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+				return nil, err
+			}
+
+			if buf.Len() <= MaxBytes {
+				return buf.Bytes(), nil
+			}
+		}
+
+		img = halve(img)
+	}
+
+	return nil, errors.New("the cover image is too large")
+}
+
+func halve(img image.Image) image.Image {
+	b := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx()/2, b.Dy()/2))
+
+	for y := 0; y < out.Bounds().Dy(); y++ {
+		for x := 0; x < out.Bounds().Dx(); x++ {
+			out.Set(x, y, img.At(b.Min.X+x*2, b.Min.Y+y*2))
+		}
+	}
+
+	return out
+}
+
+func fillGradient(img *image.RGBA, seed string) {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(seed)))
+	hue := float64(h.Sum32()%360) / 360
+
+	top := hsvToRGB(hue, 0.65, 0.55)
+	bottom := hsvToRGB(hue+0.12, 0.8, 0.2)
+
+	for y := 0; y < Size; y++ {
+		t := float64(y) / float64(Size-1)
+		c := color.RGBA{
+			R: lerp(top.R, bottom.R, t),
+			G: lerp(top.G, bottom.G, t),
+			B: lerp(top.B, bottom.B, t),
+			A: 255,
+		}
+
+		draw.Draw(img, image.Rect(0, y, Size, y+1), &image.Uniform{C: c}, image.Point{}, draw.Src)
+	}
+}
+
+// fit finds the largest scale, between min and max, at which text wraps into at most
+// maxLines lines of the given width. Text that doesn't fit even at min is clipped.
+func fit(text string, width int, max int, min int, maxLines int) (int, []string) {
+	text = strings.ToUpper(matching.FoldAccents(strings.ReplaceAll(text, "’", "'")))
+
+	for scale := max; scale > min; scale-- {
+		if lines := wrap(text, width/advance(scale)); len(lines) <= maxLines {
+			return scale, lines
+		}
+	}
+
+	lines := wrap(text, width/advance(min))
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+
+	return min, lines
+}
+
+func wrap(text string, maxChars int) []string {
+	var lines []string
+	var line string
+
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) <= maxChars:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func drawLines(img *image.RGBA, lines []string, scale int, y int) int {
+	for _, line := range lines {
+		x := margin
+
+		for _, r := range line {
+			drawGlyph(img, glyph(r), x, y, scale)
+			x += advance(scale)
+		}
+
+		y += (glyphHeight + 3) * scale
+	}
+
+	return y
+}
+
+func drawGlyph(img *image.RGBA, g [glyphHeight]string, x int, y int, scale int) {
+	for row, bits := range g {
+		for col, bit := range bits {
+			if bit != '#' {
+				continue
+			}
+
+			px := x + col*scale
+			py := y + row*scale
+			draw.Draw(img, image.Rect(px, py, px+scale, py+scale), &image.Uniform{C: textColor}, image.Point{}, draw.Src)
+		}
+	}
+}
+
+func blockHeight(lines int, scale int) int {
+	return lines*(glyphHeight+3)*scale - 3*scale
+}
+
+func advance(scale int) int {
+	return (glyphWidth + 1) * scale
+}
+
+func lerp(a uint8, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
+
+func hsvToRGB(h float64, s float64, v float64) color.RGBA {
+	h -= float64(int(h))
+	i := int(h * 6)
+	f := h*6 - float64(i)
+	p := v * (1 - s)
+	q := v * (1 - f*s)
+	t := v * (1 - (1-f)*s)
+
+	var r, g, b float64
+
+	switch i % 6 {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+
+	return color.RGBA{R: uint8(r * 255), G: uint8(g * 255), B: uint8(b * 255), A: 255}
+}
@@ -0,0 +1,130 @@
+package cover
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CoverTestSuite struct {
+	suite.Suite
+}
+
+func TestCover(t *testing.T) {
+	suite.Run(t, new(CoverTestSuite))
+}
+
+func (s *CoverTestSuite) TestGenerate() {
+	s.Run("Should render a square JPEG small enough for Spotify", func() {
+		data, err := Generate(Details{
+			Artist: "Paramore",
+			Venue:  "Allianz Parque",
+			City:   "São Paulo, Brazil",
+			Date:   "March 9, 2023",
+		})
+
+		s.NoError(err)
+		s.LessOrEqual(len(data), MaxBytes)
+
+		img, format, err := image.Decode(bytes.NewReader(data))
+
+		s.NoError(err)
+		s.Equal("jpeg", format)
+		s.Equal(image.Rect(0, 0, Size, Size), img.Bounds())
+	})
+
+	s.Run("Should pick the same background for the same artist", func() {
+		a := Render(Details{Artist: "Paramore"})
+		b := Render(Details{Artist: "paramore"})
+		c := Render(Details{Artist: "Pixies"})
+
+		s.Equal(a.At(Size-1, Size/2), b.At(Size-1, Size/2))
+		s.NotEqual(a.At(Size-1, Size/2), c.At(Size-1, Size/2))
+	})
+
+	s.Run("Should draw the artist name", func() {
+		img := Render(Details{Artist: "I"})
+
+		// The top bar of the largest "I" starts one font pixel after the margin.
+		s.Equal(textColor, img.RGBAAt(margin+14, margin))
+	})
+}
+
+func (s *CoverTestSuite) TestFit() {
+	s.Run("Should keep short names on a single large line", func() {
+		scale, lines := fit("Muse", 544, 14, 4, 4)
+
+		s.Equal(14, scale)
+		s.Equal([]string{"MUSE"}, lines)
+	})
+
+	s.Run("Should shrink and wrap long names", func() {
+		scale, lines := fit("King Gizzard & the Lizard Wizard", 544, 14, 4, 4)
+
+		s.Less(scale, 14)
+		s.LessOrEqual(len(lines), 4)
+		s.Equal("KING", lines[0][:4])
+	})
+
+	s.Run("Should fold accents the font doesn't have", func() {
+		_, lines := fit("Björk", 544, 6, 3, 1)
+
+		s.Equal([]string{"BJORK"}, lines)
+	})
+}
+
+func (s *CoverTestSuite) TestPrepare() {
+	s.Run("Should keep a small JPEG as it is", func() {
+		var buf bytes.Buffer
+		jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 100, 100)), nil)
+
+		data, err := Prepare(buf.Bytes())
+
+		s.NoError(err)
+		s.Equal(buf.Bytes(), data)
+	})
+
+	s.Run("Should convert other formats to JPEG", func() {
+		var buf bytes.Buffer
+		png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 100, 100)))
+
+		data, err := Prepare(buf.Bytes())
+
+		s.NoError(err)
+
+		_, format, err := image.Decode(bytes.NewReader(data))
+		s.NoError(err)
+		s.Equal("jpeg", format)
+	})
+
+	s.Run("Should shrink images too large for Spotify", func() {
+		rng := rand.New(rand.NewSource(1))
+		img := image.NewRGBA(image.Rect(0, 0, 1200, 1200))
+
+		for y := 0; y < 1200; y++ {
+			for x := 0; x < 1200; x++ {
+				img.Set(x, y, color.RGBA{R: uint8(rng.Intn(256)), G: uint8(rng.Intn(256)), B: uint8(rng.Intn(256)), A: 255})
+			}
+		}
+
+		var buf bytes.Buffer
+		png.Encode(&buf, img)
+
+		data, err := Prepare(buf.Bytes())
+
+		s.NoError(err)
+		s.LessOrEqual(len(data), MaxBytes)
+	})
+
+	s.Run("Should return an error for files that aren't images", func() {
+		_, err := Prepare([]byte("not an image"))
+
+		s.ErrorContains(err, "unsupported cover image")
+	})
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/mathcale/setlist-to-playlist/config"
 	"github.com/mathcale/setlist-to-playlist/internal/clients/setlistfm"
 	spotify_client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
 	"github.com/mathcale/setlist-to-playlist/internal/infra/cli"
 	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands"
 	rootcmd_gw "github.com/mathcale/setlist-to-playlist/internal/infra/cli/commands/gateways"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/prompts"
 	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
 	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence/drivers"
 	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence/strategies/plaintext"
@@ -18,8 +20,12 @@ import (
 	spotify_handlers "github.com/mathcale/setlist-to-playlist/internal/infra/web/handlers/spotify"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/httpclient"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/matching"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/oauth2"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/responsehandler"
+	cache_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/cache"
+	history_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/history"
+	overrides_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/overrides"
 	setlistfm_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/setlistfm"
 	spotify_ucs "github.com/mathcale/setlist-to-playlist/internal/usecases/spotify"
 	spotify_uc_gw "github.com/mathcale/setlist-to-playlist/internal/usecases/spotify/gateways"
@@ -70,11 +76,22 @@ func (di *DependencyInjector) Inject() (*Dependencies, error) {
 		di.Config.SetlistFM.APIKey,
 	)
 
-	spotifyClient := spotify_client.NewSpotifyClient(
+	setlistFMWebClient := setlistfm.NewSetlistFMWebClient(
+		httpclient.NewHttpClient(
+			di.Config.SetlistFM.WebURL,
+			time.Duration(di.Config.SetlistFM.Timeout)*time.Millisecond,
+		),
+	)
+
+	var spotifyClient spotify_client.SpotifyClientInterface = spotify_client.NewSpotifyClient(
 		l,
 		di.Config.Spotify.RedirectURL,
 		di.Config.Spotify.ClientID,
 		di.Config.Spotify.ClientSecret,
+		di.Config.Spotify.SearchConcurrency,
+		di.Config.Matching.Candidates,
+		di.Config.Spotify.Market,
+		matching.NewMatcher(di.Config.Matching.Threshold),
 	)
 
 	plainTextPersistence := plaintext.NewPlainTextPersistenceStrategy(
@@ -85,6 +102,40 @@ func (di *DependencyInjector) Inject() (*Dependencies, error) {
 
 	spotifyAuthPersistence := persistence.NewSpotifyAuthPersistence(plainTextPersistence, l)
 
+	matchCachePersistence := persistence.NewMatchCachePersistence(
+		plaintext.NewPlainTextPersistenceStrategy(fsDriver, l, di.ConfigPaths.MatchCacheFile),
+		l,
+	)
+
+	if di.Config.Cache.Enabled {
+		spotifyClient = spotify_client.NewCachedSpotifyClient(
+			spotifyClient,
+			matchCachePersistence,
+			l,
+			cache.Search{
+				Market:     di.Config.Spotify.Market,
+				Threshold:  di.Config.Matching.Threshold,
+				Candidates: di.Config.Matching.Candidates,
+			},
+			di.Config.Cache.TTL,
+		)
+	}
+
+	playlistLinksPersistence := persistence.NewPlaylistLinksPersistence(
+		plaintext.NewPlainTextPersistenceStrategy(fsDriver, l, di.ConfigPaths.PlaylistsFile),
+		l,
+	)
+
+	historyPersistence := persistence.NewHistoryPersistence(
+		plaintext.NewPlainTextPersistenceStrategy(fsDriver, l, di.ConfigPaths.HistoryFile),
+		l,
+	)
+
+	overridesPersistence := persistence.NewOverridesPersistence(
+		plaintext.NewPlainTextPersistenceStrategy(fsDriver, l, di.ConfigPaths.OverridesFile),
+		l,
+	)
+
 	spotifyUserAuthenticationUseCaseGateway := spotify_uc_gw.
 		NewSpotifyUserAuthenticationUseCaseGateway(
 			spotifyClient,
@@ -95,9 +146,20 @@ func (di *DependencyInjector) Inject() (*Dependencies, error) {
 
 	spotifyCallbackUseCase := spotify_ucs.NewSpotifyAuthCallbackUseCase(spotifyClient, l)
 	getSetlistByIDUseCase := setlistfm_ucs.NewGetSetlistByIDUseCase(setlistFMClient)
-	fetchSongsOnSpotifyUseCase := spotify_ucs.NewFetchSongsOnSpotifyUseCase(spotifyClient, l)
+	fetchSongsOnSpotifyUseCase := spotify_ucs.NewFetchSongsOnSpotifyUseCase(
+		spotifyClient,
+		overridesPersistence,
+		l,
+	)
+	searchTracksOnSpotifyUseCase := spotify_ucs.NewSearchTracksOnSpotifyUseCase(spotifyClient, l)
 	createPlaylistOnSpotifyUseCase := spotify_ucs.NewCreatePlaylistUseCase(spotifyClient, l)
 	addTracksToSpotifyPlaylistUseCase := spotify_ucs.NewAddTracksToPlaylistUseCase(spotifyClient, l)
+	updatePlaylistOnSpotifyUseCase := spotify_ucs.NewUpdatePlaylistUseCase(spotifyClient, l)
+	syncPlaylistOnSpotifyUseCase := spotify_ucs.NewSyncPlaylistUseCase(spotifyClient, l)
+	uploadPlaylistCoverUseCase := spotify_ucs.NewUploadPlaylistCoverUseCase(spotifyClient, l)
+	getLinkedPlaylistUseCase := spotify_ucs.NewGetLinkedPlaylistUseCase(playlistLinksPersistence)
+	linkPlaylistUseCase := spotify_ucs.NewLinkPlaylistUseCase(playlistLinksPersistence, l)
+	recordRunUseCase := history_ucs.NewRecordRunUseCase(historyPersistence, l)
 	spotifyUserAuthenticationUseCase := spotify_ucs.NewSpotifyUserAuthenticationUseCase(
 		spotifyUserAuthenticationUseCaseGateway,
 	)
@@ -120,16 +182,72 @@ func (di *DependencyInjector) Inject() (*Dependencies, error) {
 		spotifyClient,
 		getSetlistByIDUseCase,
 		fetchSongsOnSpotifyUseCase,
+		searchTracksOnSpotifyUseCase,
 		createPlaylistOnSpotifyUseCase,
 		addTracksToSpotifyPlaylistUseCase,
+		updatePlaylistOnSpotifyUseCase,
+		syncPlaylistOnSpotifyUseCase,
+		uploadPlaylistCoverUseCase,
+		getLinkedPlaylistUseCase,
+		linkPlaylistUseCase,
+		recordRunUseCase,
 		spotifyUserAuthenticationUseCase,
 		*genCodes,
 		state,
 		ch,
 	)
 
-	rootCmd := commands.NewRootCmd(l, rootCmdGw)
-	cli := cli.NewCLI(rootCmd.Build())
+	overridesCmdGw := rootcmd_gw.NewOverridesCmdGateway(
+		overrides_ucs.NewAddOverrideUseCase(overridesPersistence, l),
+		overrides_ucs.NewListOverridesUseCase(overridesPersistence),
+		overrides_ucs.NewRemoveOverrideUseCase(overridesPersistence, l),
+	)
+
+	setlistFMCmdGw := rootcmd_gw.NewSetlistFMCmdGateway(
+		setlistfm_ucs.NewFindArtistUseCase(setlistFMClient),
+		setlistfm_ucs.NewGetTourSetlistsUseCase(setlistFMClient, l),
+		setlistfm_ucs.NewSearchSetlistsUseCase(setlistFMClient),
+		setlistfm_ucs.NewGetLatestSetlistUseCase(setlistFMClient, l),
+		setlistfm_ucs.NewGetFestivalSetlistsUseCase(setlistFMClient, setlistFMWebClient, l),
+	)
+
+	rootCmd := commands.NewRootCmd(l, rootCmdGw, setlistFMCmdGw, di.Config, prompts.NewMatchReviewer())
+	overridesCmd := commands.NewOverridesCmd(l, overridesCmdGw)
+
+	cacheCmdGw := rootcmd_gw.NewCacheCmdGateway(
+		cache_ucs.NewClearMatchCacheUseCase(matchCachePersistence, l),
+		cache_ucs.NewGetMatchCacheStatsUseCase(matchCachePersistence, di.Config.Cache.TTL),
+	)
+
+	cacheCmd := commands.NewCacheCmd(l, cacheCmdGw)
+
+	historyCmdGw := rootcmd_gw.NewHistoryCmdGateway(
+		history_ucs.NewListHistoryUseCase(historyPersistence),
+		history_ucs.NewGetSetlistHistoryUseCase(historyPersistence),
+	)
+
+	historyCmd := commands.NewHistoryCmd(l, historyCmdGw)
+	syncCmd := commands.NewSyncCmd(l, rootCmdGw, historyCmdGw, di.Config)
+
+	predictCmd := commands.NewPredictCmd(l, rootCmdGw, setlistFMCmdGw, di.Config, prompts.NewMatchReviewer())
+	searchCmd := commands.NewSearchCmd(
+		l,
+		rootCmdGw,
+		setlistFMCmdGw,
+		di.Config,
+		prompts.NewMatchReviewer(),
+		prompts.NewShowPicker(),
+	)
+
+	cli := cli.NewCLI(
+		rootCmd.Build(),
+		overridesCmd.Build(),
+		cacheCmd.Build(),
+		historyCmd.Build(),
+		syncCmd.Build(),
+		predictCmd.Build(),
+		searchCmd.Build(),
+	)
 
 	return &Dependencies{
 		CLI: cli,
@@ -3,14 +3,24 @@ package httpclient
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 )
 
+// StatusError is returned when the server answers with a status other than 200.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code [%d]", e.StatusCode)
+}
+
 type HttpClientInterface interface {
 	Get(endpoint string, headers map[string]interface{}, responseObj interface{}) error
+	GetBody(endpoint string, headers map[string]interface{}) ([]byte, error)
 }
 
 type HttpClient struct {
@@ -29,36 +39,75 @@ func (c *HttpClient) Get(endpoint string, headers map[string]interface{}, respon
 	httpCtx, cancel := context.WithTimeout(context.Background(), c.Timeout)
 	defer cancel()
 
-	path := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
-	req, err := http.NewRequestWithContext(httpCtx, "GET", path, nil)
+	req, err := c.newRequest(httpCtx, endpoint, headers)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
 
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Accept", "application/json")
+	defer resp.Body.Close()
 
-	for k, v := range headers {
-		req.Header.Add(k, fmt.Sprintf("%v", v))
+	if err := json.NewDecoder(resp.Body).Decode(&responseObj); err != nil {
+		return err
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	return nil
+}
 
+// GetBody returns the raw response body, for endpoints that don't answer JSON.
+func (c *HttpClient) GetBody(endpoint string, headers map[string]interface{}) ([]byte, error) {
+	httpCtx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	req, err := c.newRequest(httpCtx, endpoint, headers)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return errors.New(fmt.Sprintf("unexpected status code [%d]", resp.StatusCode))
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	if err := json.NewDecoder(resp.Body).Decode(&responseObj); err != nil {
-		return err
+	return io.ReadAll(resp.Body)
+}
+
+func (c *HttpClient) newRequest(ctx context.Context, endpoint string, headers map[string]interface{}) (*http.Request, error) {
+	path := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	for k, v := range headers {
+		req.Header.Add(k, fmt.Sprintf("%v", v))
+	}
+
+	return req, nil
+}
+
+func (c *HttpClient) do(req *http.Request) (*http.Response, error) {
+	client := &http.Client{}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return resp, nil
 }
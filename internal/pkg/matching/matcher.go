@@ -0,0 +1,189 @@
+package matching
+
+import (
+	"sort"
+	"strings"
+)
+
+var (
+	DefaultThreshold = 0.8
+	TitleWeight      = 0.75
+	ArtistWeight     = 0.25
+)
+
+type Query struct {
+	Title  string
+	Artist string
+}
+
+type Candidate struct {
+	ID          string
+	Title       string
+	Artists     []string
+	Album       string
+	AlbumType   string
+	ReleaseDate string
+	Popularity  int
+}
+
+type ScoredCandidate struct {
+	Candidate Candidate
+	Score     float64
+	Bonus     float64
+}
+
+type MatcherInterface interface {
+	Score(q Query, c Candidate) float64
+	Rank(q Query, candidates []Candidate, p Policy) []ScoredCandidate
+	Best(q Query, candidates []Candidate, p Policy) (*ScoredCandidate, bool)
+}
+
+type Matcher struct {
+	Threshold float64
+}
+
+func NewMatcher(threshold float64) MatcherInterface {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultThreshold
+	}
+
+	return &Matcher{
+		Threshold: threshold,
+	}
+}
+
+func (m *Matcher) Score(q Query, c Candidate) float64 {
+	title := Similarity(NormalizeTitle(q.Title), NormalizeTitle(c.Title))
+
+	if q.Artist == "" || len(c.Artists) == 0 {
+		return title
+	}
+
+	artist := 0.0
+	for _, a := range c.Artists {
+		if s := Similarity(NormalizeArtist(q.Artist), NormalizeArtist(a)); s > artist {
+			artist = s
+		}
+	}
+
+	return title*TitleWeight + artist*ArtistWeight
+}
+
+func (m *Matcher) Rank(q Query, candidates []Candidate, p Policy) []ScoredCandidate {
+	ranked := make([]ScoredCandidate, 0, len(candidates))
+
+	for _, c := range candidates {
+		variants := Variants(q, c)
+		if !p.Allows(variants) {
+			continue
+		}
+
+		ranked = append(ranked, ScoredCandidate{
+			Candidate: c,
+			Score:     m.Score(q, c),
+			Bonus:     p.Bonus(variants),
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+
+		if a.Score+a.Bonus != b.Score+b.Bonus {
+			return a.Score+a.Bonus > b.Score+b.Bonus
+		}
+
+		if p.Preference == PreferOriginalRelease {
+			return earlierRelease(a.Candidate.ReleaseDate, b.Candidate.ReleaseDate)
+		}
+
+		return false
+	})
+
+	return ranked
+}
+
+func (m *Matcher) Best(q Query, candidates []Candidate, p Policy) (*ScoredCandidate, bool) {
+	ranked := m.Rank(q, candidates, p)
+	if len(ranked) == 0 {
+		return nil, false
+	}
+
+	for i := range ranked {
+		if ranked[i].Score >= m.Threshold {
+			return &ranked[i], true
+		}
+	}
+
+	return &ranked[0], false
+}
+
+func earlierRelease(a, b string) bool {
+	if a == "" || b == "" {
+		return a != ""
+	}
+
+	return a < b
+}
+
+func Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	if a == "" || b == "" {
+		return 0
+	}
+
+	edit := 1 - float64(levenshtein(a, b))/float64(max(len([]rune(a)), len([]rune(b))))
+	tokens := jaccard(strings.Fields(a), strings.Fields(b))
+
+	return max(edit, tokens)
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func jaccard(a, b []string) float64 {
+	set := make(map[string]int, len(a)+len(b))
+
+	for _, t := range a {
+		set[t] |= 1
+	}
+
+	for _, t := range b {
+		set[t] |= 2
+	}
+
+	shared := 0
+	for _, v := range set {
+		if v == 3 {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(set))
+}
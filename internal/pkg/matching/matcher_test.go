@@ -0,0 +1,142 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MatcherTestSuite struct {
+	suite.Suite
+
+	Matcher MatcherInterface
+}
+
+func (s *MatcherTestSuite) SetupTest() {
+	s.Matcher = NewMatcher(0.8)
+}
+
+func TestMatcher(t *testing.T) {
+	suite.Run(t, new(MatcherTestSuite))
+}
+
+func (s *MatcherTestSuite) TestNormalizeTitle() {
+	cases := []struct {
+		in       string
+		expected string
+	}{
+		{"All the Small Things", "all the small things"},
+		{"Here Comes the Sun - Remastered 2009", "here comes the sun"},
+		{"Yesterday - Live At The BBC", "yesterday"},
+		{"Stay (feat. Someone Else)", "stay"},
+		{"Stay With Me feat. Someone Else", "stay with me"},
+		{"Don't Stop Me Now", "dont stop me now"},
+		{"Rock & Roll", "rock and roll"},
+		{"Dammit [Explicit]", "dammit"},
+		{"Canción Rápida!!", "cancion rapida"},
+		{"Song 2 - 2012 Remaster", "song 2"},
+		{"Part I - Overture", "part i overture"},
+	}
+
+	for _, c := range cases {
+		s.Run(c.in, func() {
+			s.Equal(c.expected, NormalizeTitle(c.in))
+		})
+	}
+}
+
+func (s *MatcherTestSuite) TestNormalizeArtist() {
+	s.Equal("beatles", NormalizeArtist("The Beatles"))
+	s.Equal("blink 182", NormalizeArtist("blink-182"))
+	s.Equal("simon and garfunkel", NormalizeArtist("Simon & Garfunkel"))
+}
+
+func (s *MatcherTestSuite) TestFoldAccents() {
+	s.Equal("Sao Paulo", FoldAccents("São Paulo"))
+	s.Equal("BJORK", FoldAccents("BJÖRK"))
+}
+
+func (s *MatcherTestSuite) TestSimilarity() {
+	s.Run("Should be 1 for equal strings", func() {
+		s.Equal(1.0, Similarity("dammit", "dammit"))
+	})
+
+	s.Run("Should be 0 when one side is empty", func() {
+		s.Equal(0.0, Similarity("dammit", ""))
+	})
+
+	s.Run("Should tolerate small typos", func() {
+		s.Greater(Similarity("all the small things", "all the smal things"), 0.9)
+	})
+
+	s.Run("Should be low for different songs", func() {
+		s.Less(Similarity("dammit", "adams song"), 0.5)
+	})
+}
+
+func (s *MatcherTestSuite) TestScore() {
+	q := Query{Title: "Here Comes the Sun", Artist: "The Beatles"}
+
+	s.Run("Should give a perfect score to an exact match", func() {
+		c := Candidate{Title: "Here Comes The Sun - Remastered 2009", Artists: []string{"The Beatles"}}
+
+		s.Equal(1.0, s.Matcher.Score(q, c))
+	})
+
+	s.Run("Should penalize a different artist", func() {
+		original := Candidate{Title: "Here Comes the Sun", Artists: []string{"The Beatles"}}
+		cover := Candidate{Title: "Here Comes the Sun", Artists: []string{"Nina Simone"}}
+
+		s.Greater(s.Matcher.Score(q, original), s.Matcher.Score(q, cover))
+	})
+
+	s.Run("Should ignore the artist when candidate has none", func() {
+		c := Candidate{Title: "Here Comes the Sun"}
+
+		s.Equal(1.0, s.Matcher.Score(q, c))
+	})
+}
+
+func (s *MatcherTestSuite) TestBest() {
+	q := Query{Title: "Dammit", Artist: "blink-182"}
+
+	s.Run("Should pick the highest scoring candidate", func() {
+		candidates := []Candidate{
+			{ID: "1", Title: "Adam's Song", Artists: []string{"blink-182"}},
+			{ID: "2", Title: "Dammit", Artists: []string{"Karaoke Hits Band"}},
+			{ID: "3", Title: "Dammit", Artists: []string{"blink-182"}},
+		}
+
+		best, ok := s.Matcher.Best(q, candidates, Policy{})
+
+		s.True(ok)
+		s.Equal("3", best.Candidate.ID)
+		s.Equal(1.0, best.Score)
+	})
+
+	s.Run("Should not accept candidates below the threshold", func() {
+		candidates := []Candidate{
+			{ID: "1", Title: "Adam's Song", Artists: []string{"blink-182"}},
+		}
+
+		best, ok := s.Matcher.Best(q, candidates, Policy{})
+
+		s.False(ok)
+		s.Equal("1", best.Candidate.ID)
+	})
+
+	s.Run("Should return nothing without candidates", func() {
+		best, ok := s.Matcher.Best(q, nil, Policy{})
+
+		s.False(ok)
+		s.Nil(best)
+	})
+}
+
+func (s *MatcherTestSuite) TestNewMatcher() {
+	s.Run("Should fall back to the default threshold when out of range", func() {
+		m := NewMatcher(0).(*Matcher)
+
+		s.Equal(DefaultThreshold, m.Threshold)
+	})
+}
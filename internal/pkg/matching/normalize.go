@@ -0,0 +1,75 @@
+package matching
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	bracketsRegex      = regexp.MustCompile(`[\(\[\{][^\)\]\}]*[\)\]\}]`)
+	featuringRegex     = regexp.MustCompile(`(?i)\s+(feat\.?|ft\.?|featuring)\s+.*$`)
+	versionSuffixRegex = regexp.MustCompile(`(?i)\s+-\s+.*\b(remaster(ed)?|live|version|edit|mix|remix|mono|stereo|demo|acoustic|bonus|session|take|deluxe|single|radio)\b.*$`)
+)
+
+func NormalizeTitle(title string) string {
+	t := strings.ToLower(title)
+	t = bracketsRegex.ReplaceAllString(t, " ")
+	t = versionSuffixRegex.ReplaceAllString(t, "")
+	t = featuringRegex.ReplaceAllString(t, "")
+
+	return normalizeText(t)
+}
+
+func NormalizeArtist(artist string) string {
+	a := strings.ToLower(artist)
+	a = strings.TrimPrefix(a, "the ")
+
+	return normalizeText(a)
+}
+
+func normalizeText(s string) string {
+	s = strings.ReplaceAll(s, "&", " and ")
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			b.WriteRune(foldRune(r))
+		case r == '\'' || r == '’' || r == '.':
+			// Dropped so that "don't" matches "dont" and "m.i.a." matches "mia"
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// FoldAccents replaces accented letters with their plain counterparts, keeping the case.
+func FoldAccents(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToUpper(foldRune(unicode.ToLower(r)))
+		}
+
+		return foldRune(r)
+	}, s)
+}
+
+func foldRune(r rune) rune {
+	if folded, ok := accents[r]; ok {
+		return folded
+	}
+
+	return r
+}
+
+var accents = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n', 'ý': 'y', 'ÿ': 'y',
+}
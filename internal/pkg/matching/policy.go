@@ -0,0 +1,179 @@
+package matching
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type VersionPreference string
+
+const (
+	PreferAny             VersionPreference = "any"
+	StudioOnly            VersionPreference = "studio-only"
+	PreferLive            VersionPreference = "prefer-live"
+	PreferOriginalRelease VersionPreference = "prefer-original-release"
+)
+
+type CoverStrategy string
+
+const (
+	CoversPerformer CoverStrategy = "performer"
+	CoversOriginal  CoverStrategy = "original"
+	CoversFallback  CoverStrategy = "fallback"
+	CoversBest      CoverStrategy = "best"
+)
+
+type Variant string
+
+const (
+	VariantLive         Variant = "live"
+	VariantRemaster     Variant = "remaster"
+	VariantDemo         Variant = "demo"
+	VariantCompilation  Variant = "compilation"
+	VariantKaraoke      Variant = "karaoke"
+	VariantInstrumental Variant = "instrumental"
+	VariantTribute      Variant = "tribute"
+)
+
+var (
+	PreferenceBonus = 0.15
+
+	variantRegexes = map[Variant]*regexp.Regexp{
+		VariantLive:         regexp.MustCompile(`\blive\b|\bunplugged\b|\bin concert\b`),
+		VariantRemaster:     regexp.MustCompile(`\bremaster(ed)?\b|\banniversary\b|\bdeluxe\b`),
+		VariantDemo:         regexp.MustCompile(`\bdemo\b|\brough mix\b|\bouttake\b`),
+		VariantKaraoke:      regexp.MustCompile(`\bkaraoke\b|originally performed|in the style of|made famous`),
+		VariantInstrumental: regexp.MustCompile(`\binstrumental\b|\bbacking track\b`),
+		VariantTribute:      regexp.MustCompile(`\btribute\b`),
+	}
+
+	excludableVariants = []Variant{
+		VariantLive,
+		VariantRemaster,
+		VariantDemo,
+		VariantCompilation,
+		VariantKaraoke,
+		VariantInstrumental,
+		VariantTribute,
+	}
+)
+
+type Policy struct {
+	Preference VersionPreference
+	Exclude    []Variant
+	Covers     CoverStrategy
+}
+
+func NewPolicy(preference string, exclude []string, covers string) (Policy, error) {
+	p := Policy{
+		Preference: PreferAny,
+		Covers:     CoversFallback,
+	}
+
+	switch pref := VersionPreference(strings.ToLower(strings.TrimSpace(preference))); pref {
+	case "":
+	case PreferAny, StudioOnly, PreferLive, PreferOriginalRelease:
+		p.Preference = pref
+	default:
+		return Policy{}, fmt.Errorf(
+			"unknown version preference %q, expected one of: %s, %s, %s, %s",
+			preference, PreferAny, StudioOnly, PreferLive, PreferOriginalRelease,
+		)
+	}
+
+	switch c := CoverStrategy(strings.ToLower(strings.TrimSpace(covers))); c {
+	case "":
+	case CoversPerformer, CoversOriginal, CoversFallback, CoversBest:
+		p.Covers = c
+	default:
+		return Policy{}, fmt.Errorf(
+			"unknown cover strategy %q, expected one of: %s, %s, %s, %s",
+			covers, CoversPerformer, CoversOriginal, CoversFallback, CoversBest,
+		)
+	}
+
+	for _, e := range exclude {
+		v, err := parseVariant(e)
+		if err != nil {
+			return Policy{}, err
+		}
+
+		p.Exclude = append(p.Exclude, v)
+	}
+
+	return p, nil
+}
+
+func parseVariant(s string) (Variant, error) {
+	v := Variant(strings.ToLower(strings.TrimSpace(s)))
+
+	for _, known := range excludableVariants {
+		if v == known {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown track variant %q", s)
+}
+
+func (p Policy) Allows(variants map[Variant]bool) bool {
+	for _, e := range p.Exclude {
+		if variants[e] {
+			return false
+		}
+	}
+
+	if p.Preference == StudioOnly && (variants[VariantLive] || variants[VariantDemo]) {
+		return false
+	}
+
+	return true
+}
+
+func (p Policy) Bonus(variants map[Variant]bool) float64 {
+	switch p.Preference {
+	case PreferLive:
+		if variants[VariantLive] {
+			return PreferenceBonus
+		}
+	case PreferOriginalRelease:
+		bonus := 0.0
+
+		for _, v := range []Variant{VariantLive, VariantRemaster, VariantDemo, VariantCompilation} {
+			if variants[v] {
+				bonus -= PreferenceBonus
+			}
+		}
+
+		return bonus
+	}
+
+	return 0
+}
+
+func Variants(q Query, c Candidate) map[Variant]bool {
+	title := strings.ToLower(q.Title)
+	text := strings.Join(append(
+		[]string{
+			strings.Replace(strings.ToLower(c.Title), title, "", 1),
+			strings.Replace(strings.ToLower(c.Album), title, "", 1),
+		},
+		c.Artists...,
+	), " | ")
+	text = strings.ToLower(text)
+
+	variants := map[Variant]bool{}
+
+	for v, re := range variantRegexes {
+		if re.MatchString(text) {
+			variants[v] = true
+		}
+	}
+
+	if c.AlbumType == "compilation" {
+		variants[VariantCompilation] = true
+	}
+
+	return variants
+}
@@ -0,0 +1,177 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PolicyTestSuite struct {
+	suite.Suite
+
+	Matcher MatcherInterface
+}
+
+func (s *PolicyTestSuite) SetupTest() {
+	s.Matcher = NewMatcher(0.8)
+}
+
+func TestPolicy(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
+
+func (s *PolicyTestSuite) TestNewPolicy() {
+	s.Run("Should parse a valid policy", func() {
+		p, err := NewPolicy("Studio-Only", []string{"karaoke", " tribute "}, "best")
+
+		s.NoError(err)
+		s.Equal(StudioOnly, p.Preference)
+		s.Equal(CoversBest, p.Covers)
+		s.Equal([]Variant{VariantKaraoke, VariantTribute}, p.Exclude)
+	})
+
+	s.Run("Should default to no preference", func() {
+		p, err := NewPolicy("", nil, "")
+
+		s.NoError(err)
+		s.Equal(PreferAny, p.Preference)
+		s.Equal(CoversFallback, p.Covers)
+	})
+
+	s.Run("Should reject an unknown preference", func() {
+		_, err := NewPolicy("prefer-bootlegs", nil, "")
+
+		s.ErrorContains(err, `unknown version preference "prefer-bootlegs"`)
+	})
+
+	s.Run("Should reject an unknown variant", func() {
+		_, err := NewPolicy("", []string{"kazoo"}, "")
+
+		s.ErrorContains(err, `unknown track variant "kazoo"`)
+	})
+
+	s.Run("Should reject an unknown cover strategy", func() {
+		_, err := NewPolicy("", nil, "whatever")
+
+		s.ErrorContains(err, `unknown cover strategy "whatever"`)
+	})
+}
+
+func (s *PolicyTestSuite) TestVariants() {
+	cases := []struct {
+		name     string
+		query    Query
+		c        Candidate
+		expected []Variant
+	}{
+		{
+			name:     "studio version",
+			query:    Query{Title: "Dammit"},
+			c:        Candidate{Title: "Dammit", Album: "Dude Ranch", AlbumType: "album"},
+			expected: nil,
+		},
+		{
+			name:     "live version",
+			query:    Query{Title: "Dammit"},
+			c:        Candidate{Title: "Dammit - Live", Album: "The Mark, Tom and Travis Show"},
+			expected: []Variant{VariantLive},
+		},
+		{
+			name:     "live album",
+			query:    Query{Title: "Yesterday"},
+			c:        Candidate{Title: "Yesterday", Album: "Live At The BBC"},
+			expected: []Variant{VariantLive},
+		},
+		{
+			name:     "song with live in its title",
+			query:    Query{Title: "Live Forever"},
+			c:        Candidate{Title: "Live Forever", Album: "Live Forever"},
+			expected: nil,
+		},
+		{
+			name:     "remaster on a compilation",
+			query:    Query{Title: "Here Comes the Sun"},
+			c:        Candidate{Title: "Here Comes the Sun - Remastered 2009", Album: "1967-1970", AlbumType: "compilation"},
+			expected: []Variant{VariantRemaster, VariantCompilation},
+		},
+		{
+			name:  "karaoke cover",
+			query: Query{Title: "Dammit"},
+			c: Candidate{
+				Title:   "Dammit (Originally Performed by blink-182) [Karaoke Version]",
+				Artists: []string{"Karaoke Hits Band"},
+			},
+			expected: []Variant{VariantKaraoke},
+		},
+		{
+			name:     "instrumental tribute",
+			query:    Query{Title: "Dammit"},
+			c:        Candidate{Title: "Dammit - Instrumental", Album: "A Tribute to blink-182"},
+			expected: []Variant{VariantInstrumental, VariantTribute},
+		},
+	}
+
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			variants := Variants(c.query, c.c)
+
+			s.Len(variants, len(c.expected))
+
+			for _, v := range c.expected {
+				s.True(variants[v], "expected variant %s", v)
+			}
+		})
+	}
+}
+
+func (s *PolicyTestSuite) TestRankWithPolicy() {
+	q := Query{Title: "Dammit", Artist: "blink-182"}
+
+	live := Candidate{ID: "live", Title: "Dammit - Live", Album: "The Mark, Tom and Travis Show", ReleaseDate: "2000-11-07", Artists: []string{"blink-182"}}
+	remaster := Candidate{ID: "remaster", Title: "Dammit - Remastered", Album: "Dude Ranch (Remastered)", ReleaseDate: "2018-01-01", Artists: []string{"blink-182"}}
+	studio := Candidate{ID: "studio", Title: "Dammit", Album: "Dude Ranch", ReleaseDate: "1997-06-17", Artists: []string{"blink-182"}}
+	karaoke := Candidate{ID: "karaoke", Title: "Dammit [Karaoke Version]", Artists: []string{"Karaoke Hits Band"}}
+
+	candidates := []Candidate{live, karaoke, remaster, studio}
+
+	s.Run("Should drop live versions when studio-only", func() {
+		ranked := s.Matcher.Rank(q, candidates, Policy{Preference: StudioOnly})
+
+		for _, r := range ranked {
+			s.NotEqual("live", r.Candidate.ID)
+		}
+	})
+
+	s.Run("Should drop excluded variants", func() {
+		ranked := s.Matcher.Rank(q, candidates, Policy{Exclude: []Variant{VariantKaraoke}})
+
+		for _, r := range ranked {
+			s.NotEqual("karaoke", r.Candidate.ID)
+		}
+	})
+
+	s.Run("Should favour live versions when prefer-live", func() {
+		best, ok := s.Matcher.Best(q, candidates, Policy{Preference: PreferLive})
+
+		s.True(ok)
+		s.Equal("live", best.Candidate.ID)
+	})
+
+	s.Run("Should favour the original release when prefer-original-release", func() {
+		best, ok := s.Matcher.Best(q, []Candidate{remaster, live, studio}, Policy{Preference: PreferOriginalRelease})
+
+		s.True(ok)
+		s.Equal("studio", best.Candidate.ID)
+	})
+
+	s.Run("Should break ties with the earliest release when prefer-original-release", func() {
+		reissue := studio
+		reissue.ID = "reissue"
+		reissue.ReleaseDate = "2012-01-01"
+
+		best, ok := s.Matcher.Best(q, []Candidate{reissue, studio}, Policy{Preference: PreferOriginalRelease})
+
+		s.True(ok)
+		s.Equal("studio", best.Candidate.ID)
+	})
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+)
+
+type ClearMatchCacheUseCaseMock struct {
+	mock.Mock
+}
+
+type GetMatchCacheStatsUseCaseMock struct {
+	mock.Mock
+}
+
+type CacheCmdGatewayMock struct {
+	mock.Mock
+}
+
+func (m *ClearMatchCacheUseCaseMock) Execute() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *GetMatchCacheStatsUseCaseMock) Execute() (*cache.Stats, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*cache.Stats), args.Error(1)
+}
+
+func (m *CacheCmdGatewayMock) ClearMatchCache() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *CacheCmdGatewayMock) GetMatchCacheStats() (*cache.Stats, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*cache.Stats), args.Error(1)
+}
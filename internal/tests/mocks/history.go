@@ -0,0 +1,87 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
+)
+
+type HistoryPersistenceMock struct {
+	mock.Mock
+}
+
+type RecordRunUseCaseMock struct {
+	mock.Mock
+}
+
+type ListHistoryUseCaseMock struct {
+	mock.Mock
+}
+
+type GetSetlistHistoryUseCaseMock struct {
+	mock.Mock
+}
+
+type HistoryCmdGatewayMock struct {
+	mock.Mock
+}
+
+func (m *HistoryPersistenceMock) Read() (*history.History, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*history.History), args.Error(1)
+}
+
+func (m *HistoryPersistenceMock) Write(data history.History) error {
+	args := m.Called(data)
+	return args.Error(0)
+}
+
+func (m *RecordRunUseCaseMock) Execute(entry history.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *ListHistoryUseCaseMock) Execute(filter history.Filter) ([]history.Entry, error) {
+	args := m.Called(filter)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]history.Entry), args.Error(1)
+}
+
+func (m *GetSetlistHistoryUseCaseMock) Execute(setlistID string) ([]history.Entry, error) {
+	args := m.Called(setlistID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]history.Entry), args.Error(1)
+}
+
+func (m *HistoryCmdGatewayMock) ListHistory(filter history.Filter) ([]history.Entry, error) {
+	args := m.Called(filter)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]history.Entry), args.Error(1)
+}
+
+func (m *HistoryCmdGatewayMock) GetSetlistHistory(setlistID string) ([]history.Entry, error) {
+	args := m.Called(setlistID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]history.Entry), args.Error(1)
+}
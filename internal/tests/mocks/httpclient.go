@@ -10,3 +10,13 @@ func (m *HttpClientMock) Get(url string, headers map[string]interface{}, respons
 	args := m.Called(url, headers, response)
 	return args.Error(0)
 }
+
+func (m *HttpClientMock) GetBody(url string, headers map[string]interface{}) ([]byte, error) {
+	args := m.Called(url, headers)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
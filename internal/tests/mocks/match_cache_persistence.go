@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+)
+
+type MatchCachePersistenceMock struct {
+	mock.Mock
+}
+
+func (m *MatchCachePersistenceMock) Read() (*cache.MatchCache, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*cache.MatchCache), args.Error(1)
+}
+
+func (m *MatchCachePersistenceMock) Write(data cache.MatchCache) error {
+	args := m.Called(data)
+	return args.Error(0)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/cli/prompts"
+)
+
+type MatchReviewerMock struct {
+	mock.Mock
+}
+
+func (m *MatchReviewerMock) Review(
+	ctx context.Context,
+	songs *entities.FindAllSongsOutput,
+	search prompts.SearchFunc,
+) (*entities.FindAllSongsOutput, error) {
+	args := m.Called(ctx, songs, search)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.FindAllSongsOutput), args.Error(1)
+}
@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+)
+
+type AddOverrideUseCaseMock struct {
+	mock.Mock
+}
+
+type ListOverridesUseCaseMock struct {
+	mock.Mock
+}
+
+type RemoveOverrideUseCaseMock struct {
+	mock.Mock
+}
+
+type OverridesCmdGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AddOverrideUseCaseMock) Execute(override overrides.Override) error {
+	args := m.Called(override)
+	return args.Error(0)
+}
+
+func (m *ListOverridesUseCaseMock) Execute(artist string) ([]overrides.Override, error) {
+	args := m.Called(artist)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]overrides.Override), args.Error(1)
+}
+
+func (m *RemoveOverrideUseCaseMock) Execute(artist string, song string) error {
+	args := m.Called(artist, song)
+	return args.Error(0)
+}
+
+func (m *OverridesCmdGatewayMock) AddOverride(artist string, song string, track string) (*overrides.Override, error) {
+	args := m.Called(artist, song, track)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*overrides.Override), args.Error(1)
+}
+
+func (m *OverridesCmdGatewayMock) ListOverrides(artist string) ([]overrides.Override, error) {
+	args := m.Called(artist)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]overrides.Override), args.Error(1)
+}
+
+func (m *OverridesCmdGatewayMock) RemoveOverride(artist string, song string) error {
+	args := m.Called(artist, song)
+	return args.Error(0)
+}
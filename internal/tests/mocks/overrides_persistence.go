@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+)
+
+type OverridesPersistenceMock struct {
+	mock.Mock
+}
+
+func (m *OverridesPersistenceMock) Read() (*overrides.Overrides, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*overrides.Overrides), args.Error(1)
+}
+
+func (m *OverridesPersistenceMock) Write(data overrides.Overrides) error {
+	args := m.Called(data)
+	return args.Error(0)
+}
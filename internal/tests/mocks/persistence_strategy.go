@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type PersistenceStrategyMock struct {
+	mock.Mock
+}
+
+func (m *PersistenceStrategyMock) Read() ([]byte, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *PersistenceStrategyMock) Write(data []byte) error {
+	args := m.Called(data)
+	return args.Error(0)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+)
+
+type PlaylistLinksPersistenceMock struct {
+	mock.Mock
+}
+
+func (m *PlaylistLinksPersistenceMock) Read() (*spotify.PlaylistLinks, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*spotify.PlaylistLinks), args.Error(1)
+}
+
+func (m *PlaylistLinksPersistenceMock) Write(data spotify.PlaylistLinks) error {
+	args := m.Called(data)
+	return args.Error(0)
+}
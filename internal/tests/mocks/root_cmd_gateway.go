@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/mathcale/setlist-to-playlist/internal/entities/history"
 	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
 	spotifyentities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
 )
@@ -32,12 +33,15 @@ func (m *RootCmdGatewayMock) HandleSpotifyAuthentication(ctx context.Context) er
 	return args.Error(0)
 }
 
+func (m *RootCmdGatewayMock) RequireSpotifyScopes(scopes []string) {
+	m.Called(scopes)
+}
+
 func (m *RootCmdGatewayMock) FetchSongsOnSpotify(
 	ctx context.Context,
-	songTitles []string,
-	artist string,
+	input spotifyentities.FindAllSongsInput,
 ) (*spotifyentities.FindAllSongsOutput, error) {
-	args := m.Called(ctx, songTitles, artist)
+	args := m.Called(ctx, input)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -46,16 +50,82 @@ func (m *RootCmdGatewayMock) FetchSongsOnSpotify(
 	return args.Get(0).(*spotifyentities.FindAllSongsOutput), args.Error(1)
 }
 
+func (m *RootCmdGatewayMock) SearchTracksOnSpotify(
+	ctx context.Context,
+	query string,
+) ([]spotifyentities.Song, error) {
+	args := m.Called(ctx, query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]spotifyentities.Song), args.Error(1)
+}
+
 func (m *RootCmdGatewayMock) CreatePlaylistOnSpotify(
 	ctx context.Context,
-	playlistName string,
+	input spotifyentities.CreatePlaylistInput,
+	songs []spotifyentities.Song,
+) (*spotifyentities.CreatePlaylistOutput, error) {
+	args := m.Called(ctx, input, songs)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*spotifyentities.CreatePlaylistOutput), args.Error(1)
+}
+
+func (m *RootCmdGatewayMock) UpdatePlaylistOnSpotify(
+	ctx context.Context,
+	playlistID string,
+	songs []spotifyentities.Song,
+) (*spotifyentities.CreatePlaylistOutput, error) {
+	args := m.Called(ctx, playlistID, songs)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*spotifyentities.CreatePlaylistOutput), args.Error(1)
+}
+
+func (m *RootCmdGatewayMock) GetLinkedPlaylist(setlistID string) (*spotifyentities.PlaylistLink, error) {
+	args := m.Called(setlistID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*spotifyentities.PlaylistLink), args.Error(1)
+}
+
+func (m *RootCmdGatewayMock) LinkPlaylist(setlistID string, playlist spotifyentities.CreatePlaylistOutput) error {
+	args := m.Called(setlistID, playlist)
+	return args.Error(0)
+}
+
+func (m *RootCmdGatewayMock) RecordRun(entry history.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *RootCmdGatewayMock) SyncPlaylistOnSpotify(
+	ctx context.Context,
+	playlistID string,
 	songs []spotifyentities.Song,
-) (*string, error) {
-	args := m.Called(ctx, playlistName, songs)
+) (*spotifyentities.PlaylistDiff, error) {
+	args := m.Called(ctx, playlistID, songs)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 
-	return args.Get(0).(*string), args.Error(1)
+	return args.Get(0).(*spotifyentities.PlaylistDiff), args.Error(1)
+}
+
+func (m *RootCmdGatewayMock) UploadPlaylistCover(ctx context.Context, playlistID string, image []byte) error {
+	args := m.Called(ctx, playlistID, image)
+	return args.Error(0)
 }
@@ -35,3 +35,181 @@ func (m *SetlistFMClientMock) GetSetlistByID(setlistID string) (*setlistfm.Set,
 
 	return args.Get(0).(*setlistfm.Set), args.Error(1)
 }
+
+func (m *SetlistFMClientMock) SearchArtists(name string, page int) (*setlistfm.ArtistPage, error) {
+	args := m.Called(name, page)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.ArtistPage), args.Error(1)
+}
+
+func (m *SetlistFMClientMock) GetArtist(mbid string) (*setlistfm.Artist, error) {
+	args := m.Called(mbid)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.Artist), args.Error(1)
+}
+
+func (m *SetlistFMClientMock) GetArtistSetlists(mbid string, page int) (*setlistfm.SetlistPage, error) {
+	args := m.Called(mbid, page)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.SetlistPage), args.Error(1)
+}
+
+func (m *SetlistFMClientMock) SearchSetlists(in setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.SetlistPage), args.Error(1)
+}
+
+type SearchSetlistsUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *SearchSetlistsUseCaseMock) Execute(in setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.SetlistPage), args.Error(1)
+}
+
+type FindArtistUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *FindArtistUseCaseMock) Execute(name string) (*setlistfm.Artist, error) {
+	args := m.Called(name)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.Artist), args.Error(1)
+}
+
+type GetTourSetlistsUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GetTourSetlistsUseCaseMock) Execute(in setlistfm.GetTourSetlistsInput) (setlistfm.Shows, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(setlistfm.Shows), args.Error(1)
+}
+
+type GetLatestSetlistUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GetLatestSetlistUseCaseMock) Execute(in setlistfm.GetLatestSetlistInput) (*setlistfm.Set, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.Set), args.Error(1)
+}
+
+type SetlistFMCmdGatewayMock struct {
+	mock.Mock
+}
+
+func (m *SetlistFMCmdGatewayMock) FindArtist(name string) (*setlistfm.Artist, error) {
+	args := m.Called(name)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.Artist), args.Error(1)
+}
+
+func (m *SetlistFMCmdGatewayMock) GetTourSetlists(in setlistfm.GetTourSetlistsInput) (setlistfm.Shows, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(setlistfm.Shows), args.Error(1)
+}
+
+func (m *SetlistFMCmdGatewayMock) SearchSetlists(in setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.SetlistPage), args.Error(1)
+}
+
+func (m *SetlistFMCmdGatewayMock) GetLatestSetlist(in setlistfm.GetLatestSetlistInput) (*setlistfm.Set, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.Set), args.Error(1)
+}
+
+type SetlistFMWebClientMock struct {
+	mock.Mock
+}
+
+func (m *SetlistFMWebClientMock) GetFestivalSetlists(path string) ([]setlistfm.LineupSetlist, error) {
+	args := m.Called(path)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]setlistfm.LineupSetlist), args.Error(1)
+}
+
+type GetFestivalSetlistsUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GetFestivalSetlistsUseCaseMock) Execute(in setlistfm.GetFestivalSetlistsInput) (*setlistfm.Festival, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.Festival), args.Error(1)
+}
+
+func (m *SetlistFMCmdGatewayMock) GetFestivalSetlists(in setlistfm.GetFestivalSetlistsInput) (*setlistfm.Festival, error) {
+	args := m.Called(in)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*setlistfm.Festival), args.Error(1)
+}
@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+)
+
+type ShowPickerMock struct {
+	mock.Mock
+}
+
+func (m *ShowPickerMock) Pick(shows []setlistfm.Set, hasMore bool) (int, error) {
+	args := m.Called(shows, hasMore)
+	return args.Int(0), args.Error(1)
+}
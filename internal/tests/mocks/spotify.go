@@ -19,6 +19,10 @@ type FetchSongsOnSpotifyUseCaseMock struct {
 	mock.Mock
 }
 
+type SearchTracksOnSpotifyUseCaseMock struct {
+	mock.Mock
+}
+
 type CreatePlaylistOnSpotifyUseCaseMock struct {
 	mock.Mock
 }
@@ -48,10 +52,9 @@ func (m *SpotifyAuthCallbackUseCaseMock) Execute(
 
 func (m *FetchSongsOnSpotifyUseCaseMock) Execute(
 	ctx context.Context,
-	songs []string,
-	artist string,
+	input entities.FindAllSongsInput,
 ) (*entities.FindAllSongsOutput, error) {
-	args := m.Called(ctx, songs, artist)
+	args := m.Called(ctx, input)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -60,6 +63,16 @@ func (m *FetchSongsOnSpotifyUseCaseMock) Execute(
 	return args.Get(0).(*entities.FindAllSongsOutput), args.Error(1)
 }
 
+func (m *SearchTracksOnSpotifyUseCaseMock) Execute(ctx context.Context, query string) ([]entities.Song, error) {
+	args := m.Called(ctx, query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.Song), args.Error(1)
+}
+
 func (m *CreatePlaylistOnSpotifyUseCaseMock) Execute(
 	ctx context.Context,
 	input entities.CreatePlaylistInput,
@@ -89,3 +102,72 @@ func (m *SpotifyUserAuthenticationUseCaseMock) Execute(
 	args := m.Called(ctx, pkceCodes, state)
 	return args.Error(0)
 }
+
+type UpdatePlaylistUseCaseMock struct {
+	mock.Mock
+}
+
+type GetLinkedPlaylistUseCaseMock struct {
+	mock.Mock
+}
+
+type LinkPlaylistUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *UpdatePlaylistUseCaseMock) Execute(
+	ctx context.Context,
+	input entities.UpdatePlaylistInput,
+) (*entities.CreatePlaylistOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.CreatePlaylistOutput), args.Error(1)
+}
+
+func (m *GetLinkedPlaylistUseCaseMock) Execute(setlistID string) (*entities.PlaylistLink, error) {
+	args := m.Called(setlistID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.PlaylistLink), args.Error(1)
+}
+
+func (m *LinkPlaylistUseCaseMock) Execute(setlistID string, playlist entities.CreatePlaylistOutput) error {
+	args := m.Called(setlistID, playlist)
+	return args.Error(0)
+}
+
+type SyncPlaylistUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *SyncPlaylistUseCaseMock) Execute(
+	ctx context.Context,
+	input entities.SyncPlaylistInput,
+) (*entities.PlaylistDiff, error) {
+	args := m.Called(ctx, input)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.PlaylistDiff), args.Error(1)
+}
+
+type UploadPlaylistCoverUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *UploadPlaylistCoverUseCaseMock) Execute(
+	ctx context.Context,
+	input entities.UploadPlaylistCoverInput,
+) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
@@ -85,10 +85,9 @@ func (m *SpotifyClientMock) RefreshToken(
 
 func (m *SpotifyClientMock) FindAllSongsByName(
 	ctx context.Context,
-	name []string,
-	artist string,
+	input entities.FindAllSongsInput,
 ) (*entities.FindAllSongsOutput, error) {
-	args := m.Called(ctx, name, artist)
+	args := m.Called(ctx, input)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -97,12 +96,33 @@ func (m *SpotifyClientMock) FindAllSongsByName(
 	return args.Get(0).(*entities.FindAllSongsOutput), args.Error(1)
 }
 
+func (m *SpotifyClientMock) SearchTracks(ctx context.Context, query string) ([]entities.Song, error) {
+	args := m.Called(ctx, query)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.Song), args.Error(1)
+}
+
+func (m *SpotifyClientMock) GetTracks(ctx context.Context, ids []string) ([]entities.Song, error) {
+	args := m.Called(ctx, ids)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.Song), args.Error(1)
+}
+
 func (m *SpotifyClientMock) CreatePlaylist(
 	ctx context.Context,
 	title string,
 	description string,
+	visibility entities.PlaylistVisibility,
 ) (*entities.CreatePlaylistOutput, error) {
-	args := m.Called(ctx, title, description)
+	args := m.Called(ctx, title, description, visibility)
 
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -118,3 +138,69 @@ func (m *SpotifyClientMock) AddTracksToPlaylist(
 	args := m.Called(ctx, input)
 	return args.Error(0)
 }
+
+func (m *SpotifyClientMock) GetPlaylist(ctx context.Context, playlistID string) (*entities.CreatePlaylistOutput, error) {
+	args := m.Called(ctx, playlistID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.CreatePlaylistOutput), args.Error(1)
+}
+
+func (m *SpotifyClientMock) ReplacePlaylistTracks(
+	ctx context.Context,
+	input entities.AddTracksToPlaylistClientInput,
+) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *SpotifyClientMock) GetPlaylistTracks(ctx context.Context, playlistID string) ([]entities.Song, error) {
+	args := m.Called(ctx, playlistID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.Song), args.Error(1)
+}
+
+func (m *SpotifyClientMock) RemovePlaylistTracks(
+	ctx context.Context,
+	playlistID string,
+	items []entities.PlaylistItem,
+) error {
+	args := m.Called(ctx, playlistID, items)
+	return args.Error(0)
+}
+
+func (m *SpotifyClientMock) ReorderPlaylistTrack(
+	ctx context.Context,
+	playlistID string,
+	from int,
+	insertBefore int,
+) error {
+	args := m.Called(ctx, playlistID, from, insertBefore)
+	return args.Error(0)
+}
+
+func (m *SpotifyClientMock) Scopes() []string {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).([]string)
+}
+
+func (m *SpotifyClientMock) RequireScopes(scopes ...string) {
+	m.Called(scopes)
+}
+
+func (m *SpotifyClientMock) UploadPlaylistCover(ctx context.Context, playlistID string, image []byte) error {
+	args := m.Called(ctx, playlistID, image)
+	return args.Error(0)
+}
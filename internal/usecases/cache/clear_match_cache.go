@@ -0,0 +1,43 @@
+package cache
+
+import (
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type ClearMatchCacheUseCaseInterface interface {
+	Execute() (int, error)
+}
+
+type ClearMatchCacheUseCase struct {
+	Persistence persistence.MatchCachePersistenceInterface
+	Logger      logger.LoggerInterface
+}
+
+func NewClearMatchCacheUseCase(
+	p persistence.MatchCachePersistenceInterface,
+	l logger.LoggerInterface,
+) ClearMatchCacheUseCaseInterface {
+	return &ClearMatchCacheUseCase{
+		Persistence: p,
+		Logger:      l,
+	}
+}
+
+func (uc *ClearMatchCacheUseCase) Execute() (int, error) {
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		uc.Logger.Debug("Match cache unreadable, overwriting it", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		saved = &entities.MatchCache{}
+	}
+
+	if err := uc.Persistence.Write(entities.MatchCache{Entries: map[string]entities.Entry{}}); err != nil {
+		return 0, err
+	}
+
+	return len(saved.Entries), nil
+}
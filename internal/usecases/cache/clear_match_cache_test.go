@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type ClearMatchCacheUseCaseTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.MatchCachePersistenceMock
+	LoggerMock      *mocks.LoggerMock
+
+	UseCase ClearMatchCacheUseCaseInterface
+}
+
+func (s *ClearMatchCacheUseCaseTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.MatchCachePersistenceMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.UseCase = NewClearMatchCacheUseCase(s.PersistenceMock, s.LoggerMock)
+}
+
+func (s *ClearMatchCacheUseCaseTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestClearMatchCacheUseCase(t *testing.T) {
+	suite.Run(t, new(ClearMatchCacheUseCaseTestSuite))
+}
+
+func (s *ClearMatchCacheUseCaseTestSuite) TestExecute() {
+	empty := entities.MatchCache{Entries: map[string]entities.Entry{}}
+
+	s.Run("Should empty the cache and report how many entries were removed", func() {
+		defer s.cleanMocks()
+
+		saved := &entities.MatchCache{}
+		saved.Put("a", spotify.SongResult{}, time.Now())
+		saved.Put("b", spotify.SongResult{}, time.Now())
+
+		s.PersistenceMock.On("Read").Return(saved, nil)
+		s.PersistenceMock.On("Write", empty).Return(nil)
+
+		cleared, err := s.UseCase.Execute()
+
+		s.NoError(err)
+		s.Equal(2, cleared)
+	})
+
+	s.Run("Should overwrite an unreadable cache", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+		s.PersistenceMock.On("Write", empty).Return(nil)
+
+		cleared, err := s.UseCase.Execute()
+
+		s.NoError(err)
+		s.Zero(cleared)
+	})
+
+	s.Run("Should return an error when writing fails", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(&entities.MatchCache{}, nil)
+		s.PersistenceMock.On("Write", empty).Return(errors.New("any-error"))
+
+		_, err := s.UseCase.Execute()
+
+		s.ErrorContains(err, "any-error")
+	})
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"time"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+)
+
+type GetMatchCacheStatsUseCaseInterface interface {
+	Execute() (*entities.Stats, error)
+}
+
+type GetMatchCacheStatsUseCase struct {
+	Persistence persistence.MatchCachePersistenceInterface
+	TTL         time.Duration
+	Now         func() time.Time
+}
+
+func NewGetMatchCacheStatsUseCase(
+	p persistence.MatchCachePersistenceInterface,
+	ttl time.Duration,
+) GetMatchCacheStatsUseCaseInterface {
+	return &GetMatchCacheStatsUseCase{
+		Persistence: p,
+		TTL:         ttl,
+		Now:         time.Now,
+	}
+}
+
+func (uc *GetMatchCacheStatsUseCase) Execute() (*entities.Stats, error) {
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := saved.Stats(uc.TTL, uc.Now())
+
+	return &stats, nil
+}
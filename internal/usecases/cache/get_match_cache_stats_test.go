@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/cache"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type GetMatchCacheStatsUseCaseTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.MatchCachePersistenceMock
+	Now             time.Time
+
+	UseCase *GetMatchCacheStatsUseCase
+}
+
+func (s *GetMatchCacheStatsUseCaseTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.MatchCachePersistenceMock)
+	s.Now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	s.UseCase = NewGetMatchCacheStatsUseCase(s.PersistenceMock, time.Hour).(*GetMatchCacheStatsUseCase)
+	s.UseCase.Now = func() time.Time { return s.Now }
+}
+
+func (s *GetMatchCacheStatsUseCaseTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+}
+
+func TestGetMatchCacheStatsUseCase(t *testing.T) {
+	suite.Run(t, new(GetMatchCacheStatsUseCaseTestSuite))
+}
+
+func (s *GetMatchCacheStatsUseCaseTestSuite) TestExecute() {
+	s.Run("Should compute the stats with the configured TTL", func() {
+		defer s.cleanMocks()
+
+		saved := &entities.MatchCache{}
+		saved.Put("a", spotify.SongResult{Status: spotify.MatchStatusMatched}, s.Now.Add(-2*time.Hour))
+		saved.Put("b", spotify.SongResult{Status: spotify.MatchStatusNotFound}, s.Now)
+
+		s.PersistenceMock.On("Read").Return(saved, nil)
+
+		stats, err := s.UseCase.Execute()
+
+		s.NoError(err)
+		s.Equal(2, stats.Entries)
+		s.Equal(1, stats.Expired)
+		s.Equal(1, stats.Matched)
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+
+		stats, err := s.UseCase.Execute()
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(stats)
+	})
+}
@@ -0,0 +1,29 @@
+package history
+
+import (
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+)
+
+type GetSetlistHistoryUseCaseInterface interface {
+	Execute(setlistID string) ([]entities.Entry, error)
+}
+
+type GetSetlistHistoryUseCase struct {
+	Persistence persistence.HistoryPersistenceInterface
+}
+
+func NewGetSetlistHistoryUseCase(p persistence.HistoryPersistenceInterface) GetSetlistHistoryUseCaseInterface {
+	return &GetSetlistHistoryUseCase{
+		Persistence: p,
+	}
+}
+
+func (uc *GetSetlistHistoryUseCase) Execute(setlistID string) ([]entities.Entry, error) {
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return saved.BySetlist(setlistID), nil
+}
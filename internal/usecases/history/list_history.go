@@ -0,0 +1,29 @@
+package history
+
+import (
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+)
+
+type ListHistoryUseCaseInterface interface {
+	Execute(filter entities.Filter) ([]entities.Entry, error)
+}
+
+type ListHistoryUseCase struct {
+	Persistence persistence.HistoryPersistenceInterface
+}
+
+func NewListHistoryUseCase(p persistence.HistoryPersistenceInterface) ListHistoryUseCaseInterface {
+	return &ListHistoryUseCase{
+		Persistence: p,
+	}
+}
+
+func (uc *ListHistoryUseCase) Execute(filter entities.Filter) ([]entities.Entry, error) {
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return saved.Filter(filter), nil
+}
@@ -0,0 +1,93 @@
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type HistoryQueriesTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.HistoryPersistenceMock
+
+	ListUseCase ListHistoryUseCaseInterface
+	GetUseCase  GetSetlistHistoryUseCaseInterface
+	Saved       *entities.History
+}
+
+func (s *HistoryQueriesTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.HistoryPersistenceMock)
+
+	s.ListUseCase = NewListHistoryUseCase(s.PersistenceMock)
+	s.GetUseCase = NewGetSetlistHistoryUseCase(s.PersistenceMock)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.Saved = &entities.History{Entries: []entities.Entry{
+		{SetlistID: "a", Artist: "blink-182", CreatedAt: now},
+		{SetlistID: "b", Artist: "Green Day", CreatedAt: now.Add(time.Hour)},
+		{SetlistID: "a", Artist: "blink-182", CreatedAt: now.Add(2 * time.Hour)},
+	}}
+}
+
+func (s *HistoryQueriesTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+}
+
+func TestHistoryQueries(t *testing.T) {
+	suite.Run(t, new(HistoryQueriesTestSuite))
+}
+
+func (s *HistoryQueriesTestSuite) TestListHistory() {
+	s.Run("Should return the filtered entries", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(s.Saved, nil)
+
+		entries, err := s.ListUseCase.Execute(entities.Filter{Artist: "green"})
+
+		s.NoError(err)
+		s.Len(entries, 1)
+		s.Equal("b", entries[0].SetlistID)
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+
+		entries, err := s.ListUseCase.Execute(entities.Filter{})
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(entries)
+	})
+}
+
+func (s *HistoryQueriesTestSuite) TestGetSetlistHistory() {
+	s.Run("Should return the runs of the setlist", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(s.Saved, nil)
+
+		entries, err := s.GetUseCase.Execute("a")
+
+		s.NoError(err)
+		s.Len(entries, 2)
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+
+		entries, err := s.GetUseCase.Execute("a")
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(entries)
+	})
+}
@@ -0,0 +1,50 @@
+package history
+
+import (
+	"time"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type RecordRunUseCaseInterface interface {
+	Execute(entry entities.Entry) error
+}
+
+type RecordRunUseCase struct {
+	Persistence persistence.HistoryPersistenceInterface
+	Logger      logger.LoggerInterface
+	Now         func() time.Time
+}
+
+func NewRecordRunUseCase(
+	p persistence.HistoryPersistenceInterface,
+	l logger.LoggerInterface,
+) RecordRunUseCaseInterface {
+	return &RecordRunUseCase{
+		Persistence: p,
+		Logger:      l,
+		Now:         time.Now,
+	}
+}
+
+func (uc *RecordRunUseCase) Execute(entry entities.Entry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = uc.Now()
+	}
+
+	uc.Logger.Debug("Recording run in history", map[string]interface{}{
+		"setlist_id":  entry.SetlistID,
+		"playlist_id": entry.PlaylistID,
+	})
+
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		return err
+	}
+
+	saved.Add(entry)
+
+	return uc.Persistence.Write(*saved)
+}
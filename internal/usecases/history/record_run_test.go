@@ -0,0 +1,75 @@
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/history"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type RecordRunUseCaseTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.HistoryPersistenceMock
+	LoggerMock      *mocks.LoggerMock
+	Now             time.Time
+
+	UseCase *RecordRunUseCase
+}
+
+func (s *RecordRunUseCaseTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.HistoryPersistenceMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.Now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	s.UseCase = NewRecordRunUseCase(s.PersistenceMock, s.LoggerMock).(*RecordRunUseCase)
+	s.UseCase.Now = func() time.Time { return s.Now }
+}
+
+func (s *RecordRunUseCaseTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestRecordRunUseCase(t *testing.T) {
+	suite.Run(t, new(RecordRunUseCaseTestSuite))
+}
+
+func (s *RecordRunUseCaseTestSuite) TestExecute() {
+	s.Run("Should append the run with the current time", func() {
+		defer s.cleanMocks()
+
+		previous := entities.Entry{SetlistID: "previous", CreatedAt: s.Now.Add(-time.Hour)}
+		expected := entities.History{Entries: []entities.Entry{
+			previous,
+			{SetlistID: "any-setlist-id", PlaylistID: "any-playlist-id", Matched: 3, CreatedAt: s.Now},
+		}}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(&entities.History{Entries: []entities.Entry{previous}}, nil)
+		s.PersistenceMock.On("Write", expected).Return(nil)
+
+		err := s.UseCase.Execute(entities.Entry{SetlistID: "any-setlist-id", PlaylistID: "any-playlist-id", Matched: 3})
+
+		s.NoError(err)
+		s.PersistenceMock.AssertExpectations(s.T())
+	})
+
+	s.Run("Should return an error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+
+		err := s.UseCase.Execute(entities.Entry{SetlistID: "any-setlist-id"})
+
+		s.ErrorContains(err, "any-error")
+		s.PersistenceMock.AssertNotCalled(s.T(), "Write", mock.Anything)
+	})
+}
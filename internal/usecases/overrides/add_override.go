@@ -0,0 +1,41 @@
+package overrides
+
+import (
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type AddOverrideUseCaseInterface interface {
+	Execute(override entities.Override) error
+}
+
+type AddOverrideUseCase struct {
+	Persistence persistence.OverridesPersistenceInterface
+	Logger      logger.LoggerInterface
+}
+
+func NewAddOverrideUseCase(
+	p persistence.OverridesPersistenceInterface,
+	l logger.LoggerInterface,
+) AddOverrideUseCaseInterface {
+	return &AddOverrideUseCase{
+		Persistence: p,
+		Logger:      l,
+	}
+}
+
+func (uc *AddOverrideUseCase) Execute(override entities.Override) error {
+	uc.Logger.Debug("Adding match override", map[string]interface{}{
+		"override": override,
+	})
+
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		return err
+	}
+
+	saved.Set(override)
+
+	return uc.Persistence.Write(*saved)
+}
@@ -0,0 +1,74 @@
+package overrides
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type AddOverrideUseCaseTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.OverridesPersistenceMock
+	LoggerMock      *mocks.LoggerMock
+
+	UseCase AddOverrideUseCaseInterface
+}
+
+func (s *AddOverrideUseCaseTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.OverridesPersistenceMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.UseCase = NewAddOverrideUseCase(s.PersistenceMock, s.LoggerMock)
+}
+
+func (s *AddOverrideUseCaseTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestAddOverrideUseCase(t *testing.T) {
+	suite.Run(t, new(AddOverrideUseCaseTestSuite))
+}
+
+func (s *AddOverrideUseCaseTestSuite) TestExecute() {
+	s.Run("Should add the override to the saved ones", func() {
+		defer s.cleanMocks()
+
+		saved := &entities.Overrides{Overrides: []entities.Override{
+			{Artist: "blink-182", Song: "Dammit", Track: "skip"},
+		}}
+
+		override := entities.Override{Artist: "blink-182", Song: "Family Reunion", Track: "skip"}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(saved, nil)
+		s.PersistenceMock.On("Write", entities.Overrides{Overrides: []entities.Override{
+			{Artist: "blink-182", Song: "Dammit", Track: "skip"},
+			override,
+		}}).Return(nil)
+
+		err := s.UseCase.Execute(override)
+
+		s.NoError(err)
+		s.PersistenceMock.AssertExpectations(s.T())
+	})
+
+	s.Run("Should return an error when reading the overrides fails", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+
+		err := s.UseCase.Execute(entities.Override{})
+
+		s.ErrorContains(err, "any-error")
+		s.PersistenceMock.AssertNotCalled(s.T(), "Write", mock.Anything)
+	})
+}
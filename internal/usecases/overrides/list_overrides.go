@@ -0,0 +1,29 @@
+package overrides
+
+import (
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+)
+
+type ListOverridesUseCaseInterface interface {
+	Execute(artist string) ([]entities.Override, error)
+}
+
+type ListOverridesUseCase struct {
+	Persistence persistence.OverridesPersistenceInterface
+}
+
+func NewListOverridesUseCase(p persistence.OverridesPersistenceInterface) ListOverridesUseCaseInterface {
+	return &ListOverridesUseCase{
+		Persistence: p,
+	}
+}
+
+func (uc *ListOverridesUseCase) Execute(artist string) ([]entities.Override, error) {
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return saved.ByArtist(artist), nil
+}
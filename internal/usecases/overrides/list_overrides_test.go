@@ -0,0 +1,73 @@
+package overrides
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type ListOverridesUseCaseTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.OverridesPersistenceMock
+
+	UseCase ListOverridesUseCaseInterface
+}
+
+func (s *ListOverridesUseCaseTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.OverridesPersistenceMock)
+
+	s.UseCase = NewListOverridesUseCase(s.PersistenceMock)
+}
+
+func (s *ListOverridesUseCaseTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+}
+
+func TestListOverridesUseCase(t *testing.T) {
+	suite.Run(t, new(ListOverridesUseCaseTestSuite))
+}
+
+func (s *ListOverridesUseCaseTestSuite) TestExecute() {
+	saved := &entities.Overrides{Overrides: []entities.Override{
+		{Artist: "blink-182", Song: "Dammit", Track: "skip"},
+		{Artist: "Green Day", Song: "Basket Case", Track: "skip"},
+	}}
+
+	s.Run("Should list every override", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(saved, nil)
+
+		out, err := s.UseCase.Execute("")
+
+		s.NoError(err)
+		s.Len(out, 2)
+	})
+
+	s.Run("Should list the overrides of an artist", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(saved, nil)
+
+		out, err := s.UseCase.Execute("green day")
+
+		s.NoError(err)
+		s.Equal([]entities.Override{{Artist: "Green Day", Song: "Basket Case", Track: "skip"}}, out)
+	})
+
+	s.Run("Should return an error when reading the overrides fails", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+
+		out, err := s.UseCase.Execute("")
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
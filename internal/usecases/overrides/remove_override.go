@@ -0,0 +1,45 @@
+package overrides
+
+import (
+	"fmt"
+
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type RemoveOverrideUseCaseInterface interface {
+	Execute(artist string, song string) error
+}
+
+type RemoveOverrideUseCase struct {
+	Persistence persistence.OverridesPersistenceInterface
+	Logger      logger.LoggerInterface
+}
+
+func NewRemoveOverrideUseCase(
+	p persistence.OverridesPersistenceInterface,
+	l logger.LoggerInterface,
+) RemoveOverrideUseCaseInterface {
+	return &RemoveOverrideUseCase{
+		Persistence: p,
+		Logger:      l,
+	}
+}
+
+func (uc *RemoveOverrideUseCase) Execute(artist string, song string) error {
+	uc.Logger.Debug("Removing match override", map[string]interface{}{
+		"artist": artist,
+		"song":   song,
+	})
+
+	saved, err := uc.Persistence.Read()
+	if err != nil {
+		return err
+	}
+
+	if !saved.Remove(artist, song) {
+		return fmt.Errorf("no override found for %q by %q", song, artist)
+	}
+
+	return uc.Persistence.Write(*saved)
+}
@@ -0,0 +1,68 @@
+package overrides
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type RemoveOverrideUseCaseTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.OverridesPersistenceMock
+	LoggerMock      *mocks.LoggerMock
+
+	UseCase RemoveOverrideUseCaseInterface
+}
+
+func (s *RemoveOverrideUseCaseTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.OverridesPersistenceMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.UseCase = NewRemoveOverrideUseCase(s.PersistenceMock, s.LoggerMock)
+}
+
+func (s *RemoveOverrideUseCaseTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestRemoveOverrideUseCase(t *testing.T) {
+	suite.Run(t, new(RemoveOverrideUseCaseTestSuite))
+}
+
+func (s *RemoveOverrideUseCaseTestSuite) TestExecute() {
+	s.Run("Should remove the override", func() {
+		defer s.cleanMocks()
+
+		saved := &entities.Overrides{Overrides: []entities.Override{
+			{Artist: "blink-182", Song: "Dammit", Track: "skip"},
+		}}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(saved, nil)
+		s.PersistenceMock.On("Write", entities.Overrides{Overrides: []entities.Override{}}).Return(nil)
+
+		err := s.UseCase.Execute("blink-182", "dammit")
+
+		s.NoError(err)
+		s.PersistenceMock.AssertExpectations(s.T())
+	})
+
+	s.Run("Should return an error when there is no such override", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(&entities.Overrides{}, nil)
+
+		err := s.UseCase.Execute("blink-182", "Dammit")
+
+		s.ErrorContains(err, `no override found for "Dammit" by "blink-182"`)
+		s.PersistenceMock.AssertNotCalled(s.T(), "Write", mock.Anything)
+	})
+}
@@ -0,0 +1,66 @@
+package setlistfm
+
+import (
+	"fmt"
+	"strings"
+
+	setlistfm_client "github.com/mathcale/setlist-to-playlist/internal/clients/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+)
+
+type FindArtistUseCaseInterface interface {
+	Execute(name string) (*setlistfm.Artist, error)
+}
+
+type FindArtistUseCase struct {
+	SetlistFMClient setlistfm_client.SetlistFMClientInterface
+}
+
+func NewFindArtistUseCase(c setlistfm_client.SetlistFMClientInterface) FindArtistUseCaseInterface {
+	return &FindArtistUseCase{
+		SetlistFMClient: c,
+	}
+}
+
+// Execute resolves the artist. A MusicBrainz ID is looked up directly, a name is searched,
+// preferring an exact (case insensitive) match over the most relevant result. When several
+// artists share that exact name an *setlistfm.AmbiguousArtistError lists them, so the
+// right one can be picked by MBID.
+func (u *FindArtistUseCase) Execute(name string) (*setlistfm.Artist, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("artist name is empty")
+	}
+
+	if setlistfm.IsMBID(name) {
+		return u.SetlistFMClient.GetArtist(strings.ToLower(name))
+	}
+
+	page, err := u.SetlistFMClient.SearchArtists(name, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.Artist) == 0 {
+		return nil, fmt.Errorf("no artist named %q was found on Setlist.fm", name)
+	}
+
+	var exact []setlistfm.Artist
+	seen := make(map[string]bool)
+
+	for _, a := range page.Artist {
+		if strings.EqualFold(a.Name, name) && !seen[a.MBID] {
+			seen[a.MBID] = true
+			exact = append(exact, a)
+		}
+	}
+
+	switch len(exact) {
+	case 0:
+		return &page.Artist[0], nil
+	case 1:
+		return &exact[0], nil
+	default:
+		return nil, &setlistfm.AmbiguousArtistError{Name: name, Candidates: exact}
+	}
+}
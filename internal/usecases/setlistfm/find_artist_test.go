@@ -0,0 +1,119 @@
+package setlistfm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entity "github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type FindArtistUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SetlistFMClientMock
+
+	UseCase FindArtistUseCaseInterface
+}
+
+func (s *FindArtistUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SetlistFMClientMock)
+
+	s.UseCase = NewFindArtistUseCase(s.ClientMock)
+}
+
+func (s *FindArtistUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+}
+
+func TestFindArtistUseCase(t *testing.T) {
+	suite.Run(t, new(FindArtistUseCaseTestSuite))
+}
+
+func (s *FindArtistUseCaseTestSuite) TestExecute() {
+	s.Run("Should prefer the artist with the exact name", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("SearchArtists", "paramore", 1).Return(&entity.ArtistPage{
+			Artist: []entity.Artist{
+				{MBID: "any-mbid-1", Name: "Paramore Tribute"},
+				{MBID: "any-mbid-2", Name: "Paramore"},
+			},
+		}, nil)
+
+		result, err := s.UseCase.Execute(" paramore ")
+
+		s.NoError(err)
+		s.Equal("any-mbid-2", result.MBID)
+	})
+
+	s.Run("Should fall back to the most relevant artist", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("SearchArtists", "blink 182", 1).Return(&entity.ArtistPage{
+			Artist: []entity.Artist{{MBID: "any-mbid", Name: "blink-182"}},
+		}, nil)
+
+		result, err := s.UseCase.Execute("blink 182")
+
+		s.NoError(err)
+		s.Equal("any-mbid", result.MBID)
+	})
+
+	s.Run("Should return an error listing the artists sharing the name", func() {
+		defer s.cleanMocks()
+
+		candidates := []entity.Artist{
+			{MBID: "any-mbid-1", Name: "Nirvana", Disambiguation: "90s US grunge band"},
+			{MBID: "any-mbid-2", Name: "Nirvana", Disambiguation: "60s band from the UK"},
+		}
+
+		s.ClientMock.On("SearchArtists", "nirvana", 1).Return(&entity.ArtistPage{Artist: candidates}, nil)
+
+		result, err := s.UseCase.Execute("nirvana")
+
+		var ambiguous *entity.AmbiguousArtistError
+		s.ErrorAs(err, &ambiguous)
+		s.Equal(candidates, ambiguous.Candidates)
+		s.Nil(result)
+	})
+
+	s.Run("Should look the artist up by MBID", func() {
+		defer s.cleanMocks()
+
+		mbid := "5b11f4ce-a62d-471e-81fc-a69a8278c7da"
+
+		s.ClientMock.On("GetArtist", mbid).Return(&entity.Artist{MBID: mbid, Name: "Nirvana"}, nil)
+
+		result, err := s.UseCase.Execute(" 5B11F4CE-A62D-471E-81FC-A69A8278C7DA ")
+
+		s.NoError(err)
+		s.Equal(mbid, result.MBID)
+		s.ClientMock.AssertNotCalled(s.T(), "SearchArtists", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should return an error when no artist is found", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("SearchArtists", "any-artist", 1).Return(&entity.ArtistPage{}, nil)
+
+		result, err := s.UseCase.Execute("any-artist")
+
+		s.ErrorContains(err, `no artist named "any-artist"`)
+		s.Nil(result)
+	})
+
+	s.Run("Should return an error when the search fails", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("SearchArtists", "any-artist", 1).Return(nil, errors.New("any-error"))
+
+		result, err := s.UseCase.Execute("any-artist")
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(result)
+	})
+}
@@ -0,0 +1,145 @@
+package setlistfm
+
+import (
+	"errors"
+	"time"
+
+	setlistfm_client "github.com/mathcale/setlist-to-playlist/internal/clients/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type GetFestivalSetlistsUseCaseInterface interface {
+	Execute(input setlistfm.GetFestivalSetlistsInput) (*setlistfm.Festival, error)
+}
+
+type GetFestivalSetlistsUseCase struct {
+	SetlistFMClient setlistfm_client.SetlistFMClientInterface
+	WebClient       setlistfm_client.SetlistFMWebClientInterface
+	Logger          logger.LoggerInterface
+	Interval        time.Duration
+}
+
+func NewGetFestivalSetlistsUseCase(
+	c setlistfm_client.SetlistFMClientInterface,
+	wc setlistfm_client.SetlistFMWebClientInterface,
+	l logger.LoggerInterface,
+) GetFestivalSetlistsUseCaseInterface {
+	return &GetFestivalSetlistsUseCase{
+		SetlistFMClient: c,
+		WebClient:       wc,
+		Logger:          l,
+		Interval:        PageInterval,
+	}
+}
+
+// Execute finds the venue and dates of the festival through one of the setlists linked from its
+// page, then searches the setlists played there on the festival days and keeps the ones of the
+// artists the input allows that have songs.
+func (u *GetFestivalSetlistsUseCase) Execute(in setlistfm.GetFestivalSetlistsInput) (*setlistfm.Festival, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, _ := setlistfm.NewGetSetlistByIDInput(in.URL).FestivalID()
+
+	path, err := in.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := u.WebClient.GetFestivalSetlists(path)
+	if err != nil {
+		return nil, err
+	}
+
+	link, ok := in.Anchor(links)
+	if !ok {
+		return nil, errors.New("none of the setlists linked from the festival page is from the festival year")
+	}
+
+	anchor, err := u.SetlistFMClient.GetSetlistByID(link.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := in.Search(anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	time.Sleep(u.Interval)
+
+	shows, err := u.searchVenue(search, anchor)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single day was searched for, the other festival days don't need to be told apart.
+	if search.Date == "" {
+		shows = setlistfm.FestivalDays(anchor, shows)
+	}
+
+	u.Logger.Debug("Festival setlists found", map[string]interface{}{"venue": anchor.Venue.Name, "setlists": len(shows)})
+
+	festival := &setlistfm.Festival{ID: *id, Name: in.Name(), URL: in.URL}
+
+	for _, set := range shows {
+		if !in.Matches(*set) {
+			u.Logger.Debug("Festival setlist filtered out", map[string]interface{}{"artist": set.Artist.Name})
+			continue
+		}
+
+		if len(set.Songs()) == 0 {
+			u.Logger.Debug("Festival setlist has no songs", map[string]interface{}{"artist": set.Artist.Name})
+			continue
+		}
+
+		festival.Shows = append(festival.Shows, set)
+	}
+
+	if len(festival.Shows) == 0 {
+		return nil, errors.New("none of the festival setlists has songs and matches the given day and artists")
+	}
+
+	return festival, nil
+}
+
+// searchVenue pages through the setlists of the search, newest first, until they are older than
+// the festival can be.
+func (u *GetFestivalSetlistsUseCase) searchVenue(
+	search setlistfm.SearchSetlistsInput,
+	anchor *setlistfm.Set,
+) (setlistfm.Shows, error) {
+	anchorDate, _ := anchor.EventTime()
+	earliest := anchorDate.Add(-setlistfm.MaxFestivalSpan)
+
+	var shows setlistfm.Shows
+
+	for p := 1; ; p++ {
+		if p > 1 {
+			time.Sleep(u.Interval)
+		}
+
+		search.Page = p
+
+		page, err := u.SetlistFMClient.SearchSetlists(search)
+		if err != nil {
+			return nil, err
+		}
+
+		older := false
+
+		for i := range page.Setlist {
+			shows = append(shows, &page.Setlist[i])
+
+			if date, ok := page.Setlist[i].EventTime(); ok && date.Before(earliest) {
+				older = true
+			}
+		}
+
+		if page.IsLast() || older {
+			return shows, nil
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package setlistfm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entity "github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type GetFestivalSetlistsUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock    *mocks.SetlistFMClientMock
+	WebClientMock *mocks.SetlistFMWebClientMock
+	LoggerMock    *mocks.LoggerMock
+
+	UseCase GetFestivalSetlistsUseCaseInterface
+}
+
+func (s *GetFestivalSetlistsUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SetlistFMClientMock)
+	s.WebClientMock = new(mocks.SetlistFMWebClientMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+
+	s.UseCase = &GetFestivalSetlistsUseCase{
+		SetlistFMClient: s.ClientMock,
+		WebClient:       s.WebClientMock,
+		Logger:          s.LoggerMock,
+	}
+}
+
+func (s *GetFestivalSetlistsUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+	s.WebClientMock.ExpectedCalls = nil
+	s.WebClientMock.Calls = nil
+}
+
+func TestGetFestivalSetlistsUseCase(t *testing.T) {
+	suite.Run(t, new(GetFestivalSetlistsUseCaseTestSuite))
+}
+
+func festivalSet(id string, artist string, date string) *entity.Set {
+	set := playedSet(id)
+	set.Artist = entity.Artist{Name: artist}
+	set.EventDate = date
+	set.Venue = entity.Venue{ID: "donington-park-id", Name: "Donington Park"}
+
+	return &set
+}
+
+func lineupSetlist(id string) entity.LineupSetlist {
+	return entity.LineupSetlist{ID: id, Year: "2024", VenueSlug: "donington-park-castle-donington-england"}
+}
+
+func venuePage(page int, total int, sets ...*entity.Set) *entity.SetlistPage {
+	out := &entity.SetlistPage{Page: page, Total: total, ItemsPerPage: 20}
+	for _, set := range sets {
+		out.Setlist = append(out.Setlist, *set)
+	}
+
+	return out
+}
+
+func (s *GetFestivalSetlistsUseCaseTestSuite) TestExecute() {
+	url := "https://www.setlist.fm/festival/2024/download-festival-2024-73d44e99.html"
+	path := "/festival/2024/download-festival-2024-73d44e99.html"
+	yearSearch := entity.SearchSetlistsInput{VenueID: "donington-park-id", Year: "2024", Page: 1}
+
+	qotsa := festivalSet("any-id-1", "Queens of the Stone Age", "14-06-2024")
+	a7x := festivalSet("any-id-2", "Avenged Sevenfold", "14-06-2024")
+	maneskin := festivalSet("any-id-4", "Måneskin", "16-06-2024")
+	cancelled := festivalSet("any-id-3", "Cancelled Band", "15-06-2024")
+	cancelled.Sets = entity.Sets{}
+	// Another event at the same venue, later the same year.
+	concert := festivalSet("any-id-5", "Any Stadium Band", "23-08-2024")
+
+	mockLineup := func() {
+		s.WebClientMock.On("GetFestivalSetlists", path).Return([]entity.LineupSetlist{
+			lineupSetlist("any-id-1"),
+			lineupSetlist("any-id-2"),
+		}, nil)
+		s.ClientMock.On("GetSetlistByID", "any-id-1").Return(qotsa, nil)
+		s.ClientMock.On("SearchSetlists", yearSearch).Return(venuePage(1, 5, concert, maneskin, cancelled, a7x, qotsa), nil)
+	}
+
+	s.Run("Should search the setlists played at the festival venue on its days", func() {
+		defer s.cleanMocks()
+
+		mockLineup()
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{URL: url})
+
+		s.NoError(err)
+		s.Equal("73d44e99", festival.ID)
+		s.Equal("Download Festival 2024", festival.Name)
+		s.Len(festival.Shows, 3)
+		s.Equal("Avenged Sevenfold", festival.Shows[0].Artist.Name)
+		s.Equal("Queens of the Stone Age", festival.Shows[1].Artist.Name)
+		s.Equal("Måneskin", festival.Shows[2].Artist.Name)
+		s.ClientMock.AssertNumberOfCalls(s.T(), "GetSetlistByID", 1)
+	})
+
+	s.Run("Should keep the shows of the included artists", func() {
+		defer s.cleanMocks()
+
+		mockLineup()
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{
+			URL:     url,
+			Artists: entity.ArtistFilter{Include: []string{"Maneskin"}},
+		})
+
+		s.NoError(err)
+		s.Len(festival.Shows, 1)
+		s.Equal("any-id-4", festival.Shows[0].ID)
+	})
+
+	s.Run("Should only search the given day of the festival", func() {
+		defer s.cleanMocks()
+
+		daySearch := entity.SearchSetlistsInput{VenueID: "donington-park-id", Date: "14-06-2024", Page: 1}
+
+		s.WebClientMock.On("GetFestivalSetlists", path).Return([]entity.LineupSetlist{lineupSetlist("any-id-4")}, nil)
+		s.ClientMock.On("GetSetlistByID", "any-id-4").Return(maneskin, nil)
+		s.ClientMock.On("SearchSetlists", daySearch).Return(venuePage(1, 2, a7x, qotsa), nil)
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{
+			URL:     url,
+			Date:    "2024-06-14",
+			Artists: entity.ArtistFilter{Exclude: []string{"avenged sevenfold"}},
+		})
+
+		s.NoError(err)
+		s.Len(festival.Shows, 1)
+		s.Equal("any-id-1", festival.Shows[0].ID)
+		s.ClientMock.AssertCalled(s.T(), "SearchSetlists", daySearch)
+	})
+
+	s.Run("Should stop paging once the setlists are older than the festival", func() {
+		defer s.cleanMocks()
+
+		earlier := festivalSet("any-id-6", "Any Spring Band", "04-05-2024")
+		second := yearSearch
+		second.Page = 2
+
+		s.WebClientMock.On("GetFestivalSetlists", path).Return([]entity.LineupSetlist{lineupSetlist("any-id-1")}, nil)
+		s.ClientMock.On("GetSetlistByID", "any-id-1").Return(qotsa, nil)
+		s.ClientMock.On("SearchSetlists", yearSearch).Return(venuePage(1, 60, concert, qotsa), nil)
+		s.ClientMock.On("SearchSetlists", second).Return(venuePage(2, 60, a7x, earlier), nil)
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{URL: url})
+
+		s.NoError(err)
+		s.Len(festival.Shows, 2)
+		s.ClientMock.AssertNumberOfCalls(s.T(), "SearchSetlists", 2)
+	})
+
+	s.Run("Should find the festival through a setlist of its year and venue", func() {
+		defer s.cleanMocks()
+
+		otherYear := lineupSetlist("any-id-7")
+		otherYear.Year = "2023"
+
+		otherVenue := lineupSetlist("any-id-8")
+		otherVenue.VenueSlug = "wembley-stadium-london-england"
+
+		s.WebClientMock.On("GetFestivalSetlists", path).Return([]entity.LineupSetlist{
+			otherYear,
+			otherVenue,
+			lineupSetlist("any-id-1"),
+			lineupSetlist("any-id-2"),
+		}, nil)
+		s.ClientMock.On("GetSetlistByID", "any-id-1").Return(qotsa, nil)
+		s.ClientMock.On("SearchSetlists", yearSearch).Return(venuePage(1, 2, a7x, qotsa), nil)
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{URL: url})
+
+		s.NoError(err)
+		s.Len(festival.Shows, 2)
+		s.ClientMock.AssertNotCalled(s.T(), "GetSetlistByID", "any-id-7")
+		s.ClientMock.AssertNotCalled(s.T(), "GetSetlistByID", "any-id-8")
+	})
+
+	s.Run("Should return an error when no show is left", func() {
+		defer s.cleanMocks()
+
+		mockLineup()
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{
+			URL:     url,
+			Artists: entity.ArtistFilter{Include: []string{"any-other-artist"}},
+		})
+
+		s.ErrorContains(err, "none of the festival setlists")
+		s.Nil(festival)
+	})
+
+	s.Run("Should return an error when the given day isn't one of the festival", func() {
+		defer s.cleanMocks()
+
+		mockLineup()
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{URL: url, Date: "23-08-2024"})
+
+		s.ErrorContains(err, "23-08-2024 isn't a day of the festival")
+		s.Nil(festival)
+		s.ClientMock.AssertNotCalled(s.T(), "SearchSetlists", mock.Anything)
+	})
+
+	s.Run("Should return an error when no linked setlist is from the festival year", func() {
+		defer s.cleanMocks()
+
+		otherYear := lineupSetlist("any-id-7")
+		otherYear.Year = "2023"
+
+		s.WebClientMock.On("GetFestivalSetlists", path).Return([]entity.LineupSetlist{otherYear}, nil)
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{URL: url})
+
+		s.ErrorContains(err, "none of the setlists linked from the festival page")
+		s.Nil(festival)
+	})
+
+	s.Run("Should return an error when the festival page can't be read", func() {
+		defer s.cleanMocks()
+
+		s.WebClientMock.On("GetFestivalSetlists", path).Return(nil, errors.New("any-error"))
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{URL: url})
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(festival)
+	})
+
+	s.Run("Should return an error when the setlists can't be searched", func() {
+		defer s.cleanMocks()
+
+		s.WebClientMock.On("GetFestivalSetlists", path).Return([]entity.LineupSetlist{lineupSetlist("any-id-1")}, nil)
+		s.ClientMock.On("GetSetlistByID", "any-id-1").Return(qotsa, nil)
+		s.ClientMock.On("SearchSetlists", yearSearch).Return(nil, errors.New("any-error"))
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{URL: url})
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(festival)
+	})
+
+	s.Run("Should return an error for a URL that isn't a festival", func() {
+		defer s.cleanMocks()
+
+		festival, err := s.UseCase.Execute(entity.GetFestivalSetlistsInput{
+			URL: "https://www.setlist.fm/setlist/blink182/2024/autodromo-de-interlagos-sao-paulo-brazil-53aa1325.html",
+		})
+
+		s.ErrorContains(err, "not a setlist.fm festival")
+		s.Nil(festival)
+		s.WebClientMock.AssertNotCalled(s.T(), "GetFestivalSetlists", mock.Anything)
+	})
+}
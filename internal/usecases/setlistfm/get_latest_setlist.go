@@ -0,0 +1,67 @@
+package setlistfm
+
+import (
+	"errors"
+	"time"
+
+	setlistfm_client "github.com/mathcale/setlist-to-playlist/internal/clients/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type GetLatestSetlistUseCaseInterface interface {
+	Execute(input setlistfm.GetLatestSetlistInput) (*setlistfm.Set, error)
+}
+
+type GetLatestSetlistUseCase struct {
+	SetlistFMClient setlistfm_client.SetlistFMClientInterface
+	Logger          logger.LoggerInterface
+	Interval        time.Duration
+}
+
+func NewGetLatestSetlistUseCase(
+	c setlistfm_client.SetlistFMClientInterface,
+	l logger.LoggerInterface,
+) GetLatestSetlistUseCaseInterface {
+	return &GetLatestSetlistUseCase{
+		SetlistFMClient: c,
+		Logger:          l,
+		Interval:        PageInterval,
+	}
+}
+
+// Execute returns the most recent setlist of the artist that has songs. Upcoming shows are
+// listed with no songs, so they are skipped, reading older pages until one is found.
+func (u *GetLatestSetlistUseCase) Execute(in setlistfm.GetLatestSetlistInput) (*setlistfm.Set, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	for p := 1; in.MaxPages == 0 || p <= in.MaxPages; p++ {
+		if p > 1 {
+			time.Sleep(u.Interval)
+		}
+
+		page, err := u.SetlistFMClient.GetArtistSetlists(in.ArtistMBID, p)
+		if err != nil {
+			return nil, err
+		}
+
+		u.Logger.Debug("Setlists page loaded", map[string]interface{}{
+			"page":  p,
+			"total": page.Total,
+		})
+
+		for i := range page.Setlist {
+			if len(page.Setlist[i].Songs()) > 0 {
+				return &page.Setlist[i], nil
+			}
+		}
+
+		if page.IsLast() {
+			break
+		}
+	}
+
+	return nil, errors.New("none of the latest setlists of the artist has songs yet")
+}
@@ -0,0 +1,113 @@
+package setlistfm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entity "github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type GetLatestSetlistUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SetlistFMClientMock
+	LoggerMock *mocks.LoggerMock
+
+	UseCase GetLatestSetlistUseCaseInterface
+}
+
+func (s *GetLatestSetlistUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SetlistFMClientMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+
+	s.UseCase = &GetLatestSetlistUseCase{SetlistFMClient: s.ClientMock, Logger: s.LoggerMock}
+}
+
+func (s *GetLatestSetlistUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+}
+
+func TestGetLatestSetlistUseCase(t *testing.T) {
+	suite.Run(t, new(GetLatestSetlistUseCaseTestSuite))
+}
+
+func emptySet(id string) entity.Set {
+	return entity.Set{ID: id}
+}
+
+func playedSet(id string) entity.Set {
+	return entity.Set{
+		ID:   id,
+		Sets: entity.Sets{Set: []entity.Songs{{Song: []entity.Song{{Name: "any-song"}}}}},
+	}
+}
+
+func (s *GetLatestSetlistUseCaseTestSuite) TestExecute() {
+	in := entity.GetLatestSetlistInput{ArtistMBID: "any-mbid"}
+
+	s.Run("Should skip upcoming shows across pages", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 1).Return(&entity.SetlistPage{
+			Setlist: []entity.Set{emptySet("upcoming-1"), emptySet("upcoming-2")}, Page: 1, Total: 4, ItemsPerPage: 2,
+		}, nil)
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 2).Return(&entity.SetlistPage{
+			Setlist: []entity.Set{emptySet("upcoming-3"), playedSet("latest")}, Page: 2, Total: 4, ItemsPerPage: 2,
+		}, nil)
+
+		set, err := s.UseCase.Execute(in)
+
+		s.NoError(err)
+		s.Equal("latest", set.ID)
+	})
+
+	s.Run("Should stop at the page limit", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 1).Return(&entity.SetlistPage{
+			Setlist: []entity.Set{emptySet("upcoming-1")}, Page: 1, Total: 10, ItemsPerPage: 1,
+		}, nil)
+
+		set, err := s.UseCase.Execute(entity.GetLatestSetlistInput{ArtistMBID: "any-mbid", MaxPages: 1})
+
+		s.ErrorContains(err, "has songs yet")
+		s.Nil(set)
+		s.ClientMock.AssertNumberOfCalls(s.T(), "GetArtistSetlists", 1)
+	})
+
+	s.Run("Should return an error when the artist has no setlists", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 1).Return(&entity.SetlistPage{Page: 1}, nil)
+
+		set, err := s.UseCase.Execute(in)
+
+		s.ErrorContains(err, "has songs yet")
+		s.Nil(set)
+	})
+
+	s.Run("Should return an error when the request fails", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 1).Return(nil, errors.New("any-error"))
+
+		set, err := s.UseCase.Execute(in)
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(set)
+	})
+
+	s.Run("Should return an error without an artist", func() {
+		defer s.cleanMocks()
+
+		set, err := s.UseCase.Execute(entity.GetLatestSetlistInput{})
+
+		s.ErrorContains(err, "artist MBID is empty")
+		s.Nil(set)
+	})
+}
@@ -0,0 +1,75 @@
+package setlistfm
+
+import (
+	"time"
+
+	setlistfm_client "github.com/mathcale/setlist-to-playlist/internal/clients/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+// PageInterval keeps paginated requests under the Setlist.fm rate limit of two per second.
+var PageInterval = 500 * time.Millisecond
+
+type GetTourSetlistsUseCaseInterface interface {
+	Execute(input setlistfm.GetTourSetlistsInput) (setlistfm.Shows, error)
+}
+
+type GetTourSetlistsUseCase struct {
+	SetlistFMClient setlistfm_client.SetlistFMClientInterface
+	Logger          logger.LoggerInterface
+	Interval        time.Duration
+}
+
+func NewGetTourSetlistsUseCase(
+	c setlistfm_client.SetlistFMClientInterface,
+	l logger.LoggerInterface,
+) GetTourSetlistsUseCaseInterface {
+	return &GetTourSetlistsUseCase{
+		SetlistFMClient: c,
+		Logger:          l,
+		Interval:        PageInterval,
+	}
+}
+
+// Execute pages through the artist setlists, newest first, keeping the ones of the tour. As
+// a tour is played in one stretch, it stops at the first page without any of its shows once
+// some were found.
+func (u *GetTourSetlistsUseCase) Execute(in setlistfm.GetTourSetlistsInput) (setlistfm.Shows, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	var shows setlistfm.Shows
+
+	for p := 1; in.MaxPages == 0 || p <= in.MaxPages; p++ {
+		if p > 1 {
+			time.Sleep(u.Interval)
+		}
+
+		page, err := u.SetlistFMClient.GetArtistSetlists(in.ArtistMBID, p)
+		if err != nil {
+			return nil, err
+		}
+
+		u.Logger.Debug("Setlists page loaded", map[string]interface{}{
+			"page":  p,
+			"total": page.Total,
+		})
+
+		found := 0
+
+		for i := range page.Setlist {
+			if in.Matches(page.Setlist[i]) {
+				shows = append(shows, &page.Setlist[i])
+				found++
+			}
+		}
+
+		if page.IsLast() || (found == 0 && len(shows) > 0) {
+			break
+		}
+	}
+
+	return shows, nil
+}
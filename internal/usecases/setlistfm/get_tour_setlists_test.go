@@ -0,0 +1,105 @@
+package setlistfm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entity "github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type GetTourSetlistsUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SetlistFMClientMock
+	LoggerMock *mocks.LoggerMock
+
+	UseCase GetTourSetlistsUseCaseInterface
+}
+
+func (s *GetTourSetlistsUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SetlistFMClientMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+
+	s.UseCase = &GetTourSetlistsUseCase{SetlistFMClient: s.ClientMock, Logger: s.LoggerMock}
+}
+
+func (s *GetTourSetlistsUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+}
+
+func TestGetTourSetlistsUseCase(t *testing.T) {
+	suite.Run(t, new(GetTourSetlistsUseCaseTestSuite))
+}
+
+func setlistPage(page int, total int, tours ...string) *entity.SetlistPage {
+	out := &entity.SetlistPage{Page: page, Total: total, ItemsPerPage: 2}
+
+	for _, tour := range tours {
+		out.Setlist = append(out.Setlist, entity.Set{ID: tour, Tour: entity.Tour{Name: tour}})
+	}
+
+	return out
+}
+
+func (s *GetTourSetlistsUseCaseTestSuite) TestExecute() {
+	in := entity.GetTourSetlistsInput{ArtistMBID: "any-mbid", Tour: "this is  why"}
+
+	s.Run("Should page through the setlists until the tour is over", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 1).Return(setlistPage(1, 8, "Festival", "This Is Why"), nil)
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 2).Return(setlistPage(2, 8, "This Is Why", "This Is Why"), nil)
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 3).Return(setlistPage(3, 8, "Older", "Older"), nil)
+
+		shows, err := s.UseCase.Execute(in)
+
+		s.NoError(err)
+		s.Len(shows, 3)
+		s.ClientMock.AssertNotCalled(s.T(), "GetArtistSetlists", "any-mbid", 4)
+	})
+
+	s.Run("Should stop at the last page", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 1).Return(setlistPage(1, 2, "Other", "Other"), nil)
+
+		shows, err := s.UseCase.Execute(in)
+
+		s.NoError(err)
+		s.Empty(shows)
+		s.ClientMock.AssertNumberOfCalls(s.T(), "GetArtistSetlists", 1)
+	})
+
+	s.Run("Should not read more pages than asked for", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", mock.Anything).Return(setlistPage(1, 100, "Other", "Other"), nil)
+
+		_, err := s.UseCase.Execute(entity.GetTourSetlistsInput{ArtistMBID: "any-mbid", Tour: "any-tour", MaxPages: 3})
+
+		s.NoError(err)
+		s.ClientMock.AssertNumberOfCalls(s.T(), "GetArtistSetlists", 3)
+	})
+
+	s.Run("Should return an error when a page fails", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetArtistSetlists", "any-mbid", 1).Return(nil, errors.New("any-error"))
+
+		shows, err := s.UseCase.Execute(in)
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(shows)
+	})
+
+	s.Run("Should return an error without a tour", func() {
+		_, err := s.UseCase.Execute(entity.GetTourSetlistsInput{ArtistMBID: "any-mbid"})
+
+		s.ErrorContains(err, "tour name is empty")
+	})
+}
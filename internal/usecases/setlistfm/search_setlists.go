@@ -0,0 +1,32 @@
+package setlistfm
+
+import (
+	setlistfm_client "github.com/mathcale/setlist-to-playlist/internal/clients/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+)
+
+type SearchSetlistsUseCaseInterface interface {
+	Execute(input setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error)
+}
+
+type SearchSetlistsUseCase struct {
+	SetlistFMClient setlistfm_client.SetlistFMClientInterface
+}
+
+func NewSearchSetlistsUseCase(c setlistfm_client.SetlistFMClientInterface) SearchSetlistsUseCaseInterface {
+	return &SearchSetlistsUseCase{
+		SetlistFMClient: c,
+	}
+}
+
+func (u *SearchSetlistsUseCase) Execute(in setlistfm.SearchSetlistsInput) (*setlistfm.SetlistPage, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	if in.Page < 1 {
+		in.Page = 1
+	}
+
+	return u.SetlistFMClient.SearchSetlists(in)
+}
@@ -0,0 +1,57 @@
+package setlistfm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	entity "github.com/mathcale/setlist-to-playlist/internal/entities/setlistfm"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SearchSetlistsUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SetlistFMClientMock
+
+	UseCase SearchSetlistsUseCaseInterface
+}
+
+func (s *SearchSetlistsUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SetlistFMClientMock)
+
+	s.UseCase = NewSearchSetlistsUseCase(s.ClientMock)
+}
+
+func (s *SearchSetlistsUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+}
+
+func TestSearchSetlistsUseCase(t *testing.T) {
+	suite.Run(t, new(SearchSetlistsUseCaseTestSuite))
+}
+
+func (s *SearchSetlistsUseCaseTestSuite) TestExecute() {
+	s.Run("Should start from the first page", func() {
+		defer s.cleanMocks()
+
+		expected := &entity.SetlistPage{Page: 1, Total: 1, Setlist: []entity.Set{{ID: "any-set-id"}}}
+
+		s.ClientMock.On("SearchSetlists", entity.SearchSetlistsInput{VenueName: "any-venue", Page: 1}).Return(expected, nil)
+
+		result, err := s.UseCase.Execute(entity.SearchSetlistsInput{VenueName: "any-venue"})
+
+		s.NoError(err)
+		s.Equal(expected, result)
+	})
+
+	s.Run("Should return an error for an invalid search", func() {
+		defer s.cleanMocks()
+
+		result, err := s.UseCase.Execute(entity.SearchSetlistsInput{})
+
+		s.Error(err)
+		s.Nil(result)
+		s.ClientMock.AssertNotCalled(s.T(), "SearchSetlists")
+	})
+}
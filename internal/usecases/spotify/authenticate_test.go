@@ -62,6 +62,7 @@ func (s *SpotifyUserAuthenticationUseCaseTestSuite) TestExecute() {
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		)
 
 		pkceCodes := oauth2util.GenerateOutput{
@@ -87,6 +88,7 @@ func (s *SpotifyUserAuthenticationUseCaseTestSuite) TestExecute() {
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		)
 
 		pkceCodes := oauth2util.GenerateOutput{
@@ -59,6 +59,7 @@ func (s *SpotifyAuthCallbackUseCaseTestSuite) TestExecute() {
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		).ToOauth2Token()
 
 		r := &http.Request{
@@ -93,6 +94,7 @@ func (s *SpotifyAuthCallbackUseCaseTestSuite) TestExecute() {
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		).ToOauth2Token()
 
 		r := &http.Request{
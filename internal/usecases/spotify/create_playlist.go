@@ -34,9 +34,10 @@ func (uc *CreatePlaylistUseCase) Execute(
 	uc.Logger.Debug("Creating playlist on Spotify", map[string]interface{}{
 		"title":       input.Title,
 		"description": input.Description,
+		"visibility":  input.Visibility.String(),
 	})
 
-	output, err := uc.Client.CreatePlaylist(ctx, input.Title, input.GetDescription())
+	output, err := uc.Client.CreatePlaylist(ctx, input.Title, input.GetDescription(), input.Visibility)
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +50,7 @@ func (s *CreatePlaylistUseCaseTestSuite) TestExecute() {
 		input := entities.CreatePlaylistInput{
 			Title:       "any-title",
 			Description: &description,
+			Visibility:  entities.NewPlaylistVisibility(false, true),
 		}
 
 		expected := &entities.CreatePlaylistOutput{
@@ -59,7 +60,7 @@ func (s *CreatePlaylistUseCaseTestSuite) TestExecute() {
 
 		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
 		s.ClientMock.
-			On("CreatePlaylist", mock.Anything, input.Title, *input.Description).
+			On("CreatePlaylist", mock.Anything, input.Title, *input.Description, input.Visibility).
 			Return(expected, nil)
 
 		out, err := s.UseCase.Execute(context.Background(), input)
@@ -80,7 +81,7 @@ func (s *CreatePlaylistUseCaseTestSuite) TestExecute() {
 
 		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
 		s.ClientMock.
-			On("CreatePlaylist", mock.Anything, input.Title, *input.Description).
+			On("CreatePlaylist", mock.Anything, input.Title, *input.Description, input.Visibility).
 			Return(nil, errors.New("any-error"))
 
 		out, err := s.UseCase.Execute(context.Background(), input)
@@ -4,44 +4,168 @@ import (
 	"context"
 
 	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
 	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
 	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
 )
 
 type FetchSongsOnSpotifyUseCaseInterface interface {
-	Execute(ctx context.Context, songs []string, artist string) (*entities.FindAllSongsOutput, error)
+	Execute(ctx context.Context, input entities.FindAllSongsInput) (*entities.FindAllSongsOutput, error)
 }
 
 type FetchSongsOnSpotifyUseCase struct {
-	Client client.SpotifyClientInterface
-	Logger logger.LoggerInterface
+	Client    client.SpotifyClientInterface
+	Overrides persistence.OverridesPersistenceInterface
+	Logger    logger.LoggerInterface
 }
 
-func NewFetchSongsOnSpotifyUseCase(c client.SpotifyClientInterface, l logger.LoggerInterface) FetchSongsOnSpotifyUseCaseInterface {
+func NewFetchSongsOnSpotifyUseCase(
+	c client.SpotifyClientInterface,
+	o persistence.OverridesPersistenceInterface,
+	l logger.LoggerInterface,
+) FetchSongsOnSpotifyUseCaseInterface {
 	return &FetchSongsOnSpotifyUseCase{
-		Client: c,
-		Logger: l,
+		Client:    c,
+		Overrides: o,
+		Logger:    l,
 	}
 }
 
 func (uc *FetchSongsOnSpotifyUseCase) Execute(
 	ctx context.Context,
-	songs []string,
-	artist string,
+	input entities.FindAllSongsInput,
 ) (*entities.FindAllSongsOutput, error) {
 	uc.Logger.Debug("Fetching songs on Spotify", map[string]interface{}{
-		"songs":  songs,
-		"artist": artist,
+		"songs":  input.Songs,
+		"artist": input.Artist,
+		"policy": input.Policy,
 	})
 
-	output, err := uc.Client.FindAllSongsByName(ctx, songs, artist)
+	saved, err := uc.Overrides.Read()
 	if err != nil {
 		return nil, err
 	}
 
+	overridden := map[int]overrides.Override{}
+	for i, q := range input.Songs {
+		if o, ok := saved.Find(input.Artist, q.Title); ok {
+			overridden[i] = *o
+		}
+	}
+
+	if len(overridden) == 0 {
+		output, err := uc.Client.FindAllSongsByName(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		uc.Logger.Debug("Songs fetched on Spotify", map[string]interface{}{
+			"songs": output,
+		})
+
+		return output, nil
+	}
+
+	uc.Logger.Debug("Applying match overrides", map[string]interface{}{
+		"overrides": overridden,
+	})
+
+	results, err := uc.applyOverrides(ctx, input, overridden)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		searchIndexes []int
+		searchSongs   []entities.SongQuery
+	)
+
+	for i, q := range input.Songs {
+		if _, ok := overridden[i]; !ok {
+			searchIndexes = append(searchIndexes, i)
+			searchSongs = append(searchSongs, q)
+		}
+	}
+
+	if len(searchSongs) > 0 {
+		searchInput := input
+		searchInput.Songs = searchSongs
+
+		found, err := uc.Client.FindAllSongsByName(ctx, searchInput)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, r := range found.Results {
+			results[searchIndexes[j]] = r
+		}
+	}
+
+	output := entities.NewFindAllSongsOutput(input.Artist, results)
+
 	uc.Logger.Debug("Songs fetched on Spotify", map[string]interface{}{
 		"songs": output,
 	})
 
 	return output, nil
 }
+
+func (uc *FetchSongsOnSpotifyUseCase) applyOverrides(
+	ctx context.Context,
+	input entities.FindAllSongsInput,
+	overridden map[int]overrides.Override,
+) ([]entities.SongResult, error) {
+	results := make([]entities.SongResult, len(input.Songs))
+
+	var ids []string
+	for i := range input.Songs {
+		o, ok := overridden[i]
+		if !ok {
+			continue
+		}
+
+		results[i] = entities.SongResult{
+			Query:          input.Songs[i].Title,
+			Status:         entities.MatchStatusSkipped,
+			SearchedArtist: input.Artist,
+			Overridden:     true,
+		}
+
+		if !o.IsSkip() {
+			ids = append(ids, o.Track)
+		}
+	}
+
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	tracks, err := uc.Client.GetTracks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]entities.Song, len(tracks))
+	for _, t := range tracks {
+		byID[t.ID] = t
+	}
+
+	for i, o := range overridden {
+		if o.IsSkip() {
+			continue
+		}
+
+		song, ok := byID[o.Track]
+		if !ok {
+			results[i].Status = entities.MatchStatusNotFound
+			continue
+		}
+
+		results[i].Status = entities.MatchStatusMatched
+		results[i].Song = &song
+		results[i].Score = 1
+	}
+
+	return results, nil
+}
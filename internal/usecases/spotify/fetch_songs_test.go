@@ -8,24 +8,28 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/mathcale/setlist-to-playlist/internal/entities/overrides"
 	dto "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
 	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
 )
 
 type FetchSongsOnSpotifyUseCaseTestSuite struct {
 	suite.Suite
-	ClientMock *mocks.SpotifyClientMock
-	LoggerMock *mocks.LoggerMock
+	ClientMock    *mocks.SpotifyClientMock
+	OverridesMock *mocks.OverridesPersistenceMock
+	LoggerMock    *mocks.LoggerMock
 
 	UseCase FetchSongsOnSpotifyUseCaseInterface
 }
 
 func (s *FetchSongsOnSpotifyUseCaseTestSuite) SetupTest() {
 	s.ClientMock = new(mocks.SpotifyClientMock)
+	s.OverridesMock = new(mocks.OverridesPersistenceMock)
 	s.LoggerMock = new(mocks.LoggerMock)
 
 	s.UseCase = NewFetchSongsOnSpotifyUseCase(
 		s.ClientMock,
+		s.OverridesMock,
 		s.LoggerMock,
 	)
 }
@@ -33,6 +37,8 @@ func (s *FetchSongsOnSpotifyUseCaseTestSuite) SetupTest() {
 func (s *FetchSongsOnSpotifyUseCaseTestSuite) cleanMocks() {
 	s.ClientMock.ExpectedCalls = nil
 	s.ClientMock.Calls = nil
+	s.OverridesMock.ExpectedCalls = nil
+	s.OverridesMock.Calls = nil
 	s.LoggerMock.ExpectedCalls = nil
 	s.LoggerMock.Calls = nil
 }
@@ -45,8 +51,14 @@ func (s *FetchSongsOnSpotifyUseCaseTestSuite) TestExecute() {
 	s.Run("should fetch songs from Spotify", func() {
 		defer s.cleanMocks()
 
-		songs := []string{"any-song-title-1", "any-song-title-2", "any-song-title-3"}
-		artist := "any-artist"
+		input := dto.FindAllSongsInput{
+			Songs: []dto.SongQuery{
+				{Title: "any-song-title-1"},
+				{Title: "any-song-title-2"},
+				{Title: "any-song-title-3", OriginalArtist: "any-original-artist"},
+			},
+			Artist: "any-artist",
+		}
 
 		expected := &dto.FindAllSongsOutput{
 			Songs: []dto.Song{
@@ -57,9 +69,10 @@ func (s *FetchSongsOnSpotifyUseCaseTestSuite) TestExecute() {
 		}
 
 		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
-		s.ClientMock.On("FindAllSongsByName", mock.Anything, songs, artist).Return(expected, nil)
+		s.OverridesMock.On("Read").Return(&overrides.Overrides{}, nil)
+		s.ClientMock.On("FindAllSongsByName", mock.Anything, input).Return(expected, nil)
 
-		out, err := s.UseCase.Execute(context.Background(), songs, artist)
+		out, err := s.UseCase.Execute(context.Background(), input)
 
 		s.NoError(err)
 		s.Equal(expected, out)
@@ -68,18 +81,122 @@ func (s *FetchSongsOnSpotifyUseCaseTestSuite) TestExecute() {
 	s.Run("should return error when fetching songs from Spotify", func() {
 		defer s.cleanMocks()
 
-		songs := []string{"any-song-title-1", "any-song-title-2", "any-song-title-3"}
-		artist := "any-artist"
+		input := dto.FindAllSongsInput{
+			Songs:  []dto.SongQuery{{Title: "any-song-title-1"}, {Title: "any-song-title-2"}},
+			Artist: "any-artist",
+		}
 
 		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.OverridesMock.On("Read").Return(&overrides.Overrides{}, nil)
 		s.ClientMock.
-			On("FindAllSongsByName", mock.Anything, songs, artist).
+			On("FindAllSongsByName", mock.Anything, input).
 			Return(nil, errors.New("any-error"))
 
-		out, err := s.UseCase.Execute(context.Background(), songs, artist)
+		out, err := s.UseCase.Execute(context.Background(), input)
 
 		s.Error(err)
 		s.ErrorContains(err, "any-error")
 		s.Nil(out)
 	})
+	s.Run("should apply saved overrides and search the remaining songs", func() {
+		defer s.cleanMocks()
+
+		input := dto.FindAllSongsInput{
+			Songs: []dto.SongQuery{
+				{Title: "any-song-title-1"},
+				{Title: "any-song-title-2"},
+				{Title: "any-song-title-3"},
+			},
+			Artist: "any-artist",
+		}
+
+		saved := &overrides.Overrides{Overrides: []overrides.Override{
+			{Artist: "Any-Artist", Song: "any-song-title-1", Track: "any-override-id"},
+			{Artist: "any-artist", Song: "any-song-title-3", Track: overrides.SkipTrack},
+			{Artist: "other-artist", Song: "any-song-title-2", Track: overrides.SkipTrack},
+		}}
+
+		overridden := dto.Song{ID: "any-override-id", Title: "any-song-title-1"}
+		searched := dto.Song{ID: "any-song-id-2", Title: "any-song-title-2"}
+
+		searchInput := input
+		searchInput.Songs = []dto.SongQuery{{Title: "any-song-title-2"}}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.OverridesMock.On("Read").Return(saved, nil)
+		s.ClientMock.On("GetTracks", mock.Anything, []string{"any-override-id"}).Return([]dto.Song{overridden}, nil)
+		s.ClientMock.On("FindAllSongsByName", mock.Anything, searchInput).Return(&dto.FindAllSongsOutput{
+			Songs: []dto.Song{searched},
+			Results: []dto.SongResult{
+				{Query: "any-song-title-2", Status: dto.MatchStatusMatched, Song: &searched},
+			},
+		}, nil)
+
+		out, err := s.UseCase.Execute(context.Background(), input)
+
+		s.NoError(err)
+		s.Equal([]dto.Song{overridden, searched}, out.Songs)
+		s.Len(out.Results, 3)
+		s.True(out.Results[0].Overridden)
+		s.Equal(dto.MatchStatusSkipped, out.Results[2].Status)
+		s.True(out.Results[2].Overridden)
+	})
+
+	s.Run("should not search when every song is overridden", func() {
+		defer s.cleanMocks()
+
+		input := dto.FindAllSongsInput{
+			Songs:  []dto.SongQuery{{Title: "any-song-title-1"}},
+			Artist: "any-artist",
+		}
+
+		saved := &overrides.Overrides{Overrides: []overrides.Override{
+			{Artist: "any-artist", Song: "any-song-title-1", Track: overrides.SkipTrack},
+		}}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.OverridesMock.On("Read").Return(saved, nil)
+
+		out, err := s.UseCase.Execute(context.Background(), input)
+
+		s.NoError(err)
+		s.Empty(out.Songs)
+		s.Equal(dto.MatchStatusSkipped, out.Results[0].Status)
+		s.ClientMock.AssertNotCalled(s.T(), "FindAllSongsByName", mock.Anything, mock.Anything)
+		s.ClientMock.AssertNotCalled(s.T(), "GetTracks", mock.Anything, mock.Anything)
+	})
+
+	s.Run("should report an overridden track that no longer exists", func() {
+		defer s.cleanMocks()
+
+		input := dto.FindAllSongsInput{
+			Songs:  []dto.SongQuery{{Title: "any-song-title-1"}},
+			Artist: "any-artist",
+		}
+
+		saved := &overrides.Overrides{Overrides: []overrides.Override{
+			{Artist: "any-artist", Song: "any-song-title-1", Track: "any-override-id"},
+		}}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.OverridesMock.On("Read").Return(saved, nil)
+		s.ClientMock.On("GetTracks", mock.Anything, []string{"any-override-id"}).Return([]dto.Song{}, nil)
+
+		out, err := s.UseCase.Execute(context.Background(), input)
+
+		s.NoError(err)
+		s.Equal(dto.MatchStatusNotFound, out.Results[0].Status)
+	})
+
+	s.Run("should return error when reading the overrides fails", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.OverridesMock.On("Read").Return(nil, errors.New("any-error"))
+
+		out, err := s.UseCase.Execute(context.Background(), dto.FindAllSongsInput{})
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
 }
@@ -3,6 +3,7 @@ package gateways
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/browser"
@@ -47,7 +48,19 @@ func (gw *SpotifyUserAuthenticationUseCaseGateway) ValidatePersistedToken() (*en
 		return nil, err
 	}
 
-	return authData, authData.Validate()
+	if err := authData.Validate(); err != nil {
+		return authData, err
+	}
+
+	if missing := authData.MissingScopes(gw.Client.Scopes()); len(missing) > 0 {
+		gw.Logger.Info("The saved Spotify authorization doesn't allow this operation, asking for it again", map[string]interface{}{
+			"missingScopes": missing,
+		})
+
+		return authData, &entities.MissingScopesError{Scopes: missing}
+	}
+
+	return authData, nil
 }
 
 func (gw *SpotifyUserAuthenticationUseCaseGateway) AuthenticateUser(
@@ -69,7 +82,7 @@ func (gw *SpotifyUserAuthenticationUseCaseGateway) AuthenticateUser(
 
 	gw.Client.SetAuthenticatedClient(gw.AuthenticatedClientChannel)
 
-	if err := gw.persistToken(); err != nil {
+	if err := gw.persistToken(strings.Join(gw.Client.Scopes(), " ")); err != nil {
 		return err
 	}
 
@@ -96,14 +109,15 @@ func (gw *SpotifyUserAuthenticationUseCaseGateway) RefreshToken(
 		Client: *cl,
 	})
 
-	if err := gw.persistToken(); err != nil {
+	if err := gw.persistToken(authData.Scope); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (gw *SpotifyUserAuthenticationUseCaseGateway) persistToken() error {
+// persistToken saves the current session, falling back to the given scope when Spotify doesn't send the granted one.
+func (gw *SpotifyUserAuthenticationUseCaseGateway) persistToken(fallbackScope string) error {
 	token, err := gw.Client.CurrentSession()
 	if err != nil {
 		return err
@@ -113,11 +127,17 @@ func (gw *SpotifyUserAuthenticationUseCaseGateway) persistToken() error {
 		"token": token,
 	})
 
+	scope, _ := token.Extra("scope").(string)
+	if scope == "" {
+		scope = fallbackScope
+	}
+
 	authData := entities.NewSpotifyUserAuthData(
 		token.AccessToken,
 		token.Expiry.Format(time.RFC3339),
 		token.RefreshToken,
 		token.TokenType,
+		scope,
 	)
 
 	if err := gw.Persistence.Write(authData); err != nil {
@@ -66,9 +66,11 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestValidatePersisted
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		)
 
 		s.PersistenceMock.On("Read").Return(&authData, nil)
+		s.ClientMock.On("Scopes").Return(client.DefaultScopes)
 
 		result, err := s.Gateway.ValidatePersistedToken()
 
@@ -76,6 +78,30 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestValidatePersisted
 		s.Equal(&authData, result)
 	})
 
+	s.Run("Should return missing scopes error when token lacks a required scope", func() {
+		defer s.cleanMocks()
+
+		authData := entity.NewSpotifyUserAuthData(
+			"any-access-token",
+			"9999-12-31T23:59:59Z",
+			"any-refresh-token",
+			"any-token-type",
+			"user-read-email playlist-modify-public",
+		)
+
+		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return(nil)
+		s.PersistenceMock.On("Read").Return(&authData, nil)
+		s.ClientMock.
+			On("Scopes").
+			Return(append(client.DefaultScopes, entity.ScopePlaylistModifyPrivate))
+
+		_, err := s.Gateway.ValidatePersistedToken()
+
+		var missingErr *entity.MissingScopesError
+		s.ErrorAs(err, &missingErr)
+		s.Equal([]string{entity.ScopePlaylistModifyPrivate}, missingErr.Scopes)
+	})
+
 	s.Run("Should return error when persistence read fails", func() {
 		defer s.cleanMocks()
 
@@ -102,6 +128,7 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestAuthenticateUser(
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		).ToOauth2Token()
 
 		authURL := "any-auth-url"
@@ -110,6 +137,7 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestAuthenticateUser(
 		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return(nil)
 		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return(nil)
 		s.ClientMock.On("GetAuthURL", state, pkceCodes).Return(authURL)
+		s.ClientMock.On("Scopes").Return(client.DefaultScopes)
 		s.ClientMock.On("SetAuthenticatedClient", mock.Anything).Return(nil)
 		s.ClientMock.On("CurrentSession").Return(authData, nil)
 		s.PersistenceMock.On("Write", mock.Anything).Return(nil)
@@ -122,6 +150,9 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestAuthenticateUser(
 
 		s.NoError(err)
 
+		persisted := s.PersistenceMock.Calls[0].Arguments.Get(0).(entity.SpotifyUserAuthData)
+		s.Equal("user-read-email playlist-modify-public", persisted.Scope)
+
 	})
 
 	s.Run("Should return error while persisting token", func() {
@@ -137,6 +168,7 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestAuthenticateUser(
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		).ToOauth2Token()
 
 		authURL := "any-auth-url"
@@ -145,6 +177,7 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestAuthenticateUser(
 		s.LoggerMock.On("Info", mock.Anything, mock.Anything).Return(nil)
 		s.LoggerMock.On("Warn", mock.Anything, mock.Anything).Return(nil)
 		s.ClientMock.On("GetAuthURL", state, pkceCodes).Return(authURL)
+		s.ClientMock.On("Scopes").Return(client.DefaultScopes)
 		s.ClientMock.On("SetAuthenticatedClient", mock.Anything).Return(nil)
 		s.ClientMock.On("CurrentSession").Return(authData, nil)
 		s.PersistenceMock.On("Write", mock.Anything).Return(errors.New("any-error"))
@@ -168,6 +201,7 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestRefreshToken() {
 			"9999-12-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		)
 
 		tok, _ := authData.ToOauth2Token()
@@ -191,6 +225,7 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestRefreshToken() {
 			"2024-01-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		)
 
 		err := errors.New("any-error")
@@ -211,6 +246,7 @@ func (s *SpotifyUserAuthenticationUseCaseGatewayTestSuite) TestRefreshToken() {
 			"9999-01-31T23:59:59Z",
 			"any-refresh-token",
 			"any-token-type",
+			"",
 		)
 
 		tok, _ := authData.ToOauth2Token()
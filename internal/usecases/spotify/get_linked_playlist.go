@@ -0,0 +1,34 @@
+package spotify
+
+import (
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+)
+
+type GetLinkedPlaylistUseCaseInterface interface {
+	Execute(setlistID string) (*entities.PlaylistLink, error)
+}
+
+type GetLinkedPlaylistUseCase struct {
+	Persistence persistence.PlaylistLinksPersistenceInterface
+}
+
+func NewGetLinkedPlaylistUseCase(p persistence.PlaylistLinksPersistenceInterface) GetLinkedPlaylistUseCaseInterface {
+	return &GetLinkedPlaylistUseCase{
+		Persistence: p,
+	}
+}
+
+func (uc *GetLinkedPlaylistUseCase) Execute(setlistID string) (*entities.PlaylistLink, error) {
+	links, err := uc.Persistence.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	link, ok := links.Get(setlistID)
+	if !ok {
+		return nil, nil
+	}
+
+	return link, nil
+}
@@ -0,0 +1,52 @@
+package spotify
+
+import (
+	"time"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/infra/persistence"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type LinkPlaylistUseCaseInterface interface {
+	Execute(setlistID string, playlist entities.CreatePlaylistOutput) error
+}
+
+type LinkPlaylistUseCase struct {
+	Persistence persistence.PlaylistLinksPersistenceInterface
+	Logger      logger.LoggerInterface
+	Now         func() time.Time
+}
+
+func NewLinkPlaylistUseCase(
+	p persistence.PlaylistLinksPersistenceInterface,
+	l logger.LoggerInterface,
+) LinkPlaylistUseCaseInterface {
+	return &LinkPlaylistUseCase{
+		Persistence: p,
+		Logger:      l,
+		Now:         time.Now,
+	}
+}
+
+func (uc *LinkPlaylistUseCase) Execute(setlistID string, playlist entities.CreatePlaylistOutput) error {
+	uc.Logger.Debug("Linking playlist to setlist", map[string]interface{}{
+		"setlist_id":  setlistID,
+		"playlist_id": playlist.ID,
+	})
+
+	links, err := uc.Persistence.Read()
+	if err != nil {
+		return err
+	}
+
+	links.Set(entities.PlaylistLink{
+		SetlistID:  setlistID,
+		PlaylistID: playlist.ID,
+		URL:        playlist.URL,
+		Visibility: playlist.Visibility.String(),
+		UpdatedAt:  uc.Now(),
+	})
+
+	return uc.Persistence.Write(*links)
+}
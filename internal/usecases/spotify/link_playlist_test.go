@@ -0,0 +1,110 @@
+package spotify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type PlaylistLinksUseCasesTestSuite struct {
+	suite.Suite
+	PersistenceMock *mocks.PlaylistLinksPersistenceMock
+	LoggerMock      *mocks.LoggerMock
+	Now             time.Time
+
+	GetUseCase  GetLinkedPlaylistUseCaseInterface
+	LinkUseCase *LinkPlaylistUseCase
+}
+
+func (s *PlaylistLinksUseCasesTestSuite) SetupTest() {
+	s.PersistenceMock = new(mocks.PlaylistLinksPersistenceMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+	s.Now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	s.GetUseCase = NewGetLinkedPlaylistUseCase(s.PersistenceMock)
+	s.LinkUseCase = NewLinkPlaylistUseCase(s.PersistenceMock, s.LoggerMock).(*LinkPlaylistUseCase)
+	s.LinkUseCase.Now = func() time.Time { return s.Now }
+}
+
+func (s *PlaylistLinksUseCasesTestSuite) cleanMocks() {
+	s.PersistenceMock.ExpectedCalls = nil
+	s.PersistenceMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestPlaylistLinksUseCases(t *testing.T) {
+	suite.Run(t, new(PlaylistLinksUseCasesTestSuite))
+}
+
+func (s *PlaylistLinksUseCasesTestSuite) TestGetLinkedPlaylist() {
+	s.Run("should return the linked playlist", func() {
+		defer s.cleanMocks()
+
+		links := &entities.PlaylistLinks{}
+		links.Set(entities.PlaylistLink{SetlistID: "any-setlist-id", PlaylistID: "any-playlist-id"})
+
+		s.PersistenceMock.On("Read").Return(links, nil)
+
+		out, err := s.GetUseCase.Execute("any-setlist-id")
+
+		s.NoError(err)
+		s.Equal("any-playlist-id", out.PlaylistID)
+	})
+
+	s.Run("should return nothing when the setlist was never converted", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(&entities.PlaylistLinks{}, nil)
+
+		out, err := s.GetUseCase.Execute("any-setlist-id")
+
+		s.NoError(err)
+		s.Nil(out)
+	})
+
+	s.Run("should return error when reading fails", func() {
+		defer s.cleanMocks()
+
+		s.PersistenceMock.On("Read").Return(nil, errors.New("any-error"))
+
+		out, err := s.GetUseCase.Execute("any-setlist-id")
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
+
+func (s *PlaylistLinksUseCasesTestSuite) TestLinkPlaylist() {
+	s.Run("should save the link", func() {
+		defer s.cleanMocks()
+
+		expected := entities.PlaylistLinks{}
+		expected.Set(entities.PlaylistLink{
+			SetlistID:  "any-setlist-id",
+			PlaylistID: "any-playlist-id",
+			URL:        "any-playlist-url",
+			Visibility: "private",
+			UpdatedAt:  s.Now,
+		})
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.PersistenceMock.On("Read").Return(&entities.PlaylistLinks{}, nil)
+		s.PersistenceMock.On("Write", expected).Return(nil)
+
+		err := s.LinkUseCase.Execute("any-setlist-id", entities.CreatePlaylistOutput{
+			ID:         "any-playlist-id",
+			URL:        "any-playlist-url",
+			Visibility: entities.NewPlaylistVisibility(true, false),
+		})
+
+		s.NoError(err)
+		s.PersistenceMock.AssertExpectations(s.T())
+	})
+}
@@ -0,0 +1,45 @@
+package spotify
+
+import (
+	"context"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type SearchTracksOnSpotifyUseCaseInterface interface {
+	Execute(ctx context.Context, query string) ([]entities.Song, error)
+}
+
+type SearchTracksOnSpotifyUseCase struct {
+	Client client.SpotifyClientInterface
+	Logger logger.LoggerInterface
+}
+
+func NewSearchTracksOnSpotifyUseCase(
+	c client.SpotifyClientInterface,
+	l logger.LoggerInterface,
+) SearchTracksOnSpotifyUseCaseInterface {
+	return &SearchTracksOnSpotifyUseCase{
+		Client: c,
+		Logger: l,
+	}
+}
+
+func (uc *SearchTracksOnSpotifyUseCase) Execute(ctx context.Context, query string) ([]entities.Song, error) {
+	uc.Logger.Debug("Searching tracks on Spotify", map[string]interface{}{
+		"query": query,
+	})
+
+	songs, err := uc.Client.SearchTracks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Debug("Tracks found on Spotify", map[string]interface{}{
+		"count": len(songs),
+	})
+
+	return songs, nil
+}
@@ -0,0 +1,73 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SearchTracksOnSpotifyUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SpotifyClientMock
+	LoggerMock *mocks.LoggerMock
+
+	UseCase SearchTracksOnSpotifyUseCaseInterface
+}
+
+func (s *SearchTracksOnSpotifyUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SpotifyClientMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.UseCase = NewSearchTracksOnSpotifyUseCase(
+		s.ClientMock,
+		s.LoggerMock,
+	)
+}
+
+func (s *SearchTracksOnSpotifyUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestSearchTracksOnSpotifyUseCase(t *testing.T) {
+	suite.Run(t, new(SearchTracksOnSpotifyUseCaseTestSuite))
+}
+
+func (s *SearchTracksOnSpotifyUseCaseTestSuite) TestExecute() {
+	s.Run("should search tracks on Spotify", func() {
+		defer s.cleanMocks()
+
+		expected := []entities.Song{
+			{ID: "any-song-id-1", Title: "any-song-title-1", Album: "any-song-album-1"},
+		}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.On("SearchTracks", mock.Anything, "any-query").Return(expected, nil)
+
+		out, err := s.UseCase.Execute(context.Background(), "any-query")
+
+		s.NoError(err)
+		s.Equal(expected, out)
+	})
+
+	s.Run("should return error when searching tracks on Spotify", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.On("SearchTracks", mock.Anything, "any-query").Return(nil, errors.New("any-error"))
+
+		out, err := s.UseCase.Execute(context.Background(), "any-query")
+
+		s.Error(err)
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
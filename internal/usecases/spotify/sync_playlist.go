@@ -0,0 +1,70 @@
+package spotify
+
+import (
+	"context"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type SyncPlaylistUseCaseInterface interface {
+	Execute(ctx context.Context, input entities.SyncPlaylistInput) (*entities.PlaylistDiff, error)
+}
+
+type SyncPlaylistUseCase struct {
+	Client client.SpotifyClientInterface
+	Logger logger.LoggerInterface
+}
+
+func NewSyncPlaylistUseCase(
+	c client.SpotifyClientInterface,
+	l logger.LoggerInterface,
+) SyncPlaylistUseCaseInterface {
+	return &SyncPlaylistUseCase{
+		Client: c,
+		Logger: l,
+	}
+}
+
+func (uc *SyncPlaylistUseCase) Execute(
+	ctx context.Context,
+	input entities.SyncPlaylistInput,
+) (*entities.PlaylistDiff, error) {
+	current, err := uc.Client.GetPlaylistTracks(ctx, input.PlaylistID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := entities.DiffPlaylist(current, input.Tracks)
+
+	uc.Logger.Debug("Syncing playlist on Spotify", map[string]interface{}{
+		"playlist_id": input.PlaylistID,
+		"removed":     len(diff.Removed),
+		"added":       len(diff.Added),
+		"moves":       len(diff.Moves),
+	})
+
+	if len(diff.Removed) > 0 {
+		if err := uc.Client.RemovePlaylistTracks(ctx, input.PlaylistID, diff.Removed); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(diff.Added) > 0 {
+		if err := uc.Client.AddTracksToPlaylist(ctx, entities.AddTracksToPlaylistClientInput{
+			PlaylistID: input.PlaylistID,
+			Tracks:     diff.Added,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, m := range diff.Moves {
+		if err := uc.Client.ReorderPlaylistTrack(ctx, input.PlaylistID, m.From, m.InsertBefore); err != nil {
+			return nil, err
+		}
+	}
+
+	return &diff, nil
+}
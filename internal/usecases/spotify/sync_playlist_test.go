@@ -0,0 +1,100 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type SyncPlaylistUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SpotifyClientMock
+	LoggerMock *mocks.LoggerMock
+
+	UseCase SyncPlaylistUseCaseInterface
+}
+
+func (s *SyncPlaylistUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SpotifyClientMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.UseCase = NewSyncPlaylistUseCase(s.ClientMock, s.LoggerMock)
+}
+
+func (s *SyncPlaylistUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestSyncPlaylistUseCase(t *testing.T) {
+	suite.Run(t, new(SyncPlaylistUseCaseTestSuite))
+}
+
+func (s *SyncPlaylistUseCaseTestSuite) TestExecute() {
+	a := entities.Song{ID: "a", Title: "any-song-a"}
+	b := entities.Song{ID: "b", Title: "any-song-b"}
+	c := entities.Song{ID: "c", Title: "any-song-c"}
+	d := entities.Song{ID: "d", Title: "any-song-d"}
+
+	s.Run("should apply only the diff", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.On("GetPlaylistTracks", mock.Anything, "any-playlist-id").Return([]entities.Song{a, b, c}, nil)
+		s.ClientMock.
+			On("RemovePlaylistTracks", mock.Anything, "any-playlist-id", []entities.PlaylistItem{{Position: 1, Song: b}}).
+			Return(nil)
+		s.ClientMock.On("AddTracksToPlaylist", mock.Anything, entities.AddTracksToPlaylistClientInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     []entities.Song{d},
+		}).Return(nil)
+		s.ClientMock.On("ReorderPlaylistTrack", mock.Anything, "any-playlist-id", 1, 0).Return(nil)
+
+		diff, err := s.UseCase.Execute(context.Background(), entities.SyncPlaylistInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     []entities.Song{c, a, d},
+		})
+
+		s.NoError(err)
+		s.Equal([]entities.Song{d}, diff.Added)
+		s.Len(diff.Removed, 1)
+		s.Equal([]entities.Song{c}, diff.Reordered())
+		s.ClientMock.AssertExpectations(s.T())
+	})
+
+	s.Run("should not touch the playlist when nothing changed", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.On("GetPlaylistTracks", mock.Anything, "any-playlist-id").Return([]entities.Song{a, b}, nil)
+
+		diff, err := s.UseCase.Execute(context.Background(), entities.SyncPlaylistInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     []entities.Song{a, b},
+		})
+
+		s.NoError(err)
+		s.True(diff.IsEmpty())
+		s.ClientMock.AssertNotCalled(s.T(), "RemovePlaylistTracks", mock.Anything, mock.Anything, mock.Anything)
+		s.ClientMock.AssertNotCalled(s.T(), "AddTracksToPlaylist", mock.Anything, mock.Anything)
+	})
+
+	s.Run("should return error when the playlist cannot be read", func() {
+		defer s.cleanMocks()
+
+		s.ClientMock.On("GetPlaylistTracks", mock.Anything, "any-playlist-id").Return(nil, errors.New("any-error"))
+
+		diff, err := s.UseCase.Execute(context.Background(), entities.SyncPlaylistInput{PlaylistID: "any-playlist-id"})
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(diff)
+	})
+}
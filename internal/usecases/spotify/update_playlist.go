@@ -0,0 +1,56 @@
+package spotify
+
+import (
+	"context"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type UpdatePlaylistUseCaseInterface interface {
+	Execute(ctx context.Context, input entities.UpdatePlaylistInput) (*entities.CreatePlaylistOutput, error)
+}
+
+type UpdatePlaylistUseCase struct {
+	Client client.SpotifyClientInterface
+	Logger logger.LoggerInterface
+}
+
+func NewUpdatePlaylistUseCase(
+	c client.SpotifyClientInterface,
+	l logger.LoggerInterface,
+) UpdatePlaylistUseCaseInterface {
+	return &UpdatePlaylistUseCase{
+		Client: c,
+		Logger: l,
+	}
+}
+
+func (uc *UpdatePlaylistUseCase) Execute(
+	ctx context.Context,
+	input entities.UpdatePlaylistInput,
+) (*entities.CreatePlaylistOutput, error) {
+	uc.Logger.Debug("Updating playlist on Spotify", map[string]interface{}{
+		"playlist_id": input.PlaylistID,
+		"tracks":      input.Tracks,
+	})
+
+	playlist, err := uc.Client.GetPlaylist(ctx, input.PlaylistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.Client.ReplacePlaylistTracks(ctx, entities.AddTracksToPlaylistClientInput{
+		PlaylistID: playlist.ID,
+		Tracks:     input.Tracks,
+	}); err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Debug("Playlist updated on Spotify", map[string]interface{}{
+		"playlist_id": playlist.ID,
+	})
+
+	return playlist, nil
+}
@@ -0,0 +1,107 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type UpdatePlaylistUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SpotifyClientMock
+	LoggerMock *mocks.LoggerMock
+
+	UseCase UpdatePlaylistUseCaseInterface
+}
+
+func (s *UpdatePlaylistUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SpotifyClientMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.UseCase = NewUpdatePlaylistUseCase(
+		s.ClientMock,
+		s.LoggerMock,
+	)
+}
+
+func (s *UpdatePlaylistUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestUpdatePlaylistUseCase(t *testing.T) {
+	suite.Run(t, new(UpdatePlaylistUseCaseTestSuite))
+}
+
+func (s *UpdatePlaylistUseCaseTestSuite) TestExecute() {
+	tracks := []entities.Song{
+		{ID: "any-song-id-1", Title: "any-song-1"},
+		{ID: "any-song-id-2", Title: "any-song-2"},
+	}
+
+	s.Run("should replace the playlist tracks", func() {
+		defer s.cleanMocks()
+
+		playlist := &entities.CreatePlaylistOutput{
+			ID:  "any-playlist-id",
+			URL: "any-playlist-url",
+		}
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.On("GetPlaylist", mock.Anything, "any-playlist-id").Return(playlist, nil)
+		s.ClientMock.On("ReplacePlaylistTracks", mock.Anything, entities.AddTracksToPlaylistClientInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     tracks,
+		}).Return(nil)
+
+		out, err := s.UseCase.Execute(context.Background(), entities.UpdatePlaylistInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     tracks,
+		})
+
+		s.NoError(err)
+		s.Equal(playlist, out)
+	})
+
+	s.Run("should return error when the playlist can't be found", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.On("GetPlaylist", mock.Anything, "any-playlist-id").Return(nil, errors.New("any-error"))
+
+		out, err := s.UseCase.Execute(context.Background(), entities.UpdatePlaylistInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     tracks,
+		})
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+		s.ClientMock.AssertNotCalled(s.T(), "ReplacePlaylistTracks", mock.Anything, mock.Anything)
+	})
+
+	s.Run("should return error when replacing the tracks fails", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.
+			On("GetPlaylist", mock.Anything, "any-playlist-id").
+			Return(&entities.CreatePlaylistOutput{ID: "any-playlist-id"}, nil)
+		s.ClientMock.On("ReplacePlaylistTracks", mock.Anything, mock.Anything).Return(errors.New("any-error"))
+
+		out, err := s.UseCase.Execute(context.Background(), entities.UpdatePlaylistInput{
+			PlaylistID: "any-playlist-id",
+			Tracks:     tracks,
+		})
+
+		s.ErrorContains(err, "any-error")
+		s.Nil(out)
+	})
+}
@@ -0,0 +1,46 @@
+package spotify
+
+import (
+	"context"
+
+	client "github.com/mathcale/setlist-to-playlist/internal/clients/spotify"
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/pkg/logger"
+)
+
+type UploadPlaylistCoverUseCaseInterface interface {
+	Execute(ctx context.Context, input entities.UploadPlaylistCoverInput) error
+}
+
+type UploadPlaylistCoverUseCase struct {
+	Client client.SpotifyClientInterface
+	Logger logger.LoggerInterface
+}
+
+func NewUploadPlaylistCoverUseCase(
+	c client.SpotifyClientInterface,
+	l logger.LoggerInterface,
+) UploadPlaylistCoverUseCaseInterface {
+	return &UploadPlaylistCoverUseCase{
+		Client: c,
+		Logger: l,
+	}
+}
+
+func (uc *UploadPlaylistCoverUseCase) Execute(
+	ctx context.Context,
+	input entities.UploadPlaylistCoverInput,
+) error {
+	uc.Logger.Debug("Uploading playlist cover to Spotify", map[string]interface{}{
+		"playlist_id": input.PlaylistID,
+		"bytes":       len(input.Image),
+	})
+
+	if err := uc.Client.UploadPlaylistCover(ctx, input.PlaylistID, input.Image); err != nil {
+		return err
+	}
+
+	uc.Logger.Debug("Playlist cover uploaded to Spotify", nil)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	entities "github.com/mathcale/setlist-to-playlist/internal/entities/spotify"
+	"github.com/mathcale/setlist-to-playlist/internal/tests/mocks"
+)
+
+type UploadPlaylistCoverUseCaseTestSuite struct {
+	suite.Suite
+	ClientMock *mocks.SpotifyClientMock
+	LoggerMock *mocks.LoggerMock
+
+	UseCase UploadPlaylistCoverUseCaseInterface
+}
+
+func (s *UploadPlaylistCoverUseCaseTestSuite) SetupTest() {
+	s.ClientMock = new(mocks.SpotifyClientMock)
+	s.LoggerMock = new(mocks.LoggerMock)
+
+	s.UseCase = NewUploadPlaylistCoverUseCase(
+		s.ClientMock,
+		s.LoggerMock,
+	)
+}
+
+func (s *UploadPlaylistCoverUseCaseTestSuite) cleanMocks() {
+	s.ClientMock.ExpectedCalls = nil
+	s.ClientMock.Calls = nil
+	s.LoggerMock.ExpectedCalls = nil
+	s.LoggerMock.Calls = nil
+}
+
+func TestUploadPlaylistCoverUseCase(t *testing.T) {
+	suite.Run(t, new(UploadPlaylistCoverUseCaseTestSuite))
+}
+
+func (s *UploadPlaylistCoverUseCaseTestSuite) TestExecute() {
+	input := entities.UploadPlaylistCoverInput{
+		PlaylistID: "any-playlist-id",
+		Image:      []byte("any-jpeg"),
+	}
+
+	s.Run("should upload the cover", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.On("UploadPlaylistCover", mock.Anything, input.PlaylistID, input.Image).Return(nil)
+
+		err := s.UseCase.Execute(context.Background(), input)
+
+		s.NoError(err)
+		s.ClientMock.AssertExpectations(s.T())
+	})
+
+	s.Run("should return error when uploading the cover", func() {
+		defer s.cleanMocks()
+
+		s.LoggerMock.On("Debug", mock.Anything, mock.Anything).Return()
+		s.ClientMock.
+			On("UploadPlaylistCover", mock.Anything, input.PlaylistID, input.Image).
+			Return(errors.New("any-error"))
+
+		err := s.UseCase.Execute(context.Background(), input)
+
+		s.ErrorContains(err, "any-error")
+	})
+}